@@ -5,12 +5,16 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/app"
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/config"
 	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
 )
 
+// shutdownTracingTimeout bounds flushing of spans, so unreachable collector does not hang shutdown.
+const shutdownTracingTimeout = 5 * time.Second
+
 func main() {
 	cfg := config.MustLoad()
 
@@ -22,17 +26,45 @@ func main() {
 	// Closing DBs
 	defer application.Pg.Close(ctx)
 	defer application.Rdb.Close()
+	defer func() {
+		ctx, cancel := context.WithTimeout(ctx, shutdownTracingTimeout)
+		defer cancel()
+
+		if err := application.ShutdownTracing(ctx); err != nil {
+			log.Error("failed to shutdown tracing", sl.Err(err))
+		}
+	}()
 
 	go func() { application.GRPCServer.MustRun(ctx) }()
 
 	go func() { application.HTTPServer.MustRun(ctx) }()
 
+	go func() { application.Anonymizer.MustRun(ctx) }()
+
+	go func() { application.Expirer.MustRun(ctx) }()
+
+	go func() { application.Exporter.MustRun(ctx) }()
+
+	go func() { application.Audit.MustRun(ctx) }()
+
+	go func() { application.Health.MustRun(ctx) }()
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
 	<-stop
 
+	// Load balancers stop sending requests once service is not serving
+	application.Health.Shutdown()
+	time.Sleep(application.ShutdownDelay)
+
 	// Stopping server
 	application.GRPCServer.Stop(ctx)
 	application.HTTPServer.Stop(ctx)
+	application.Anonymizer.Stop(ctx)
+	application.Expirer.Stop(ctx)
+	// Exports requested before servers stopped are built
+	application.Exporter.Stop(ctx)
+	// Audit writer goes last, so events of stopped servers are written
+	application.Audit.Stop(ctx)
 }
@@ -0,0 +1,82 @@
+package anonymizer
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+)
+
+type Anonymizer interface {
+	AnonymizeDeletedUsers(ctx context.Context) (int, error)
+}
+
+// App periodically anonymizes users whose deletion grace period has expired.
+type App struct {
+	anonymizer Anonymizer
+	interval   time.Duration
+	done       chan struct{}
+	stopped    chan struct{}
+	log        *slog.Logger
+}
+
+func New(anonymizer Anonymizer, interval time.Duration, log *slog.Logger) *App {
+	return &App{
+		anonymizer: anonymizer,
+		interval:   interval,
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+		log:        log,
+	}
+}
+
+func (a *App) MustRun(ctx context.Context) {
+	if err := a.Run(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (a *App) Run(ctx context.Context) error {
+	defer close(a.stopped)
+
+	a.log.Info("anonymizer started", slog.Duration("interval", a.interval))
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		a.anonymize(ctx)
+
+		select {
+		case <-a.done:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *App) anonymize(ctx context.Context) {
+	count, err := a.anonymizer.AnonymizeDeletedUsers(ctx)
+	if err != nil {
+		a.log.Error("failed to anonymize deleted users", sl.Err(err))
+		return
+	}
+
+	if count > 0 {
+		a.log.Info("deleted users anonymized", slog.Int("count", count))
+	}
+}
+
+func (a *App) Stop(ctx context.Context) {
+	a.log.Info("stopping anonymizer")
+
+	close(a.done)
+
+	select {
+	case <-a.stopped:
+	case <-ctx.Done():
+	}
+}
@@ -3,13 +3,23 @@ package app
 import (
 	"context"
 	"log/slog"
+	"time"
 
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/app/anonymizer"
+	auditapp "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/app/audit"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/app/expirer"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/app/exporter"
 	grpcapp "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/app/grpc"
 	httpapp "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/app/http"
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/config"
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/clientip"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/health"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/metrics"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/policy"
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/postgres"
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/redis"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/tracing"
 	userservice "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/service"
 	userstore "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/store"
 )
@@ -17,11 +27,31 @@ import (
 type App struct {
 	GRPCServer *grpcapp.App
 	HTTPServer *httpapp.App
+	Anonymizer *anonymizer.App
+	Expirer    *expirer.App
+	Exporter   *exporter.App
+	Audit      *auditapp.App
+	Health     *health.Checker
 	Pg         *postgres.Postgres
 	Rdb        *redis.Redis
+	// ShutdownDelay is how long NOT_SERVING is reported before servers are stopped
+	ShutdownDelay time.Duration
+	// ShutdownTracing flushes spans which are not exported yet
+	ShutdownTracing func(context.Context) error
 }
 
 func New(ctx context.Context, cfg *config.Config, log *slog.Logger) *App {
+	// Tracing goes first, so clients below are instrumented with configured provider
+	shutdownTracing, err := tracing.New(ctx, tracing.Config{
+		Enabled:     cfg.Tracing.Enabled,
+		ServiceName: cfg.Tracing.ServiceName,
+		Endpoint:    cfg.Tracing.Endpoint,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		panic(err)
+	}
+
 	// Postgres connection
 	pg, err := postgres.New(ctx, cfg.DatabaseURL, log)
 	if err != nil {
@@ -34,37 +64,148 @@ func New(ctx context.Context, cfg *config.Config, log *slog.Logger) *App {
 		panic(err)
 	}
 
+	// Pool stats
+	metrics.RegisterPgxPool(pg.DB)
+	metrics.RegisterRedisPool(rdb.Client)
+
 	// Auth config
 	authConfig := model.AuthConfig{
 		Secret:          cfg.Auth.JwtSecret,
 		AccessTokenTTL:  cfg.Auth.AccessTokenTTL,
 		RefreshTokenTTL: cfg.Auth.RefreshTokenTTL,
+		AdminApproval:   cfg.Auth.AdminApproval.Enabled,
+		AdminRequestTTL: cfg.Auth.AdminApproval.RequestTTL,
+
+		DeletionGracePeriod: cfg.Deletion.GracePeriod,
+		ExportTTL:           cfg.Export.TTL,
+		ReservedPseudonyms:  cfg.Pseudonym.Reserved,
+		BlockedPseudonyms:   cfg.Pseudonym.Blocked,
+		PseudonymCooldown:   cfg.Pseudonym.ChangeCooldown,
+		FormerPseudonymTTL:  cfg.Pseudonym.FormerTTL,
+		MetadataMaxSize:     cfg.Metadata.MaxSize,
+
+		SearchSimilarityThreshold: cfg.Search.SimilarityThreshold,
+		BatchMaxIDs:               cfg.Batch.MaxIDs,
+	}
+
+	for _, client := range cfg.Metadata.Clients {
+		authConfig.ServiceClients = append(authConfig.ServiceClients, model.ServiceClient{
+			ID:  client.ID,
+			Key: client.Key,
+		})
+	}
+
+	for _, namespace := range cfg.Metadata.Namespaces {
+		authConfig.MetadataNamespaces = append(authConfig.MetadataNamespaces, model.MetadataNamespace{
+			Name:   namespace.Name,
+			Owner:  namespace.Owner,
+			Schema: namespace.Schema,
+			Claims: namespace.Claims,
+		})
 	}
 
 	// Store
 	userStore := userstore.NewUserStore(pg, log)
+	var users interface {
+		userservice.UserModifier
+		userservice.UserProvider
+	} = userStore
+	if cfg.UserCache.TTL > 0 {
+		users = userstore.NewCachedUserStore(userStore, rdb,
+			time.Second*time.Duration(cfg.UserCache.TTL), time.Second*time.Duration(cfg.UserCache.NegativeTTL), log)
+	}
 	refreshTokenStore := userstore.NewRefreshTokenStore(rdb)
+	organizationStore := userstore.NewOrganizationStore(pg, log)
+	eventStore := userstore.NewEventStore(rdb)
+	exportStore := userstore.NewExportStore(rdb)
+	auditStore := userstore.NewAuditStore(pg, log)
+
+	// Audit writer
+	auditApp := auditapp.New(auditStore, auditapp.Config{
+		BufferSize:      cfg.Audit.BufferSize,
+		BatchSize:       cfg.Audit.BatchSize,
+		Retention:       time.Minute * time.Duration(cfg.Audit.Retention),
+		CleanupInterval: time.Minute * time.Duration(cfg.Audit.CleanupInterval),
+	}, log)
+
+	// Builder of personal data exports
+	exporterApp := exporter.New(exporter.Config{
+		Workers:   cfg.Export.Workers,
+		QueueSize: cfg.Export.QueueSize,
+	}, log)
 
 	// Service
 	userService := userservice.New(
-		userStore,
-		userStore,
+		users,
+		users,
 		refreshTokenStore,
 		refreshTokenStore,
+		organizationStore,
+		organizationStore,
+		eventStore,
+		exportStore,
+		exportStore,
+		exporterApp,
+		auditApp,
+		auditStore,
 		authConfig,
 		log,
 	)
 
+	// Readiness of dependencies
+	checker := health.New(map[string]health.Check{
+		"postgres": pg.DB.Ping,
+		"redis": func(ctx context.Context) error {
+			return rdb.Ping(ctx).Err()
+		},
+	}, time.Second*time.Duration(cfg.Health.Timeout), time.Second*time.Duration(cfg.Health.Interval), log)
+
+	// Authorization policies
+	var rules []policy.Rule
+	for _, p := range cfg.Authorization.Policies {
+		rules = append(rules, policy.Rule{
+			Name:       p.Name,
+			Methods:    p.Methods,
+			Effect:     p.Effect,
+			Expression: p.Expression,
+		})
+	}
+
+	policyEngine, err := policy.New(rules)
+	if err != nil {
+		panic(err)
+	}
+
+	// Proxies in front of the service, they set x-forwarded-for
+	trustedProxies, err := clientip.ParsePrefixes(cfg.Audit.TrustedProxies)
+	if err != nil {
+		panic(err)
+	}
+
 	// gRPC server
-	gRPCApp := grpcapp.New(ctx, cfg, userService, log)
+	gRPCApp := grpcapp.New(ctx, cfg, userService, checker, policyEngine, trustedProxies, log)
 
 	// HTTP server
-	httpServer := httpapp.New(ctx, cfg, log)
+	httpServer := httpapp.New(ctx, cfg, userService, checker, policyEngine, trustedProxies, log)
+
+	// Anonymizer of deleted users
+	anonymizerApp := anonymizer.New(userService, time.Minute*time.Duration(cfg.Deletion.AnonymizeInterval), log)
+
+	// Expirer of stale admin requests
+	expirerApp := expirer.New(userService, time.Minute*time.Duration(cfg.Auth.AdminApproval.ExpireInterval), log)
 
 	return &App{
 		GRPCServer: gRPCApp,
 		HTTPServer: httpServer,
+		Anonymizer: anonymizerApp,
+		Expirer:    expirerApp,
+		Exporter:   exporterApp,
+		Audit:      auditApp,
+		Health:     checker,
 		Pg:         pg,
 		Rdb:        rdb,
+
+		ShutdownDelay:   time.Second * time.Duration(cfg.Health.ShutdownDelay),
+		ShutdownTracing: shutdownTracing,
 	}
 }
@@ -0,0 +1,152 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/metrics"
+)
+
+// flushInterval bounds how long recorded event waits for its batch to fill up.
+const flushInterval = time.Second
+
+type AuditModifier interface {
+	SaveAuditEvents(ctx context.Context, events []model.AuditEvent) error
+	DeleteAuditEventsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type Config struct {
+	// BufferSize is number of events waiting to be written, events recorded to full buffer are dropped
+	BufferSize int
+	// BatchSize is maximal number of events written at once
+	BatchSize int
+	// Retention is how long events are kept, CleanupInterval is how often older events are deleted
+	Retention       time.Duration
+	CleanupInterval time.Duration
+}
+
+// App writes audit events in batches in background, so recording never blocks requests,
+// and deletes events older than retention period.
+type App struct {
+	modifier AuditModifier
+	cfg      Config
+	events   chan model.AuditEvent
+	done     chan struct{}
+	stopped  chan struct{}
+	log      *slog.Logger
+}
+
+func New(modifier AuditModifier, cfg Config, log *slog.Logger) *App {
+	return &App{
+		modifier: modifier,
+		cfg:      cfg,
+		events:   make(chan model.AuditEvent, cfg.BufferSize),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		log:      log,
+	}
+}
+
+// Record queues event for writing, it is dropped when buffer is full.
+func (a *App) Record(ctx context.Context, event model.AuditEvent) {
+	select {
+	case a.events <- event:
+	default:
+		metrics.AuditEventsDropped.Inc()
+		a.log.WarnContext(ctx, "audit buffer is full, event dropped", slog.String("action", event.Action))
+	}
+}
+
+func (a *App) MustRun(ctx context.Context) {
+	if err := a.Run(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (a *App) Run(ctx context.Context) error {
+	defer close(a.stopped)
+
+	a.log.Info("audit writer started", slog.Duration("retention", a.cfg.Retention))
+
+	flush := time.NewTicker(flushInterval)
+	defer flush.Stop()
+
+	cleanup := time.NewTicker(a.cfg.CleanupInterval)
+	defer cleanup.Stop()
+
+	batch := make([]model.AuditEvent, 0, a.cfg.BatchSize)
+	for {
+		select {
+		case event := <-a.events:
+			batch = append(batch, event)
+			if len(batch) >= a.cfg.BatchSize {
+				batch = a.write(ctx, batch)
+			}
+		case <-flush.C:
+			batch = a.write(ctx, batch)
+		case <-cleanup.C:
+			a.cleanup(ctx)
+		case <-a.done:
+			a.drain(ctx, batch)
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// drain writes events recorded before stop.
+func (a *App) drain(ctx context.Context, batch []model.AuditEvent) {
+	for {
+		select {
+		case event := <-a.events:
+			batch = append(batch, event)
+			if len(batch) >= a.cfg.BatchSize {
+				batch = a.write(ctx, batch)
+			}
+		default:
+			a.write(ctx, batch)
+			return
+		}
+	}
+}
+
+// write saves batch and returns it emptied, failed batch is dropped so writer does not fall behind.
+func (a *App) write(ctx context.Context, batch []model.AuditEvent) []model.AuditEvent {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	if err := a.modifier.SaveAuditEvents(ctx, batch); err != nil {
+		metrics.AuditEventsDropped.Add(float64(len(batch)))
+		a.log.Error("failed to save audit events", sl.Err(err), slog.Int("count", len(batch)))
+	}
+
+	return batch[:0]
+}
+
+func (a *App) cleanup(ctx context.Context) {
+	count, err := a.modifier.DeleteAuditEventsBefore(ctx, time.Now().Add(-a.cfg.Retention))
+	if err != nil {
+		a.log.Error("failed to delete expired audit events", sl.Err(err))
+		return
+	}
+
+	if count > 0 {
+		a.log.Info("expired audit events deleted", slog.Int64("count", count))
+	}
+}
+
+func (a *App) Stop(ctx context.Context) {
+	a.log.Info("stopping audit writer")
+
+	close(a.done)
+
+	select {
+	case <-a.stopped:
+	case <-ctx.Done():
+	}
+}
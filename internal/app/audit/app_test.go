@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/metrics"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchModifier passes saved batches to channel.
+type batchModifier chan []model.AuditEvent
+
+func (m batchModifier) SaveAuditEvents(_ context.Context, events []model.AuditEvent) error {
+	m <- append([]model.AuditEvent(nil), events...)
+	return nil
+}
+
+func (m batchModifier) DeleteAuditEventsBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newTestApp(bufferSize, batchSize int) (*App, batchModifier) {
+	modifier := make(batchModifier, bufferSize)
+	return New(modifier, Config{
+		BufferSize:      bufferSize,
+		BatchSize:       batchSize,
+		Retention:       time.Hour,
+		CleanupInterval: time.Hour,
+	}, slogdiscard.NewDiscardLogger()), modifier
+}
+
+func dropped(t *testing.T) float64 {
+	t.Helper()
+
+	var m dto.Metric
+	require.NoError(t, metrics.AuditEventsDropped.Write(&m))
+	return m.GetCounter().GetValue()
+}
+
+func TestApp_RecordDropsWhenBufferFull(t *testing.T) {
+	t.Parallel()
+
+	a, _ := newTestApp(1, 10)
+	before := dropped(t)
+
+	a.Record(context.Background(), model.AuditEvent{Action: "first"})
+	a.Record(context.Background(), model.AuditEvent{Action: "second"})
+
+	require.Len(t, a.events, 1)
+	assert.Equal(t, "first", (<-a.events).Action)
+	assert.GreaterOrEqual(t, dropped(t)-before, float64(1))
+}
+
+func TestApp_WritesFullBatch(t *testing.T) {
+	t.Parallel()
+
+	a, saved := newTestApp(10, 2)
+	go a.MustRun(context.Background())
+	defer a.Stop(context.Background())
+
+	a.Record(context.Background(), model.AuditEvent{Action: "first"})
+	a.Record(context.Background(), model.AuditEvent{Action: "second"})
+
+	select {
+	case batch := <-saved:
+		assert.Len(t, batch, 2)
+	case <-time.After(flushInterval / 2):
+		t.Fatal("full batch is not written before flush")
+	}
+}
+
+func TestApp_FlushesPartialBatch(t *testing.T) {
+	t.Parallel()
+
+	a, saved := newTestApp(10, 100)
+	go a.MustRun(context.Background())
+	defer a.Stop(context.Background())
+
+	a.Record(context.Background(), model.AuditEvent{Action: "first"})
+
+	select {
+	case batch := <-saved:
+		assert.Len(t, batch, 1)
+	case <-time.After(2 * flushInterval):
+		t.Fatal("partial batch is not flushed")
+	}
+}
+
+func TestApp_StopDrainsBuffer(t *testing.T) {
+	t.Parallel()
+
+	a, saved := newTestApp(10, 2)
+	for range 5 {
+		a.Record(context.Background(), model.AuditEvent{Action: "event"})
+	}
+
+	go a.MustRun(context.Background())
+	a.Stop(context.Background())
+	close(saved)
+
+	var count int
+	for batch := range saved {
+		assert.LessOrEqual(t, len(batch), 2)
+		count += len(batch)
+	}
+	assert.Equal(t, 5, count)
+}
@@ -0,0 +1,83 @@
+package expirer
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+)
+
+type Expirer interface {
+	ExpireAdminRequests(ctx context.Context) (int, error)
+}
+
+// App periodically expires admin requests which were not decided in time, so reads of
+// requests do not modify them.
+type App struct {
+	expirer  Expirer
+	interval time.Duration
+	done     chan struct{}
+	stopped  chan struct{}
+	log      *slog.Logger
+}
+
+func New(expirer Expirer, interval time.Duration, log *slog.Logger) *App {
+	return &App{
+		expirer:  expirer,
+		interval: interval,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		log:      log,
+	}
+}
+
+func (a *App) MustRun(ctx context.Context) {
+	if err := a.Run(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (a *App) Run(ctx context.Context) error {
+	defer close(a.stopped)
+
+	a.log.Info("admin requests expirer started", slog.Duration("interval", a.interval))
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		a.expire(ctx)
+
+		select {
+		case <-a.done:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *App) expire(ctx context.Context) {
+	count, err := a.expirer.ExpireAdminRequests(ctx)
+	if err != nil {
+		a.log.Error("failed to expire admin requests", sl.Err(err))
+		return
+	}
+
+	if count > 0 {
+		a.log.Info("admin requests expired", slog.Int("count", count))
+	}
+}
+
+func (a *App) Stop(ctx context.Context) {
+	a.log.Info("stopping admin requests expirer")
+
+	close(a.done)
+
+	select {
+	case <-a.stopped:
+	case <-ctx.Done():
+	}
+}
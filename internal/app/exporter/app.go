@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+)
+
+type Config struct {
+	// Workers is number of exports built at once
+	Workers int
+	// QueueSize is number of exports waiting for a worker, exports scheduled to full queue are rejected
+	QueueSize int
+}
+
+type job struct {
+	ctx   context.Context
+	build func(ctx context.Context)
+}
+
+// App builds personal data exports in background, so they outlive requests which scheduled them
+// but not the service: Stop waits for scheduled exports to be built.
+type App struct {
+	cfg Config
+	// mu guards jobs, so nothing is scheduled to closed channel
+	mu       sync.RWMutex
+	stopping bool
+	jobs     chan job
+	stopped  chan struct{}
+	log      *slog.Logger
+}
+
+func New(cfg Config, log *slog.Logger) *App {
+	return &App{
+		cfg:     cfg,
+		jobs:    make(chan job, cfg.QueueSize),
+		stopped: make(chan struct{}),
+		log:     log,
+	}
+}
+
+// Schedule queues build of export, it is run with values of ctx but is not canceled with it.
+func (a *App) Schedule(ctx context.Context, build func(ctx context.Context)) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.stopping {
+		return model.ErrExportUnavailable
+	}
+
+	select {
+	case a.jobs <- job{ctx: context.WithoutCancel(ctx), build: build}:
+		return nil
+	default:
+		a.log.WarnContext(ctx, "export queue is full")
+		return model.ErrExportUnavailable
+	}
+}
+
+func (a *App) MustRun(ctx context.Context) {
+	if err := a.Run(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (a *App) Run(ctx context.Context) error {
+	defer close(a.stopped)
+
+	a.log.Info("exporter started", slog.Int("workers", a.cfg.Workers))
+
+	var wg sync.WaitGroup
+	for range a.cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Jobs channel is closed on stop, so queued exports are built before workers exit
+			for job := range a.jobs {
+				job.build(job.ctx)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+func (a *App) Stop(ctx context.Context) {
+	a.log.Info("stopping exporter")
+
+	a.mu.Lock()
+	if !a.stopping {
+		a.stopping = true
+		close(a.jobs)
+	}
+	a.mu.Unlock()
+
+	select {
+	case <-a.stopped:
+	case <-ctx.Done():
+	}
+}
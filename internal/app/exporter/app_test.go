@@ -0,0 +1,60 @@
+package exporter
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger/slogdiscard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_StopBuildsScheduled(t *testing.T) {
+	t.Parallel()
+
+	a := New(Config{Workers: 1, QueueSize: 10}, slogdiscard.NewDiscardLogger())
+
+	var built atomic.Int32
+	for range 5 {
+		require.NoError(t, a.Schedule(context.Background(), func(ctx context.Context) {
+			time.Sleep(10 * time.Millisecond)
+			built.Add(1)
+		}))
+	}
+
+	go a.MustRun(context.Background())
+	a.Stop(context.Background())
+
+	assert.Equal(t, int32(5), built.Load())
+	assert.ErrorIs(t, a.Schedule(context.Background(), func(ctx context.Context) {}), model.ErrExportUnavailable)
+}
+
+func TestApp_ScheduleFullQueue(t *testing.T) {
+	t.Parallel()
+
+	a := New(Config{Workers: 1, QueueSize: 1}, slogdiscard.NewDiscardLogger())
+
+	require.NoError(t, a.Schedule(context.Background(), func(ctx context.Context) {}))
+	assert.ErrorIs(t, a.Schedule(context.Background(), func(ctx context.Context) {}), model.ErrExportUnavailable)
+}
+
+func TestApp_BuildNotCanceledWithRequest(t *testing.T) {
+	t.Parallel()
+
+	a := New(Config{Workers: 1, QueueSize: 1}, slogdiscard.NewDiscardLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	require.NoError(t, a.Schedule(ctx, func(ctx context.Context) {
+		errs <- ctx.Err()
+	}))
+	cancel()
+
+	go a.MustRun(context.Background())
+	defer a.Stop(context.Background())
+
+	assert.NoError(t, <-errs)
+}
@@ -4,16 +4,26 @@ import (
 	"context"
 	"log/slog"
 	"net"
+	"net/netip"
+	"slices"
 
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/config"
 	user "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/grpc"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/health"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/metrics"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/policy"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/redact"
 	userservice "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/service"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type App struct {
@@ -26,12 +36,14 @@ func New(
 	ctx context.Context,
 	cfg *config.Config,
 	userService *userservice.UserService,
+	checker *health.Checker,
+	policyEngine *policy.Engine,
+	trustedProxies []netip.Prefix,
 	log *slog.Logger,
 ) *App {
 	// Methods that require authentication
 	requireAuth := map[string]bool{
 		"/user.UserService/UpdateUser":  true,
-		"/user.UserService/DeleteUser":  true,
 		"/user.UserService/Login":       true,
 		"/user.UserService/AddAdmin":    true,
 		"/user.UserService/DeleteAdmin": true,
@@ -46,7 +58,13 @@ func New(
 
 	var opts []grpc.ServerOption
 
-	// Logger
+	// Logger, payloads are logged redacted at configured level
+	var payloadLevel slog.Level
+	if err := payloadLevel.UnmarshalText([]byte(cfg.PayloadLogging.Level)); err != nil {
+		panic(err)
+	}
+	logger := interceptorLogger(log, redact.New(cfg.PayloadLogging.Redact), payloadLevel)
+
 	loggingOpts := []logging.Option{
 		logging.WithLogOnEvents(
 			logging.PayloadReceived,
@@ -56,8 +74,8 @@ func New(
 
 	// Recovery
 	recoveryOpts := []recovery.Option{
-		recovery.WithRecoveryHandler(func(p any) (err error) {
-			log.Error("recovered from panic", slog.Any("error", p))
+		recovery.WithRecoveryHandlerContext(func(ctx context.Context, p any) (err error) {
+			sl.FromContext(ctx, log).Error("recovered from panic", slog.Any("error", p))
 
 			return status.Errorf(codes.Internal, "internal error")
 		}),
@@ -69,9 +87,23 @@ func New(
 	}
 
 	opts = append(opts, grpc.ChainUnaryInterceptor(
+		metrics.UnaryServerInterceptor(),
+		user.RequestMiddleware(log),
 		recovery.UnaryServerInterceptor(recoveryOpts...),
-		logging.UnaryServerInterceptor(interceptorLogger(log), loggingOpts...),
+		logging.UnaryServerInterceptor(logger, loggingOpts...),
+		user.ClientMiddleware(trustedProxies),
 		user.AuthMiddleware(secrets, requireAuth, requireAdmin),
+		user.PolicyMiddleware(policyEngine, cfg.Authorization.DryRun, log),
+	))
+
+	// Streaming calls do not log payloads, export streams whole user table
+	opts = append(opts, grpc.ChainStreamInterceptor(
+		metrics.StreamServerInterceptor(),
+		user.StreamRequestMiddleware(log),
+		recovery.StreamServerInterceptor(recoveryOpts...),
+		logging.StreamServerInterceptor(logger),
+		user.StreamAuthMiddleware(secrets, requireAuth, requireAdmin),
+		user.StreamPolicyMiddleware(policyEngine, cfg.Authorization.DryRun, log),
 	))
 
 	// TLS nolint
@@ -82,11 +114,15 @@ func New(
 
 	opts = append(opts, grpc.Creds(insecure.NewCredentials()))
 
+	// Tracing, spans continue trace context of incoming metadata
+	opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+
 	// Create gRPC server
 	gRPCServer := grpc.NewServer(opts...)
 
 	// Register services
-	user.Register(gRPCServer, userService, userService, userService, log)
+	user.Register(gRPCServer, userService, userService, userService, checker, log)
+	healthv1.RegisterHealthServer(gRPCServer, checker.Server())
 
 	return &App{
 		gRPCServer: gRPCServer,
@@ -95,8 +131,16 @@ func New(
 	}
 }
 
-func interceptorLogger(l *slog.Logger) logging.Logger {
+// interceptorLogger logs with logger of request, so records carry its request id.
+func interceptorLogger(log *slog.Logger, redactor *redact.Redactor, payloadLevel slog.Level) logging.Logger {
 	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
+		l := sl.FromContext(ctx, log)
+
+		if payload, ok := redactPayload(redactor, fields); ok {
+			l.Log(ctx, payloadLevel, msg, payload...)
+			return
+		}
+
 		switch lvl {
 		case logging.LevelDebug:
 			l.DebugContext(ctx, msg, fields...)
@@ -114,6 +158,30 @@ func interceptorLogger(l *slog.Logger) logging.Logger {
 	})
 }
 
+// payloadFields are keys of requests and responses in fields of logging interceptor.
+var payloadFields = map[string]bool{
+	"grpc.request.content":  true,
+	"grpc.response.content": true,
+}
+
+// redactPayload returns copy of fields with payload redacted, ok is false when fields have no payload.
+func redactPayload(redactor *redact.Redactor, fields []any) (res []any, ok bool) {
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, _ := fields[i].(string)
+		m, isMessage := fields[i+1].(proto.Message)
+		if !payloadFields[key] || !isMessage {
+			continue
+		}
+
+		if !ok {
+			res, ok = slices.Clone(fields), true
+		}
+		res[i+1] = redactor.Redact(m)
+	}
+
+	return res, ok
+}
+
 func (a *App) MustRun(ctx context.Context) {
 	if err := a.Run(ctx); err != nil {
 		panic(err)
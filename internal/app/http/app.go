@@ -5,15 +5,67 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/pprof"
+	"net/netip"
+	"strings"
 
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/config"
+	user "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/grpc"
+	userhttp "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/http"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/policy"
+	userservice "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/service"
 	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// passedHeaders are forwarded between HTTP and gRPC as is, without Grpc-Metadata- prefix.
+var passedHeaders = map[string]bool{
+	user.PaginationHeader:     true,
+	user.CursorHeader:         true,
+	user.IncludeTotalHeader:   true,
+	user.NextCursorHeader:     true,
+	user.PrevCursorHeader:     true,
+	user.FilterHeader:         true,
+	user.SortHeader:           true,
+	user.SearchQueryHeader:    true,
+	user.IncludeDeletedHeader: true,
+	user.UpdateMaskHeader:     true,
+	user.ReadMaskHeader:       true,
+}
+
+func incomingHeaderMatcher(key string) (string, bool) {
+	if passedHeaders[strings.ToLower(key)] || strings.ToLower(key) == user.RequestIDHeader {
+		return strings.ToLower(key), true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func outgoingHeaderMatcher(key string) (string, bool) {
+	// Request id is already in response, it is set by RequestID middleware
+	if key == user.RequestIDHeader {
+		return "", false
+	}
+
+	if passedHeaders[key] {
+		return key, true
+	}
+
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// untracedPaths are polled by infrastructure, their spans are noise.
+var untracedPaths = map[string]bool{
+	"/metrics": true,
+	"/livez":   true,
+	"/readyz":  true,
+}
+
 type App struct {
 	httpServer *http.Server
 	cert       string
@@ -24,6 +76,10 @@ type App struct {
 func New(
 	ctx context.Context,
 	cfg *config.Config,
+	userService *userservice.UserService,
+	checker userhttp.ReadinessChecker,
+	policyEngine *policy.Engine,
+	trustedProxies []netip.Prefix,
 	log *slog.Logger,
 ) *App {
 	// creds, err := credentials.NewClientTLSFromFile(cfg.Cert, "") nolint
@@ -31,15 +87,26 @@ func New(
 	// 	logger.Log().Fatal(ctx, "failed to create server TLS credentials: %v", err)
 	// }
 
-	conn, err := grpc.NewClient(cfg.GrpcPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// Trace context of gateway spans is propagated to gRPC server in metadata
+	conn, err := grpc.NewClient(cfg.GrpcPort,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
 	if err != nil {
 		panic(err)
 	}
 
-	gwmux := runtime.NewServeMux()
+	gwmux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
 	mux := http.NewServeMux()
 	mux.Handle("/", gwmux)
 	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.Handle("/metrics", promhttp.Handler())
+
+	// Liveness and readiness probes
+	userhttp.RegisterHealth(mux, checker)
 
 	// Register user
 	err = userv1.RegisterUserServiceHandler(ctx, gwmux, conn)
@@ -47,13 +114,119 @@ func New(
 		panic(err)
 	}
 
+	// Routes without RPC, router records their metrics, client and policies instead of gRPC interceptors
+	router := userhttp.NewRouter(gwmux, policyEngine, cfg.Authorization.DryRun, cfg.Auth.JwtSecret, trustedProxies, log)
+
+	// Deletion of accounts, protos have no DeleteUser RPC
+	err = userhttp.RegisterDeleteUser(router, userService, cfg.Auth.JwtSecret, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Personal data export
+	err = userhttp.RegisterExport(router, userService, cfg.Auth.JwtSecret, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Pseudonym availability, protos are frozen so there is no RPC for it
+	err = userhttp.RegisterPseudonym(router, userService, cfg.Auth.JwtSecret, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Telegram profile, protos are frozen so User message has no fields for it
+	err = userhttp.RegisterTelegramProfile(router, userService, cfg.Auth.JwtSecret, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Admin override of pseudonym, protos are frozen so there is no RPC for it
+	err = userhttp.RegisterSetPseudonym(router, userService, cfg.Auth.JwtSecret, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Fuzzy user search
+	err = userhttp.RegisterSearch(router, userService, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Bulk export of users for admins
+	err = userhttp.RegisterUsersExport(router, userService, cfg.Auth.JwtSecret, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Batch lookup of users by ids
+	err = userhttp.RegisterBatch(router, userService, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// User metadata of service clients behind X-Service-Key, protos are frozen so there are no RPCs for it
+	err = userhttp.RegisterMetadata(router, userService, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Pending admin changes, protos have no RPCs for them
+	err = userhttp.RegisterAdminRequests(router, userService, cfg.Auth.JwtSecret, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Organizations and tokens scoped to them, protos have no RPCs for them
+	err = userhttp.RegisterOrganizations(router, userService, cfg.Auth.JwtSecret, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Explanation of policy decisions for admins
+	err = userhttp.RegisterPolicy(router, policyEngine, cfg.Auth.JwtSecret, log)
+	if err != nil {
+		panic(err)
+	}
+
+	// Audit log for admins, protos are frozen so there is no RPC for it
+	err = userhttp.RegisterAudit(router, userService, cfg.Auth.JwtSecret, log)
+	if err != nil {
+		panic(err)
+	}
+
 	// Cors
-	withCors := cors.AllowAll().Handler(mux)
+	withCors := cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{
+			http.MethodHead,
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+		},
+		AllowedHeaders: []string{"*"},
+		ExposedHeaders: []string{user.NextCursorHeader, user.PrevCursorHeader, userhttp.RequestIDHeader},
+	}).Handler(mux)
+
+	// Request id, it is forwarded to gRPC server and returned to client
+	withRequestID := userhttp.RequestID(withCors, log)
+
+	// Tracing, scrapes of metrics and probes are not traced
+	withTracing := otelhttp.NewHandler(withRequestID, "gateway",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return "HTTP " + r.Method
+		}),
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return !untracedPaths[r.URL.Path]
+		}),
+	)
 
 	// Server
 	gwServer := &http.Server{
 		Addr:    cfg.HttpPort,
-		Handler: withCors,
+		Handler: withTracing,
 	}
 
 	return &App{
@@ -8,13 +8,25 @@ import (
 )
 
 type Config struct {
-	Env         string `yaml:"env" env-default:"local"`
-	DatabaseURL string `yaml:"database_url" env:"DATABASE_URL" env-required:"true"`
-	RedisURL    string `yaml:"redis_url" env:"REDIS_URL" env-required:"true"`
-	Tls         Tls    `yaml:"tls"`
-	GrpcPort    string `yaml:"grpc_port" env-required:"true"`
-	HttpPort    string `yaml:"http_port" env-required:"true"`
-	Auth        Auth   `yaml:"auth" env-required:"true"`
+	Env            string         `yaml:"env" env-default:"local"`
+	DatabaseURL    string         `yaml:"database_url" env:"DATABASE_URL" env-required:"true"`
+	RedisURL       string         `yaml:"redis_url" env:"REDIS_URL" env-required:"true"`
+	Tls            Tls            `yaml:"tls"`
+	GrpcPort       string         `yaml:"grpc_port" env-required:"true"`
+	HttpPort       string         `yaml:"http_port" env-required:"true"`
+	Auth           Auth           `yaml:"auth" env-required:"true"`
+	Authorization  Authorization  `yaml:"authorization"`
+	Deletion       Deletion       `yaml:"deletion"`
+	Export         Export         `yaml:"export"`
+	Pseudonym      Pseudonym      `yaml:"pseudonym"`
+	Metadata       Metadata       `yaml:"metadata"`
+	Search         Search         `yaml:"search"`
+	Batch          Batch          `yaml:"batch"`
+	UserCache      UserCache      `yaml:"user_cache"`
+	Tracing        Tracing        `yaml:"tracing"`
+	Audit          Audit          `yaml:"audit"`
+	PayloadLogging PayloadLogging `yaml:"payload_logging"`
+	Health         Health         `yaml:"health"`
 }
 
 type Tls struct {
@@ -23,10 +35,105 @@ type Tls struct {
 }
 
 type Auth struct {
-	JwtSecret       string `yaml:"jwt_secret" env-required:"true"`
-	AccessTokenTTL  int    `yaml:"access_token_ttl" env-required:"true"`
-	RefreshTokenTTL int    `yaml:"refresh_token_ttl" env-required:"true"`
-	TmaSecret       string `yaml:"tma_secret" env-required:"true"`
+	JwtSecret       string        `yaml:"jwt_secret" env-required:"true"`
+	AccessTokenTTL  int           `yaml:"access_token_ttl" env-required:"true"`
+	RefreshTokenTTL int           `yaml:"refresh_token_ttl" env-required:"true"`
+	TmaSecret       string        `yaml:"tma_secret" env-required:"true"`
+	AdminApproval   AdminApproval `yaml:"admin_approval"`
+}
+
+// AdminApproval configures approval of admin changes by another major admin, RequestTTL
+// and ExpireInterval of pending requests in minutes.
+type AdminApproval struct {
+	Enabled        bool `yaml:"enabled" env-default:"false"`
+	RequestTTL     int  `yaml:"request_ttl" env-default:"1440"`
+	ExpireInterval int  `yaml:"expire_interval" env-default:"5"`
+}
+
+type Deletion struct {
+	GracePeriod       int `yaml:"grace_period" env-default:"43200"`
+	AnonymizeInterval int `yaml:"anonymize_interval" env-default:"60"`
+}
+
+// Export configures personal data exports, TTL in minutes. Workers build exports at once and
+// QueueSize exports wait for them, exports requested to full queue are rejected.
+type Export struct {
+	TTL       int `yaml:"ttl" env-default:"60"`
+	Workers   int `yaml:"workers" env-default:"4"`
+	QueueSize int `yaml:"queue_size" env-default:"64"`
+}
+
+type Pseudonym struct {
+	Reserved       []string `yaml:"reserved"`
+	Blocked        []string `yaml:"blocked"`
+	ChangeCooldown int      `yaml:"change_cooldown" env-default:"10080"`
+	FormerTTL      int      `yaml:"former_ttl" env-default:"43200"`
+}
+
+type Search struct {
+	SimilarityThreshold float64 `yaml:"similarity_threshold" env-default:"0.3"`
+}
+
+// Batch configures lookup of users by ids, found users are cached as configured by UserCache.
+type Batch struct {
+	MaxIDs int `yaml:"max_ids" env-default:"100"`
+}
+
+// UserCache configures redis cache of user lookups by id, username and ids batch, TTL and
+// NegativeTTL of missing users in seconds. Zero TTL disables cache, zero NegativeTTL disables caching of missing users.
+type UserCache struct {
+	TTL         int `yaml:"ttl" env-default:"0"`
+	NegativeTTL int `yaml:"negative_ttl" env-default:"0"`
+}
+
+// Tracing configures OpenTelemetry traces, Endpoint is URL of OTLP/HTTP collector and
+// SampleRatio is share of sampled traces from 0 to 1.
+type Tracing struct {
+	Enabled     bool    `yaml:"enabled" env-default:"false"`
+	ServiceName string  `yaml:"service_name" env-default:"beatflow-auth"`
+	Endpoint    string  `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	SampleRatio float64 `yaml:"sample_ratio" env-default:"1"`
+}
+
+// Audit configures security audit log, Retention and CleanupInterval in minutes. TrustedProxies are
+// addresses or CIDRs of proxies in front of the service, client address is read from x-forwarded-for
+// only if it is set by gateway or them.
+type Audit struct {
+	BufferSize      int      `yaml:"buffer_size" env-default:"1024"`
+	BatchSize       int      `yaml:"batch_size" env-default:"100"`
+	Retention       int      `yaml:"retention" env-default:"129600"`
+	CleanupInterval int      `yaml:"cleanup_interval" env-default:"60"`
+	TrustedProxies  []string `yaml:"trusted_proxies"`
+}
+
+type Metadata struct {
+	MaxSize    int                 `yaml:"max_size" env-default:"16384"`
+	Clients    []ServiceClient     `yaml:"clients"`
+	Namespaces []MetadataNamespace `yaml:"namespaces"`
+}
+
+type ServiceClient struct {
+	ID  string `yaml:"id"`
+	Key string `yaml:"key"`
+}
+
+type MetadataNamespace struct {
+	Name   string `yaml:"name"`
+	Owner  string `yaml:"owner"`
+	Schema string `yaml:"schema"`
+	Claims bool   `yaml:"claims"`
+}
+
+type Authorization struct {
+	DryRun   bool     `yaml:"dry_run" env-default:"false"`
+	Policies []Policy `yaml:"policies"`
+}
+
+type Policy struct {
+	Name       string   `yaml:"name"`
+	Methods    []string `yaml:"methods"`
+	Effect     string   `yaml:"effect"`
+	Expression string   `yaml:"expression"`
 }
 
 func MustLoad() *Config {
@@ -63,3 +170,19 @@ func fetchConfigPath() string {
 
 	return res
 }
+
+// PayloadLogging configures logging of gRPC requests and responses, Level is slog level of
+// payload records and Redact lists masked fields by name or full name. Tokens are always masked.
+type PayloadLogging struct {
+	Level  string   `yaml:"level" env-default:"debug"`
+	Redact []string `yaml:"redact"`
+}
+
+// Health configures readiness checks in seconds, Timeout bounds pings of dependencies,
+// Interval is period of grpc.health.v1 status updates and ShutdownDelay is how long
+// NOT_SERVING status is reported before servers are stopped.
+type Health struct {
+	Timeout       int `yaml:"timeout" env-default:"2"`
+	Interval      int `yaml:"interval" env-default:"5"`
+	ShutdownDelay int `yaml:"shutdown_delay" env-default:"0"`
+}
@@ -12,6 +12,92 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AdminRequestAction string
+
+const (
+	AdminRequestActionAddAdmin    AdminRequestAction = "add_admin"
+	AdminRequestActionDeleteAdmin AdminRequestAction = "delete_admin"
+)
+
+func (e *AdminRequestAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AdminRequestAction(s)
+	case string:
+		*e = AdminRequestAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AdminRequestAction: %T", src)
+	}
+	return nil
+}
+
+type NullAdminRequestAction struct {
+	AdminRequestAction AdminRequestAction
+	Valid              bool // Valid is true if AdminRequestAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAdminRequestAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.AdminRequestAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AdminRequestAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAdminRequestAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AdminRequestAction), nil
+}
+
+type AdminRequestStatus string
+
+const (
+	AdminRequestStatusPending  AdminRequestStatus = "pending"
+	AdminRequestStatusApproved AdminRequestStatus = "approved"
+	AdminRequestStatusRejected AdminRequestStatus = "rejected"
+	AdminRequestStatusExpired  AdminRequestStatus = "expired"
+)
+
+func (e *AdminRequestStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AdminRequestStatus(s)
+	case string:
+		*e = AdminRequestStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AdminRequestStatus: %T", src)
+	}
+	return nil
+}
+
+type NullAdminRequestStatus struct {
+	AdminRequestStatus AdminRequestStatus
+	Valid              bool // Valid is true if AdminRequestStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAdminRequestStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.AdminRequestStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AdminRequestStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAdminRequestStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AdminRequestStatus), nil
+}
+
 type AdminScale string
 
 const (
@@ -54,19 +140,186 @@ func (ns NullAdminScale) Value() (driver.Value, error) {
 	return string(ns.AdminScale), nil
 }
 
-type User struct {
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner  OrganizationRole = "owner"
+	OrganizationRoleAdmin  OrganizationRole = "admin"
+	OrganizationRoleMember OrganizationRole = "member"
+)
+
+func (e *OrganizationRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OrganizationRole(s)
+	case string:
+		*e = OrganizationRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OrganizationRole: %T", src)
+	}
+	return nil
+}
+
+type NullOrganizationRole struct {
+	OrganizationRole OrganizationRole
+	Valid            bool // Valid is true if OrganizationRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOrganizationRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.OrganizationRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OrganizationRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOrganizationRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OrganizationRole), nil
+}
+
+type ProfileFieldSource string
+
+const (
+	ProfileFieldSourceTelegram ProfileFieldSource = "telegram"
+	ProfileFieldSourceUser     ProfileFieldSource = "user"
+)
+
+func (e *ProfileFieldSource) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ProfileFieldSource(s)
+	case string:
+		*e = ProfileFieldSource(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ProfileFieldSource: %T", src)
+	}
+	return nil
+}
+
+type NullProfileFieldSource struct {
+	ProfileFieldSource ProfileFieldSource
+	Valid              bool // Valid is true if ProfileFieldSource is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullProfileFieldSource) Scan(value interface{}) error {
+	if value == nil {
+		ns.ProfileFieldSource, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ProfileFieldSource.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullProfileFieldSource) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ProfileFieldSource), nil
+}
+
+type AdminRequest struct {
+	ID           uuid.UUID
+	Action       AdminRequestAction
+	TargetUserID uuid.UUID
+	Scale        NullAdminScale
+	RequestedBy  uuid.UUID
+	Status       AdminRequestStatus
+	DecidedBy    pgtype.UUID
+	CreatedAt    pgtype.Timestamp
+	ExpiresAt    pgtype.Timestamp
+	DecidedAt    pgtype.Timestamp
+}
+
+type AuditEvent struct {
+	ID        uuid.UUID
+	Action    string
+	Outcome   string
+	ActorID   pgtype.UUID
+	TargetID  pgtype.UUID
+	Ip        *string
+	UserAgent *string
+	Metadata  []byte
+	CreatedAt pgtype.Timestamp
+}
+
+type EventsOutbox struct {
+	ID         uuid.UUID
+	Type       string
+	UserID     uuid.UUID
+	OccurredAt pgtype.Timestamp
+}
+
+type Organization struct {
 	ID        uuid.UUID
-	Username  string
-	Pseudonym string
-	FirstName string
-	LastName  string
-	IsDeleted bool
+	Name      string
+	CreatedBy uuid.UUID
 	CreatedAt pgtype.Timestamp
 	UpdatedAt pgtype.Timestamp
 }
 
+type OrganizationMember struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Role           OrganizationRole
+	CreatedAt      pgtype.Timestamp
+}
+
+type PseudonymHistory struct {
+	ID                   uuid.UUID
+	UserID               uuid.UUID
+	OldPseudonym         string
+	OldPseudonymSkeleton string
+	NewPseudonym         string
+	ChangedBy            uuid.UUID
+	ChangedAt            pgtype.Timestamp
+}
+
+type User struct {
+	ID                uuid.UUID
+	Username          string
+	Pseudonym         string
+	FirstName         string
+	LastName          string
+	IsDeleted         bool
+	CreatedAt         pgtype.Timestamp
+	UpdatedAt         pgtype.Timestamp
+	DeletedAt         pgtype.Timestamp
+	AnonymizedAt      pgtype.Timestamp
+	PseudonymSkeleton string
+	TelegramID        *int64
+	LanguageCode      *string
+	IsPremium         bool
+	PhotoUrl          *string
+	AllowsWriteToPm   bool
+	IsBot             bool
+	FirstNameSource   ProfileFieldSource
+	LastNameSource    ProfileFieldSource
+}
+
 type UsersAdmin struct {
 	UserID    uuid.UUID
 	Scale     AdminScale
 	CreatedAt pgtype.Timestamp
 }
+
+type UsersDeletion struct {
+	UserID    uuid.UUID
+	DeletedBy uuid.UUID
+	CreatedAt pgtype.Timestamp
+}
+
+type UsersMetadatum struct {
+	UserID    uuid.UUID
+	Namespace string
+	Data      []byte
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
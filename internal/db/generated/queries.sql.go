@@ -12,6 +12,75 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const anonymizeDeletedUsers = `-- name: AnonymizeDeletedUsers :many
+update "users"
+set "username" = 'deleted_' || "id",
+"pseudonym" = 'deleted',
+"pseudonym_skeleton" = 'deleted',
+"first_name" = '',
+"last_name" = '',
+"telegram_id" = null,
+"language_code" = null,
+"photo_url" = null,
+"anonymized_at" = now(),
+"updated_at" = now()
+where "is_deleted" = true
+and "anonymized_at" is null
+and "deleted_at" <= $1
+returning id
+`
+
+func (q *Queries) AnonymizeDeletedUsers(ctx context.Context, deletedAt pgtype.Timestamp) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, anonymizeDeletedUsers, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const anonymizeUsersAuditEvents = `-- name: AnonymizeUsersAuditEvents :exec
+update "audit_events"
+set "ip" = case when actor_id = any($1::uuid[]) then null else "ip" end,
+"user_agent" = case when actor_id = any($1::uuid[]) then null else "user_agent" end,
+"metadata" = case when target_id = any($1::uuid[]) then "metadata" - $2::text[] else "metadata" end
+where actor_id = any($1::uuid[])
+or target_id = any($1::uuid[])
+`
+
+type AnonymizeUsersAuditEventsParams struct {
+	UserIds []uuid.UUID
+	Keys    []string
+}
+
+func (q *Queries) AnonymizeUsersAuditEvents(ctx context.Context, arg AnonymizeUsersAuditEventsParams) error {
+	_, err := q.db.Exec(ctx, anonymizeUsersAuditEvents, arg.UserIds, arg.Keys)
+	return err
+}
+
+const countAdminRequests = `-- name: CountAdminRequests :one
+select count(*) from "admin_requests"
+where status = coalesce($1, status)
+`
+
+func (q *Queries) CountAdminRequests(ctx context.Context, status NullAdminRequestStatus) (int64, error) {
+	row := q.db.QueryRow(ctx, countAdminRequests, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countAdmins = `-- name: CountAdmins :one
 select count(*)
 from "users_admins" ua
@@ -35,6 +104,41 @@ func (q *Queries) CountAdmins(ctx context.Context, arg CountAdminsParams) (int64
 	return count, err
 }
 
+const decideAdminRequest = `-- name: DecideAdminRequest :one
+update "admin_requests"
+set "status" = $1,
+"decided_by" = $2,
+"decided_at" = now()
+where id = $3
+and "status" = 'pending'
+and "expires_at" > now()
+returning id, action, target_user_id, scale, requested_by, status, decided_by, created_at, expires_at, decided_at
+`
+
+type DecideAdminRequestParams struct {
+	Status    AdminRequestStatus
+	DecidedBy pgtype.UUID
+	ID        uuid.UUID
+}
+
+func (q *Queries) DecideAdminRequest(ctx context.Context, arg DecideAdminRequestParams) (AdminRequest, error) {
+	row := q.db.QueryRow(ctx, decideAdminRequest, arg.Status, arg.DecidedBy, arg.ID)
+	var i AdminRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Action,
+		&i.TargetUserID,
+		&i.Scale,
+		&i.RequestedBy,
+		&i.Status,
+		&i.DecidedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.DecidedAt,
+	)
+	return i, err
+}
+
 const deleteAdmin = `-- name: DeleteAdmin :exec
 delete from "users_admins" where user_id = $1
 `
@@ -44,53 +148,175 @@ func (q *Queries) DeleteAdmin(ctx context.Context, userID uuid.UUID) error {
 	return err
 }
 
-const getAdmins = `-- name: GetAdmins :many
-select u.id, u.username, ua.scale, ua.created_at
-from "users_admins" ua
-join "users" u on u.id = ua.user_id
-where u.id = coalesce($1, u.id)
-and u.username = coalesce($2, u.username)
-and ua.scale = coalesce($3, ua.scale)
-and u.is_deleted = false
-order by ua.created_at
-limit $5 offset $4
+const deleteAuditEventsBefore = `-- name: DeleteAuditEventsBefore :execrows
+delete from "audit_events"
+where "created_at" < $1
 `
 
-type GetAdminsParams struct {
-	UserID     pgtype.UUID
-	Username   *string
-	AdminScale NullAdminScale
-	Offset     int32
-	Limit      int32
+func (q *Queries) DeleteAuditEventsBefore(ctx context.Context, createdAt pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAuditEventsBefore, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-type GetAdminsRow struct {
-	ID        uuid.UUID
-	Username  string
-	Scale     AdminScale
-	CreatedAt pgtype.Timestamp
+const deleteOrganizationMember = `-- name: DeleteOrganizationMember :exec
+delete from "organization_members"
+where organization_id = $1
+and user_id = $2
+`
+
+type DeleteOrganizationMemberParams struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
 }
 
-func (q *Queries) GetAdmins(ctx context.Context, arg GetAdminsParams) ([]GetAdminsRow, error) {
-	rows, err := q.db.Query(ctx, getAdmins,
-		arg.UserID,
-		arg.Username,
-		arg.AdminScale,
-		arg.Offset,
-		arg.Limit,
+func (q *Queries) DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) error {
+	_, err := q.db.Exec(ctx, deleteOrganizationMember, arg.OrganizationID, arg.UserID)
+	return err
+}
+
+const deleteOutboxEvent = `-- name: DeleteOutboxEvent :exec
+delete from "events_outbox"
+where id = $1
+`
+
+func (q *Queries) DeleteOutboxEvent(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteOutboxEvent, id)
+	return err
+}
+
+const deleteUser = `-- name: DeleteUser :execrows
+update "users"
+set "is_deleted" = true,
+"deleted_at" = now(),
+"updated_at" = now()
+where id = $1
+and "is_deleted" = false
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteUserDeletion = `-- name: DeleteUserDeletion :exec
+delete from "users_deletions"
+where user_id = $1
+`
+
+func (q *Queries) DeleteUserDeletion(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUserDeletion, userID)
+	return err
+}
+
+const deleteUsersMetadata = `-- name: DeleteUsersMetadata :exec
+delete from "users_metadata"
+where user_id = any($1::uuid[])
+`
+
+func (q *Queries) DeleteUsersMetadata(ctx context.Context, userIds []uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUsersMetadata, userIds)
+	return err
+}
+
+const deleteUsersPseudonymHistory = `-- name: DeleteUsersPseudonymHistory :exec
+delete from "pseudonym_history"
+where user_id = any($1::uuid[])
+`
+
+func (q *Queries) DeleteUsersPseudonymHistory(ctx context.Context, userIds []uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUsersPseudonymHistory, userIds)
+	return err
+}
+
+const expireAdminRequests = `-- name: ExpireAdminRequests :execrows
+update "admin_requests"
+set "status" = 'expired'
+where "status" = 'pending'
+and "expires_at" <= now()
+`
+
+func (q *Queries) ExpireAdminRequests(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, expireAdminRequests)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAdminByUserID = `-- name: GetAdminByUserID :one
+select user_id, scale, created_at from "users_admins"
+where user_id = $1
+`
+
+func (q *Queries) GetAdminByUserID(ctx context.Context, userID uuid.UUID) (UsersAdmin, error) {
+	row := q.db.QueryRow(ctx, getAdminByUserID, userID)
+	var i UsersAdmin
+	err := row.Scan(&i.UserID, &i.Scale, &i.CreatedAt)
+	return i, err
+}
+
+const getAdminRequestByID = `-- name: GetAdminRequestByID :one
+select id, action, target_user_id, scale, requested_by, status, decided_by, created_at, expires_at, decided_at from "admin_requests"
+where id = $1
+`
+
+func (q *Queries) GetAdminRequestByID(ctx context.Context, id uuid.UUID) (AdminRequest, error) {
+	row := q.db.QueryRow(ctx, getAdminRequestByID, id)
+	var i AdminRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Action,
+		&i.TargetUserID,
+		&i.Scale,
+		&i.RequestedBy,
+		&i.Status,
+		&i.DecidedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.DecidedAt,
 	)
+	return i, err
+}
+
+const getAdminRequests = `-- name: GetAdminRequests :many
+select id, action, target_user_id, scale, requested_by, status, decided_by, created_at, expires_at, decided_at from "admin_requests"
+where status = coalesce($1, status)
+order by created_at desc
+limit $3 offset $2
+`
+
+type GetAdminRequestsParams struct {
+	Status NullAdminRequestStatus
+	Offset int32
+	Limit  int32
+}
+
+func (q *Queries) GetAdminRequests(ctx context.Context, arg GetAdminRequestsParams) ([]AdminRequest, error) {
+	rows, err := q.db.Query(ctx, getAdminRequests, arg.Status, arg.Offset, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetAdminsRow
+	var items []AdminRequest
 	for rows.Next() {
-		var i GetAdminsRow
+		var i AdminRequest
 		if err := rows.Scan(
 			&i.ID,
-			&i.Username,
+			&i.Action,
+			&i.TargetUserID,
 			&i.Scale,
+			&i.RequestedBy,
+			&i.Status,
+			&i.DecidedBy,
 			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.DecidedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -102,6 +328,211 @@ func (q *Queries) GetAdmins(ctx context.Context, arg GetAdminsParams) ([]GetAdmi
 	return items, nil
 }
 
+const getAdminScaleForUpdate = `-- name: GetAdminScaleForUpdate :one
+select scale from "users_admins"
+where user_id = $1
+for update
+`
+
+func (q *Queries) GetAdminScaleForUpdate(ctx context.Context, userID uuid.UUID) (AdminScale, error) {
+	row := q.db.QueryRow(ctx, getAdminScaleForUpdate, userID)
+	var scale AdminScale
+	err := row.Scan(&scale)
+	return scale, err
+}
+
+const getAllUserMetadata = `-- name: GetAllUserMetadata :many
+select user_id, namespace, data, created_at, updated_at from "users_metadata"
+where user_id = $1
+order by namespace
+`
+
+func (q *Queries) GetAllUserMetadata(ctx context.Context, userID uuid.UUID) ([]UsersMetadatum, error) {
+	rows, err := q.db.Query(ctx, getAllUserMetadata, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UsersMetadatum
+	for rows.Next() {
+		var i UsersMetadatum
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Namespace,
+			&i.Data,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastPseudonymChange = `-- name: GetLastPseudonymChange :one
+select id, user_id, old_pseudonym, old_pseudonym_skeleton, new_pseudonym, changed_by, changed_at from "pseudonym_history"
+where user_id = $1
+order by changed_at desc
+limit 1
+`
+
+func (q *Queries) GetLastPseudonymChange(ctx context.Context, userID uuid.UUID) (PseudonymHistory, error) {
+	row := q.db.QueryRow(ctx, getLastPseudonymChange, userID)
+	var i PseudonymHistory
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OldPseudonym,
+		&i.OldPseudonymSkeleton,
+		&i.NewPseudonym,
+		&i.ChangedBy,
+		&i.ChangedAt,
+	)
+	return i, err
+}
+
+const getLegacyUserAdminByUsername = `-- name: GetLegacyUserAdminByUsername :one
+select u.id, ua.scale from "users" u
+left join "users_admins" ua on u.id = ua.user_id
+where u.username = $1
+and u.telegram_id is null
+and "is_deleted" = false
+`
+
+type GetLegacyUserAdminByUsernameRow struct {
+	ID    uuid.UUID
+	Scale NullAdminScale
+}
+
+func (q *Queries) GetLegacyUserAdminByUsername(ctx context.Context, username string) (GetLegacyUserAdminByUsernameRow, error) {
+	row := q.db.QueryRow(ctx, getLegacyUserAdminByUsername, username)
+	var i GetLegacyUserAdminByUsernameRow
+	err := row.Scan(&i.ID, &i.Scale)
+	return i, err
+}
+
+const getOrganizationMember = `-- name: GetOrganizationMember :one
+select organization_id, user_id, role, created_at from "organization_members"
+where organization_id = $1
+and user_id = $2
+`
+
+type GetOrganizationMemberParams struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+}
+
+func (q *Queries) GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (OrganizationMember, error) {
+	row := q.db.QueryRow(ctx, getOrganizationMember, arg.OrganizationID, arg.UserID)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getOutboxEventsForUpdate = `-- name: GetOutboxEventsForUpdate :many
+select id, type, user_id, occurred_at from "events_outbox"
+order by "occurred_at"
+limit $1
+for update skip locked
+`
+
+func (q *Queries) GetOutboxEventsForUpdate(ctx context.Context, limit int32) ([]EventsOutbox, error) {
+	rows, err := q.db.Query(ctx, getOutboxEventsForUpdate, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EventsOutbox
+	for rows.Next() {
+		var i EventsOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.UserID,
+			&i.OccurredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPseudonymHistory = `-- name: GetPseudonymHistory :many
+select id, user_id, old_pseudonym, old_pseudonym_skeleton, new_pseudonym, changed_by, changed_at from "pseudonym_history"
+where user_id = $1
+order by changed_at
+`
+
+func (q *Queries) GetPseudonymHistory(ctx context.Context, userID uuid.UUID) ([]PseudonymHistory, error) {
+	rows, err := q.db.Query(ctx, getPseudonymHistory, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PseudonymHistory
+	for rows.Next() {
+		var i PseudonymHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.OldPseudonym,
+			&i.OldPseudonymSkeleton,
+			&i.NewPseudonym,
+			&i.ChangedBy,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRestorableUser = `-- name: GetRestorableUser :one
+select u.id, ud.deleted_by from "users" u
+left join "users_deletions" ud on u.id = ud.user_id
+where (u.telegram_id = $1 or (u.telegram_id is null and u.username = $2 and u.username <> ''))
+and u.is_deleted = true
+and u.anonymized_at is null
+and u.deleted_at > $3
+order by u.deleted_at desc
+limit 1
+`
+
+type GetRestorableUserParams struct {
+	TelegramID   *int64
+	Username     string
+	DeletedAfter pgtype.Timestamp
+}
+
+type GetRestorableUserRow struct {
+	ID        uuid.UUID
+	DeletedBy pgtype.UUID
+}
+
+func (q *Queries) GetRestorableUser(ctx context.Context, arg GetRestorableUserParams) (GetRestorableUserRow, error) {
+	row := q.db.QueryRow(ctx, getRestorableUser, arg.TelegramID, arg.Username, arg.DeletedAfter)
+	var i GetRestorableUserRow
+	err := row.Scan(&i.ID, &i.DeletedBy)
+	return i, err
+}
+
 const getUserAdminByID = `-- name: GetUserAdminByID :one
 select u.id, ua.scale from "users" u
 left join "users_admins" ua on u.id = ua.user_id
@@ -121,6 +552,25 @@ func (q *Queries) GetUserAdminByID(ctx context.Context, id uuid.UUID) (GetUserAd
 	return i, err
 }
 
+const getUserAdminByTelegramID = `-- name: GetUserAdminByTelegramID :one
+select u.id, ua.scale from "users" u
+left join "users_admins" ua on u.id = ua.user_id
+where u.telegram_id = $1
+and "is_deleted" = false
+`
+
+type GetUserAdminByTelegramIDRow struct {
+	ID    uuid.UUID
+	Scale NullAdminScale
+}
+
+func (q *Queries) GetUserAdminByTelegramID(ctx context.Context, telegramID *int64) (GetUserAdminByTelegramIDRow, error) {
+	row := q.db.QueryRow(ctx, getUserAdminByTelegramID, telegramID)
+	var i GetUserAdminByTelegramIDRow
+	err := row.Scan(&i.ID, &i.Scale)
+	return i, err
+}
+
 const getUserAdminByUsername = `-- name: GetUserAdminByUsername :one
 select u.id, ua.scale from "users" u
 left join "users_admins" ua on u.id = ua.user_id
@@ -140,8 +590,84 @@ func (q *Queries) GetUserAdminByUsername(ctx context.Context, username string) (
 	return i, err
 }
 
+const getUserAdminRequests = `-- name: GetUserAdminRequests :many
+select id, action, target_user_id, scale, requested_by, status, decided_by, created_at, expires_at, decided_at from "admin_requests"
+where target_user_id = $1
+or requested_by = $1
+or decided_by = $1
+order by created_at
+`
+
+func (q *Queries) GetUserAdminRequests(ctx context.Context, targetUserID uuid.UUID) ([]AdminRequest, error) {
+	rows, err := q.db.Query(ctx, getUserAdminRequests, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AdminRequest
+	for rows.Next() {
+		var i AdminRequest
+		if err := rows.Scan(
+			&i.ID,
+			&i.Action,
+			&i.TargetUserID,
+			&i.Scale,
+			&i.RequestedBy,
+			&i.Status,
+			&i.DecidedBy,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.DecidedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserAuditEvents = `-- name: GetUserAuditEvents :many
+select id, action, outcome, actor_id, target_id, ip, user_agent, metadata, created_at from "audit_events"
+where actor_id = $1
+or target_id = $1
+order by created_at
+`
+
+func (q *Queries) GetUserAuditEvents(ctx context.Context, userID pgtype.UUID) ([]AuditEvent, error) {
+	rows, err := q.db.Query(ctx, getUserAuditEvents, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Action,
+			&i.Outcome,
+			&i.ActorID,
+			&i.TargetID,
+			&i.Ip,
+			&i.UserAgent,
+			&i.Metadata,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserByID = `-- name: GetUserByID :one
-select id, username, pseudonym, first_name, last_name, is_deleted, created_at, updated_at from "users"
+select id, username, pseudonym, first_name, last_name, is_deleted, created_at, updated_at, deleted_at, anonymized_at, pseudonym_skeleton, telegram_id, language_code, is_premium, photo_url, allows_write_to_pm, is_bot, first_name_source, last_name_source from "users"
 where id = $1
 and "is_deleted" = false
 `
@@ -158,10 +684,281 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.IsDeleted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.AnonymizedAt,
+		&i.PseudonymSkeleton,
+		&i.TelegramID,
+		&i.LanguageCode,
+		&i.IsPremium,
+		&i.PhotoUrl,
+		&i.AllowsWriteToPm,
+		&i.IsBot,
+		&i.FirstNameSource,
+		&i.LastNameSource,
 	)
 	return i, err
 }
 
+const getUserByIDForUpdate = `-- name: GetUserByIDForUpdate :one
+select id, username, pseudonym, first_name, last_name, is_deleted, created_at, updated_at, deleted_at, anonymized_at, pseudonym_skeleton, telegram_id, language_code, is_premium, photo_url, allows_write_to_pm, is_bot, first_name_source, last_name_source from "users"
+where id = $1
+and "is_deleted" = false
+for update
+`
+
+func (q *Queries) GetUserByIDForUpdate(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByIDForUpdate, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Pseudonym,
+		&i.FirstName,
+		&i.LastName,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.AnonymizedAt,
+		&i.PseudonymSkeleton,
+		&i.TelegramID,
+		&i.LanguageCode,
+		&i.IsPremium,
+		&i.PhotoUrl,
+		&i.AllowsWriteToPm,
+		&i.IsBot,
+		&i.FirstNameSource,
+		&i.LastNameSource,
+	)
+	return i, err
+}
+
+const getUserMetadata = `-- name: GetUserMetadata :one
+select user_id, namespace, data, created_at, updated_at from "users_metadata"
+where user_id = $1
+and namespace = $2
+`
+
+type GetUserMetadataParams struct {
+	UserID    uuid.UUID
+	Namespace string
+}
+
+func (q *Queries) GetUserMetadata(ctx context.Context, arg GetUserMetadataParams) (UsersMetadatum, error) {
+	row := q.db.QueryRow(ctx, getUserMetadata, arg.UserID, arg.Namespace)
+	var i UsersMetadatum
+	err := row.Scan(
+		&i.UserID,
+		&i.Namespace,
+		&i.Data,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserMetadataByNamespaces = `-- name: GetUserMetadataByNamespaces :many
+select user_id, namespace, data, created_at, updated_at from "users_metadata"
+where user_id = $1
+and namespace = any($2::text[])
+order by namespace
+`
+
+type GetUserMetadataByNamespacesParams struct {
+	UserID     uuid.UUID
+	Namespaces []string
+}
+
+func (q *Queries) GetUserMetadataByNamespaces(ctx context.Context, arg GetUserMetadataByNamespacesParams) ([]UsersMetadatum, error) {
+	rows, err := q.db.Query(ctx, getUserMetadataByNamespaces, arg.UserID, arg.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UsersMetadatum
+	for rows.Next() {
+		var i UsersMetadatum
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Namespace,
+			&i.Data,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserOrganizations = `-- name: GetUserOrganizations :many
+select o.id, o.name, om.role, om.created_at
+from "organization_members" om
+join "organizations" o on o.id = om.organization_id
+where om.user_id = $1
+order by om.created_at
+`
+
+type GetUserOrganizationsRow struct {
+	ID        uuid.UUID
+	Name      string
+	Role      OrganizationRole
+	CreatedAt pgtype.Timestamp
+}
+
+func (q *Queries) GetUserOrganizations(ctx context.Context, userID uuid.UUID) ([]GetUserOrganizationsRow, error) {
+	rows, err := q.db.Query(ctx, getUserOrganizations, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserOrganizationsRow
+	for rows.Next() {
+		var i GetUserOrganizationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Role,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsersByIDs = `-- name: GetUsersByIDs :many
+select id, username, pseudonym, first_name, last_name, is_deleted, created_at, updated_at, deleted_at, anonymized_at, pseudonym_skeleton, telegram_id, language_code, is_premium, photo_url, allows_write_to_pm, is_bot, first_name_source, last_name_source from "users"
+where id = any($1::uuid[])
+and "is_deleted" = false
+`
+
+func (q *Queries) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]User, error) {
+	rows, err := q.db.Query(ctx, getUsersByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Pseudonym,
+			&i.FirstName,
+			&i.LastName,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AnonymizedAt,
+			&i.PseudonymSkeleton,
+			&i.TelegramID,
+			&i.LanguageCode,
+			&i.IsPremium,
+			&i.PhotoUrl,
+			&i.AllowsWriteToPm,
+			&i.IsBot,
+			&i.FirstNameSource,
+			&i.LastNameSource,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isPseudonymTaken = `-- name: IsPseudonymTaken :one
+select exists(
+    select 1 from "users"
+    where "pseudonym_skeleton" = $1
+    and "is_deleted" = false
+    and "id" is distinct from $2
+) or exists(
+    select 1 from "pseudonym_history"
+    where "old_pseudonym_skeleton" = $1
+    and "changed_at" > $3
+    and "user_id" is distinct from $2
+)
+`
+
+type IsPseudonymTakenParams struct {
+	PseudonymSkeleton string
+	UserID            pgtype.UUID
+	ReleasedAfter     pgtype.Timestamp
+}
+
+func (q *Queries) IsPseudonymTaken(ctx context.Context, arg IsPseudonymTakenParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isPseudonymTaken, arg.PseudonymSkeleton, arg.UserID, arg.ReleasedAfter)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const lockUser = `-- name: LockUser :one
+select id from "users"
+where id = $1
+and "is_deleted" = false
+for update
+`
+
+func (q *Queries) LockUser(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, lockUser, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const releaseUsername = `-- name: ReleaseUsername :exec
+update "users"
+set "username" = 'released_' || "id",
+"updated_at" = now()
+where "username" = $1
+and "id" <> $2
+and "is_deleted" = false
+`
+
+type ReleaseUsernameParams struct {
+	Username string
+	ID       uuid.UUID
+}
+
+func (q *Queries) ReleaseUsername(ctx context.Context, arg ReleaseUsernameParams) error {
+	_, err := q.db.Exec(ctx, releaseUsername, arg.Username, arg.ID)
+	return err
+}
+
+const restoreUser = `-- name: RestoreUser :exec
+update "users"
+set "is_deleted" = false,
+"deleted_at" = null,
+"username" = $1,
+"updated_at" = now()
+where id = $2
+`
+
+type RestoreUserParams struct {
+	Username string
+	ID       uuid.UUID
+}
+
+func (q *Queries) RestoreUser(ctx context.Context, arg RestoreUserParams) error {
+	_, err := q.db.Exec(ctx, restoreUser, arg.Username, arg.ID)
+	return err
+}
+
 const saveAdmin = `-- name: SaveAdmin :exec
 insert into "users_admins" ("user_id", "scale") values ($1, $2)
 `
@@ -176,52 +973,303 @@ func (q *Queries) SaveAdmin(ctx context.Context, arg SaveAdminParams) error {
 	return err
 }
 
+const saveAdminRequest = `-- name: SaveAdminRequest :one
+insert into "admin_requests" ("action", "target_user_id", "scale", "requested_by", "expires_at")
+values ($1, $2, $3, $4, $5)
+returning id, action, target_user_id, scale, requested_by, status, decided_by, created_at, expires_at, decided_at
+`
+
+type SaveAdminRequestParams struct {
+	Action       AdminRequestAction
+	TargetUserID uuid.UUID
+	Scale        NullAdminScale
+	RequestedBy  uuid.UUID
+	ExpiresAt    pgtype.Timestamp
+}
+
+func (q *Queries) SaveAdminRequest(ctx context.Context, arg SaveAdminRequestParams) (AdminRequest, error) {
+	row := q.db.QueryRow(ctx, saveAdminRequest,
+		arg.Action,
+		arg.TargetUserID,
+		arg.Scale,
+		arg.RequestedBy,
+		arg.ExpiresAt,
+	)
+	var i AdminRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Action,
+		&i.TargetUserID,
+		&i.Scale,
+		&i.RequestedBy,
+		&i.Status,
+		&i.DecidedBy,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.DecidedAt,
+	)
+	return i, err
+}
+
+type SaveAuditEventsParams struct {
+	Action    string
+	Outcome   string
+	ActorID   pgtype.UUID
+	TargetID  pgtype.UUID
+	Ip        *string
+	UserAgent *string
+	Metadata  []byte
+	CreatedAt pgtype.Timestamp
+}
+
+const saveOrganization = `-- name: SaveOrganization :one
+insert into "organizations" ("name", "created_by")
+values ($1, $2)
+returning id, name, created_by, created_at, updated_at
+`
+
+type SaveOrganizationParams struct {
+	Name      string
+	CreatedBy uuid.UUID
+}
+
+func (q *Queries) SaveOrganization(ctx context.Context, arg SaveOrganizationParams) (Organization, error) {
+	row := q.db.QueryRow(ctx, saveOrganization, arg.Name, arg.CreatedBy)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const saveOrganizationMember = `-- name: SaveOrganizationMember :exec
+insert into "organization_members" ("organization_id", "user_id", "role")
+values ($1, $2, $3)
+`
+
+type SaveOrganizationMemberParams struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Role           OrganizationRole
+}
+
+func (q *Queries) SaveOrganizationMember(ctx context.Context, arg SaveOrganizationMemberParams) error {
+	_, err := q.db.Exec(ctx, saveOrganizationMember, arg.OrganizationID, arg.UserID, arg.Role)
+	return err
+}
+
+const saveOutboxEvents = `-- name: SaveOutboxEvents :exec
+insert into "events_outbox" ("type", "user_id")
+select $1::varchar, unnest($2::uuid[])
+`
+
+type SaveOutboxEventsParams struct {
+	Type    string
+	UserIds []uuid.UUID
+}
+
+func (q *Queries) SaveOutboxEvents(ctx context.Context, arg SaveOutboxEventsParams) error {
+	_, err := q.db.Exec(ctx, saveOutboxEvents, arg.Type, arg.UserIds)
+	return err
+}
+
+const savePseudonymChange = `-- name: SavePseudonymChange :exec
+insert into "pseudonym_history" ("user_id", "old_pseudonym", "old_pseudonym_skeleton", "new_pseudonym", "changed_by")
+values ($1, $2, $3, $4, $5)
+`
+
+type SavePseudonymChangeParams struct {
+	UserID               uuid.UUID
+	OldPseudonym         string
+	OldPseudonymSkeleton string
+	NewPseudonym         string
+	ChangedBy            uuid.UUID
+}
+
+func (q *Queries) SavePseudonymChange(ctx context.Context, arg SavePseudonymChangeParams) error {
+	_, err := q.db.Exec(ctx, savePseudonymChange,
+		arg.UserID,
+		arg.OldPseudonym,
+		arg.OldPseudonymSkeleton,
+		arg.NewPseudonym,
+		arg.ChangedBy,
+	)
+	return err
+}
+
 const saveUser = `-- name: SaveUser :one
-insert into "users" ("username", "pseudonym", "first_name", "last_name")
-values ($1, $2, $3, $4)
+insert into "users" (
+    "username", "pseudonym", "pseudonym_skeleton", "first_name", "last_name",
+    "telegram_id", "language_code", "is_premium", "photo_url", "allows_write_to_pm", "is_bot"
+)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 returning "id"
 `
 
 type SaveUserParams struct {
-	Username  string
-	Pseudonym string
-	FirstName string
-	LastName  string
+	Username          string
+	Pseudonym         string
+	PseudonymSkeleton string
+	FirstName         string
+	LastName          string
+	TelegramID        *int64
+	LanguageCode      *string
+	IsPremium         bool
+	PhotoUrl          *string
+	AllowsWriteToPm   bool
+	IsBot             bool
 }
 
 func (q *Queries) SaveUser(ctx context.Context, arg SaveUserParams) (uuid.UUID, error) {
 	row := q.db.QueryRow(ctx, saveUser,
 		arg.Username,
 		arg.Pseudonym,
+		arg.PseudonymSkeleton,
 		arg.FirstName,
 		arg.LastName,
+		arg.TelegramID,
+		arg.LanguageCode,
+		arg.IsPremium,
+		arg.PhotoUrl,
+		arg.AllowsWriteToPm,
+		arg.IsBot,
 	)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
 }
 
+const saveUserDeletion = `-- name: SaveUserDeletion :exec
+insert into "users_deletions" ("user_id", "deleted_by")
+values ($1, $2)
+on conflict ("user_id") do update
+set "deleted_by" = excluded."deleted_by",
+"created_at" = now()
+`
+
+type SaveUserDeletionParams struct {
+	UserID    uuid.UUID
+	DeletedBy uuid.UUID
+}
+
+func (q *Queries) SaveUserDeletion(ctx context.Context, arg SaveUserDeletionParams) error {
+	_, err := q.db.Exec(ctx, saveUserDeletion, arg.UserID, arg.DeletedBy)
+	return err
+}
+
+const setUserMetadata = `-- name: SetUserMetadata :one
+insert into "users_metadata" (user_id, namespace, data)
+values ($1, $2, $3)
+on conflict (user_id, namespace) do update
+set data = excluded.data,
+updated_at = now()
+returning user_id, namespace, data, created_at, updated_at
+`
+
+type SetUserMetadataParams struct {
+	UserID    uuid.UUID
+	Namespace string
+	Data      []byte
+}
+
+func (q *Queries) SetUserMetadata(ctx context.Context, arg SetUserMetadataParams) (UsersMetadatum, error) {
+	row := q.db.QueryRow(ctx, setUserMetadata, arg.UserID, arg.Namespace, arg.Data)
+	var i UsersMetadatum
+	err := row.Scan(
+		&i.UserID,
+		&i.Namespace,
+		&i.Data,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const syncTelegramProfile = `-- name: SyncTelegramProfile :execrows
+update "users"
+set "username" = $1,
+"first_name" = case when "first_name_source" = 'telegram' then $2 else "first_name" end,
+"last_name" = case when "last_name_source" = 'telegram' then $3 else "last_name" end,
+"telegram_id" = $4,
+"language_code" = $5,
+"is_premium" = $6,
+"photo_url" = $7,
+"allows_write_to_pm" = $8,
+"updated_at" = now()
+where id = $9
+and "is_deleted" = false
+and (
+    "username" is distinct from $1
+    or ("first_name_source" = 'telegram' and "first_name" is distinct from $2)
+    or ("last_name_source" = 'telegram' and "last_name" is distinct from $3)
+    or "telegram_id" is distinct from $4
+    or "language_code" is distinct from $5
+    or "is_premium" is distinct from $6
+    or "photo_url" is distinct from $7
+    or "allows_write_to_pm" is distinct from $8
+)
+`
+
+type SyncTelegramProfileParams struct {
+	Username        string
+	FirstName       string
+	LastName        string
+	TelegramID      *int64
+	LanguageCode    *string
+	IsPremium       bool
+	PhotoUrl        *string
+	AllowsWriteToPm bool
+	ID              uuid.UUID
+}
+
+func (q *Queries) SyncTelegramProfile(ctx context.Context, arg SyncTelegramProfileParams) (int64, error) {
+	result, err := q.db.Exec(ctx, syncTelegramProfile,
+		arg.Username,
+		arg.FirstName,
+		arg.LastName,
+		arg.TelegramID,
+		arg.LanguageCode,
+		arg.IsPremium,
+		arg.PhotoUrl,
+		arg.AllowsWriteToPm,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 update "users"
 set "pseudonym" = coalesce($1, "pseudonym"),
-"first_name" = coalesce($2, "first_name"),
-"last_name" = coalesce($3, "last_name"),
+"pseudonym_skeleton" = coalesce($2, "pseudonym_skeleton"),
+"first_name" = coalesce($3, "first_name"),
+"first_name_source" = case when $3 is null then "first_name_source" else 'user' end,
+"last_name" = coalesce($4, "last_name"),
+"last_name_source" = case when $4 is null then "last_name_source" else 'user' end,
 "updated_at" = now()
-where id = $4
+where id = $5
 and "is_deleted" = false
-returning id, username, pseudonym, first_name, last_name, is_deleted, created_at, updated_at
+returning id, username, pseudonym, first_name, last_name, is_deleted, created_at, updated_at, deleted_at, anonymized_at, pseudonym_skeleton, telegram_id, language_code, is_premium, photo_url, allows_write_to_pm, is_bot, first_name_source, last_name_source
 `
 
 type UpdateUserParams struct {
-	Pseudonym *string
-	FirstName *string
-	LastName  *string
-	ID        uuid.UUID
+	Pseudonym         *string
+	PseudonymSkeleton *string
+	FirstName         *string
+	LastName          *string
+	ID                uuid.UUID
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
 	row := q.db.QueryRow(ctx, updateUser,
 		arg.Pseudonym,
+		arg.PseudonymSkeleton,
 		arg.FirstName,
 		arg.LastName,
 		arg.ID,
@@ -236,6 +1284,17 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.IsDeleted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.AnonymizedAt,
+		&i.PseudonymSkeleton,
+		&i.TelegramID,
+		&i.LanguageCode,
+		&i.IsPremium,
+		&i.PhotoUrl,
+		&i.AllowsWriteToPm,
+		&i.IsBot,
+		&i.FirstNameSource,
+		&i.LastNameSource,
 	)
 	return i, err
 }
@@ -0,0 +1,3 @@
+drop table if exists "admin_requests" cascade;
+drop type if exists "admin_request_status" cascade;
+drop type if exists "admin_request_action" cascade;
\ No newline at end of file
@@ -0,0 +1,20 @@
+create type "admin_request_action" as enum ('add_admin', 'delete_admin');
+create type "admin_request_status" as enum ('pending', 'approved', 'rejected', 'expired');
+
+create table if not exists "admin_requests" (
+    "id" uuid primary key default uuid_generate_v4(),
+    "action" admin_request_action not null,
+    "target_user_id" uuid not null,
+    "scale" admin_scale,
+    "requested_by" uuid not null,
+    "status" admin_request_status not null default 'pending',
+    "decided_by" uuid,
+    "created_at" timestamp not null default now(),
+    "expires_at" timestamp not null,
+    "decided_at" timestamp
+);
+
+alter table "admin_requests" add foreign key ("target_user_id") references "users" ("id");
+alter table "admin_requests" add foreign key ("requested_by") references "users" ("id");
+alter table "admin_requests" add foreign key ("decided_by") references "users" ("id");
+create index on "admin_requests" ("status", "expires_at");
\ No newline at end of file
@@ -0,0 +1,3 @@
+drop table if exists "organization_members" cascade;
+drop table if exists "organizations" cascade;
+drop type if exists "organization_role" cascade;
\ No newline at end of file
@@ -0,0 +1,23 @@
+create type "organization_role" as enum ('owner', 'admin', 'member');
+
+create table if not exists "organizations" (
+    "id" uuid primary key default uuid_generate_v4(),
+    "name" varchar(128) not null,
+    "created_by" uuid not null,
+    "created_at" timestamp not null default now(),
+    "updated_at" timestamp not null default now()
+);
+
+alter table "organizations" add foreign key ("created_by") references "users" ("id");
+
+create table if not exists "organization_members" (
+    "organization_id" uuid not null,
+    "user_id" uuid not null,
+    "role" organization_role not null,
+    "created_at" timestamp not null default now(),
+    primary key ("organization_id", "user_id")
+);
+
+alter table "organization_members" add foreign key ("organization_id") references "organizations" ("id") on delete cascade;
+alter table "organization_members" add foreign key ("user_id") references "users" ("id");
+create index on "organization_members" ("user_id");
\ No newline at end of file
@@ -0,0 +1,3 @@
+drop index if exists "users_username_active_key";
+
+alter table "users" add constraint "users_username_key" unique (username);
\ No newline at end of file
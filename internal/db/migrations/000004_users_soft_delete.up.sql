@@ -0,0 +1,4 @@
+alter table "users" drop constraint if exists "users_username_key";
+
+-- Deleted accounts keep their username, so it must be unique only among active users
+create unique index if not exists "users_username_active_key" on "users" ("username") where "is_deleted" = false;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table "users" drop column if exists "anonymized_at";
+alter table "users" drop column if exists "deleted_at";
\ No newline at end of file
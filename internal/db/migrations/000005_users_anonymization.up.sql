@@ -0,0 +1,6 @@
+alter table "users" add column if not exists "deleted_at" timestamp;
+alter table "users" add column if not exists "anonymized_at" timestamp;
+
+update "users" set "deleted_at" = "updated_at" where "is_deleted" = true;
+
+create index on "users" ("deleted_at") where "is_deleted" = true and "anonymized_at" is null;
\ No newline at end of file
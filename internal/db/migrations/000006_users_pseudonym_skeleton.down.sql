@@ -0,0 +1,2 @@
+drop index if exists "users_pseudonym_skeleton_active_key";
+alter table "users" drop column if exists "pseudonym_skeleton";
\ No newline at end of file
@@ -0,0 +1,18 @@
+alter table "users" add column if not exists "pseudonym_skeleton" text;
+
+-- Skeleton of existing users is approximated by lower case pseudonym, it is recomputed on next change.
+-- Duplicates which already exist keep their pseudonym and get unique skeleton to not break the index.
+update "users" u
+set "pseudonym_skeleton" = lower(u."pseudonym") || case when d.rn > 1 then '#' || u."id" else '' end
+from (
+    select "id", row_number() over (partition by lower("pseudonym") order by "created_at") as rn
+    from "users"
+    where "is_deleted" = false
+) d
+where d."id" = u."id";
+
+update "users" set "pseudonym_skeleton" = lower("pseudonym") where "pseudonym_skeleton" is null;
+
+alter table "users" alter column "pseudonym_skeleton" set not null;
+
+create unique index "users_pseudonym_skeleton_active_key" on "users" ("pseudonym_skeleton") where "is_deleted" = false;
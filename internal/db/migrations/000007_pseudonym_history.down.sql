@@ -0,0 +1 @@
+drop table if exists "pseudonym_history";
\ No newline at end of file
@@ -0,0 +1,14 @@
+create table if not exists "pseudonym_history" (
+    "id" uuid primary key default uuid_generate_v4(),
+    "user_id" uuid not null,
+    "old_pseudonym" varchar(64) not null,
+    "old_pseudonym_skeleton" text not null,
+    "new_pseudonym" varchar(64) not null,
+    "changed_by" uuid not null,
+    "changed_at" timestamp not null default now()
+);
+
+alter table "pseudonym_history" add foreign key ("user_id") references "users" ("id");
+alter table "pseudonym_history" add foreign key ("changed_by") references "users" ("id");
+create index on "pseudonym_history" ("user_id", "changed_at");
+create index on "pseudonym_history" ("old_pseudonym_skeleton", "changed_at");
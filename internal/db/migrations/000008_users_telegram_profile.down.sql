@@ -0,0 +1,8 @@
+drop index if exists "users_telegram_id_active_key";
+
+alter table "users" drop column if exists "is_bot";
+alter table "users" drop column if exists "allows_write_to_pm";
+alter table "users" drop column if exists "photo_url";
+alter table "users" drop column if exists "is_premium";
+alter table "users" drop column if exists "language_code";
+alter table "users" drop column if exists "telegram_id";
\ No newline at end of file
@@ -0,0 +1,8 @@
+alter table "users" add column if not exists "telegram_id" bigint;
+alter table "users" add column if not exists "language_code" varchar(35);
+alter table "users" add column if not exists "is_premium" boolean not null default false;
+alter table "users" add column if not exists "photo_url" text;
+alter table "users" add column if not exists "allows_write_to_pm" boolean not null default false;
+alter table "users" add column if not exists "is_bot" boolean not null default false;
+
+create unique index if not exists "users_telegram_id_active_key" on "users" ("telegram_id") where "is_deleted" = false;
\ No newline at end of file
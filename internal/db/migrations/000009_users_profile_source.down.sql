@@ -0,0 +1,4 @@
+alter table "users" drop column if exists "last_name_source";
+alter table "users" drop column if exists "first_name_source";
+
+drop type if exists "profile_field_source";
\ No newline at end of file
@@ -0,0 +1,5 @@
+create type "profile_field_source" as enum ('telegram', 'user');
+
+-- Fields edited through UpdateUser are no longer overwritten by Telegram profile on login
+alter table "users" add column if not exists "first_name_source" profile_field_source not null default 'telegram';
+alter table "users" add column if not exists "last_name_source" profile_field_source not null default 'telegram';
\ No newline at end of file
@@ -0,0 +1 @@
+drop table if exists "users_metadata";
\ No newline at end of file
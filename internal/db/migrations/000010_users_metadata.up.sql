@@ -0,0 +1,10 @@
+create table if not exists "users_metadata" (
+    "user_id" uuid not null,
+    "namespace" varchar(64) not null,
+    "data" jsonb not null default '{}',
+    "created_at" timestamp not null default now(),
+    "updated_at" timestamp not null default now(),
+    primary key ("user_id", "namespace")
+);
+
+alter table "users_metadata" add foreign key ("user_id") references "users" ("id") on delete cascade;
\ No newline at end of file
@@ -0,0 +1,5 @@
+drop index if exists "users_search_tsv_idx";
+drop index if exists "users_search_trgm_idx";
+
+drop function if exists "users_search_text"(text, text, text, text);
+drop function if exists "immutable_unaccent"(text);
\ No newline at end of file
@@ -0,0 +1,20 @@
+create extension if not exists "pg_trgm";
+create extension if not exists "unaccent";
+
+-- unaccent is only stable because dictionary can change, wrapper with fixed dictionary
+-- is immutable and can be used in index expressions
+create or replace function "immutable_unaccent"(text) returns text
+language sql immutable parallel safe strict
+as $$ select public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+create or replace function "users_search_text"("username" text, "pseudonym" text, "first_name" text, "last_name" text) returns text
+language sql immutable parallel safe
+as $$ select lower(immutable_unaccent(concat_ws(' ', "username", "pseudonym", "first_name", "last_name"))) $$;
+
+create index if not exists "users_search_trgm_idx" on "users"
+using gin (users_search_text("username", "pseudonym", "first_name", "last_name") gin_trgm_ops)
+where "is_deleted" = false;
+
+create index if not exists "users_search_tsv_idx" on "users"
+using gin (to_tsvector('simple', users_search_text("username", "pseudonym", "first_name", "last_name")))
+where "is_deleted" = false;
\ No newline at end of file
@@ -0,0 +1,17 @@
+create table if not exists "audit_events" (
+    "id" uuid primary key default uuid_generate_v4(),
+    "action" varchar(32) not null,
+    "outcome" varchar(16) not null,
+    "actor_id" uuid,
+    "target_id" uuid,
+    "ip" varchar(64),
+    "user_agent" text,
+    "metadata" jsonb not null default '{}',
+    "created_at" timestamp not null default now()
+);
+
+-- events outlive users, so actor and target have no foreign keys
+create index on "audit_events" ("created_at");
+create index on "audit_events" ("actor_id", "created_at");
+create index on "audit_events" ("target_id", "created_at");
+create index on "audit_events" ("action", "created_at");
\ No newline at end of file
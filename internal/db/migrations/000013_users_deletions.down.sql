@@ -0,0 +1 @@
+drop table if exists "users_deletions";
\ No newline at end of file
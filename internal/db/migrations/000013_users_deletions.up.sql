@@ -0,0 +1,9 @@
+create table if not exists "users_deletions" (
+    "user_id" uuid primary key,
+    "deleted_by" uuid not null,
+    "created_at" timestamp not null default now()
+);
+
+-- deletions by admins can not be undone by users logging in again
+alter table "users_deletions" add foreign key ("user_id") references "users" ("id");
+alter table "users_deletions" add foreign key ("deleted_by") references "users" ("id");
\ No newline at end of file
@@ -0,0 +1 @@
+drop table if exists "events_outbox";
\ No newline at end of file
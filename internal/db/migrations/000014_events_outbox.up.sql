@@ -0,0 +1,9 @@
+create table if not exists "events_outbox" (
+    "id" uuid primary key default uuid_generate_v4(),
+    "type" varchar(64) not null,
+    "user_id" uuid not null,
+    "occurred_at" timestamp not null default now()
+);
+
+-- events are saved with changes they describe and deleted once published
+create index on "events_outbox" ("occurred_at");
\ No newline at end of file
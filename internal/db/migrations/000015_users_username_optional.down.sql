@@ -0,0 +1,3 @@
+drop index if exists "users_username_active_key";
+
+create unique index if not exists "users_username_active_key" on "users" ("username") where "is_deleted" = false;
\ No newline at end of file
@@ -0,0 +1,4 @@
+drop index if exists "users_username_active_key";
+
+-- Telegram usernames are optional, users without one share empty username
+create unique index if not exists "users_username_active_key" on "users" ("username") where "is_deleted" = false and "username" <> '';
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- Digits and symbols are folded into letters of skeleton again, active users whose folded skeleton
+-- is taken keep the current one.
+with folded as (
+    select "id", "is_deleted", translate("pseudonym_skeleton", '0134578@$|!', 'oleastbasli') as "skeleton",
+        row_number() over (partition by "is_deleted", translate("pseudonym_skeleton", '0134578@$|!', 'oleastbasli') order by "created_at") as rn
+    from "users"
+    where "pseudonym_skeleton" ~ '[0134578@$|!]'
+)
+update "users" u
+set "pseudonym_skeleton" = f."skeleton"
+from folded f
+where f."id" = u."id"
+and (f."is_deleted" or (f.rn = 1 and not exists (
+    select 1 from "users" o
+    where o."is_deleted" = false
+    and o."id" <> u."id"
+    and o."pseudonym_skeleton" = f."skeleton"
+)));
+
+update "pseudonym_history"
+set "old_pseudonym_skeleton" = translate("old_pseudonym_skeleton", '0134578@$|!', 'oleastbasli')
+where "old_pseudonym_skeleton" ~ '[0134578@$|!]';
\ No newline at end of file
@@ -0,0 +1,34 @@
+-- Digits and symbols are no longer folded into letters in pseudonym skeleton, they are folded only when
+-- pseudonym is matched against reserved and blocked words. Skeletons of pseudonyms with them are recomputed
+-- the way service computes them, combining marks are approximated by the combining diacritical marks block.
+create function pg_temp.pseudonym_skeleton(pseudonym text) returns text
+language sql immutable
+as $$
+    select translate(
+        regexp_replace(lower(normalize(pseudonym, nfkd)), '[\u0300-\u036f[:space:]_.-]', '', 'g'),
+        'авеёкмнорстухѕіјԁαβεηικνορτυχɡı',
+        'abeekmhopctyxsijdabenikvoptuxgi'
+    )
+$$;
+
+-- Active users whose recomputed skeleton is taken keep the current one, it is recomputed on next change.
+with recomputed as (
+    select "id", "is_deleted", pg_temp.pseudonym_skeleton("pseudonym") as "skeleton",
+        row_number() over (partition by "is_deleted", pg_temp.pseudonym_skeleton("pseudonym") order by "created_at") as rn
+    from "users"
+    where normalize("pseudonym", nfkd) ~ '[0134578@$|!]'
+)
+update "users" u
+set "pseudonym_skeleton" = r."skeleton"
+from recomputed r
+where r."id" = u."id"
+and (r."is_deleted" or (r.rn = 1 and not exists (
+    select 1 from "users" o
+    where o."is_deleted" = false
+    and o."id" <> u."id"
+    and o."pseudonym_skeleton" = r."skeleton"
+)));
+
+update "pseudonym_history"
+set "old_pseudonym_skeleton" = pg_temp.pseudonym_skeleton("old_pseudonym")
+where normalize("old_pseudonym", nfkd) ~ '[0134578@$|!]';
\ No newline at end of file
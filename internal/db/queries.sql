@@ -1,13 +1,19 @@
 -- name: SaveUser :one
-insert into "users" ("username", "pseudonym", "first_name", "last_name")
-values ($1, $2, $3, $4)
+insert into "users" (
+    "username", "pseudonym", "pseudonym_skeleton", "first_name", "last_name",
+    "telegram_id", "language_code", "is_premium", "photo_url", "allows_write_to_pm", "is_bot"
+)
+values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 returning "id";
 
 -- name: UpdateUser :one
 update "users"
 set "pseudonym" = coalesce(sqlc.narg('pseudonym'), "pseudonym"),
+"pseudonym_skeleton" = coalesce(sqlc.narg('pseudonym_skeleton'), "pseudonym_skeleton"),
 "first_name" = coalesce(sqlc.narg('first_name'), "first_name"),
+"first_name_source" = case when sqlc.narg('first_name') is null then "first_name_source" else 'user' end,
 "last_name" = coalesce(sqlc.narg('last_name'), "last_name"),
+"last_name_source" = case when sqlc.narg('last_name') is null then "last_name_source" else 'user' end,
 "updated_at" = now()
 where id = sqlc.arg('id')
 and "is_deleted" = false
@@ -24,6 +30,19 @@ left join "users_admins" ua on u.id = ua.user_id
 where u.username = $1
 and "is_deleted" = false;
 
+-- name: GetUserAdminByTelegramID :one
+select u.id, ua.scale from "users" u
+left join "users_admins" ua on u.id = ua.user_id
+where u.telegram_id = $1
+and "is_deleted" = false;
+
+-- name: GetLegacyUserAdminByUsername :one
+select u.id, ua.scale from "users" u
+left join "users_admins" ua on u.id = ua.user_id
+where u.username = $1
+and u.telegram_id is null
+and "is_deleted" = false;
+
 -- name: GetUserAdminByID :one
 select u.id, ua.scale from "users" u
 left join "users_admins" ua on u.id = ua.user_id
@@ -31,23 +50,23 @@ where u.id = $1
 and "is_deleted" = false;
 
 
+-- name: LockUser :one
+select id from "users"
+where id = $1
+and "is_deleted" = false
+for update;
+
+-- name: GetAdminScaleForUpdate :one
+select scale from "users_admins"
+where user_id = $1
+for update;
+
 -- name: SaveAdmin :exec
 insert into "users_admins" ("user_id", "scale") values ($1, $2);
 
 -- name: DeleteAdmin :exec
 delete from "users_admins" where user_id = $1;
 
--- name: GetAdmins :many
-select u.id, u.username, ua.scale, ua.created_at
-from "users_admins" ua
-join "users" u on u.id = ua.user_id
-where u.id = coalesce(sqlc.narg('user_id'), u.id)
-and u.username = coalesce(sqlc.narg('username'), u.username)
-and ua.scale = coalesce(sqlc.narg('admin_scale'), ua.scale)
-and u.is_deleted = false
-order by ua.created_at
-limit sqlc.arg('limit') offset sqlc.arg('offset');
-
 -- name: CountAdmins :one
 select count(*)
 from "users_admins" ua
@@ -55,4 +74,269 @@ join "users" u on u.id = ua.user_id
 where u.id = coalesce(sqlc.narg('user_id'), u.id)
 and u.username = coalesce(sqlc.narg('username'), u.username)
 and ua.scale = coalesce(sqlc.narg('admin_scale'), ua.scale)
-and u.is_deleted = false;
\ No newline at end of file
+and u.is_deleted = false;
+
+-- name: SaveAdminRequest :one
+insert into "admin_requests" ("action", "target_user_id", "scale", "requested_by", "expires_at")
+values ($1, $2, $3, $4, $5)
+returning *;
+
+-- name: GetAdminRequestByID :one
+select * from "admin_requests"
+where id = $1;
+
+-- name: GetAdminRequests :many
+select * from "admin_requests"
+where status = coalesce(sqlc.narg('status'), status)
+order by created_at desc
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: CountAdminRequests :one
+select count(*) from "admin_requests"
+where status = coalesce(sqlc.narg('status'), status);
+
+-- name: DecideAdminRequest :one
+update "admin_requests"
+set "status" = sqlc.arg('status'),
+"decided_by" = sqlc.arg('decided_by'),
+"decided_at" = now()
+where id = sqlc.arg('id')
+and "status" = 'pending'
+and "expires_at" > now()
+returning *;
+
+-- name: ExpireAdminRequests :execrows
+update "admin_requests"
+set "status" = 'expired'
+where "status" = 'pending'
+and "expires_at" <= now();
+
+-- name: SaveOrganization :one
+insert into "organizations" ("name", "created_by")
+values ($1, $2)
+returning *;
+
+-- name: SaveOrganizationMember :exec
+insert into "organization_members" ("organization_id", "user_id", "role")
+values ($1, $2, $3);
+
+-- name: GetOrganizationMember :one
+select * from "organization_members"
+where organization_id = $1
+and user_id = $2;
+
+-- name: DeleteOrganizationMember :exec
+delete from "organization_members"
+where organization_id = $1
+and user_id = $2;
+
+-- name: GetUserOrganizations :many
+select o.id, o.name, om.role, om.created_at
+from "organization_members" om
+join "organizations" o on o.id = om.organization_id
+where om.user_id = $1
+order by om.created_at;
+
+-- name: DeleteUser :execrows
+update "users"
+set "is_deleted" = true,
+"deleted_at" = now(),
+"updated_at" = now()
+where id = $1
+and "is_deleted" = false;
+
+-- name: SaveUserDeletion :exec
+insert into "users_deletions" ("user_id", "deleted_by")
+values ($1, $2)
+on conflict ("user_id") do update
+set "deleted_by" = excluded."deleted_by",
+"created_at" = now();
+
+-- name: DeleteUserDeletion :exec
+delete from "users_deletions"
+where user_id = $1;
+
+-- name: GetRestorableUser :one
+select u.id, ud.deleted_by from "users" u
+left join "users_deletions" ud on u.id = ud.user_id
+where (u.telegram_id = sqlc.narg('telegram_id') or (u.telegram_id is null and u.username = sqlc.arg('username') and u.username <> ''))
+and u.is_deleted = true
+and u.anonymized_at is null
+and u.deleted_at > sqlc.arg('deleted_after')
+order by u.deleted_at desc
+limit 1;
+
+-- name: RestoreUser :exec
+update "users"
+set "is_deleted" = false,
+"deleted_at" = null,
+"username" = sqlc.arg('username'),
+"updated_at" = now()
+where id = sqlc.arg('id');
+
+-- name: AnonymizeDeletedUsers :many
+update "users"
+set "username" = 'deleted_' || "id",
+"pseudonym" = 'deleted',
+"pseudonym_skeleton" = 'deleted',
+"first_name" = '',
+"last_name" = '',
+"telegram_id" = null,
+"language_code" = null,
+"photo_url" = null,
+"anonymized_at" = now(),
+"updated_at" = now()
+where "is_deleted" = true
+and "anonymized_at" is null
+and "deleted_at" <= $1
+returning id;
+
+-- name: GetAdminByUserID :one
+select * from "users_admins"
+where user_id = $1;
+
+-- name: GetUserAdminRequests :many
+select * from "admin_requests"
+where target_user_id = $1
+or requested_by = $1
+or decided_by = $1
+order by created_at;
+
+-- name: IsPseudonymTaken :one
+select exists(
+    select 1 from "users"
+    where "pseudonym_skeleton" = sqlc.arg('pseudonym_skeleton')
+    and "is_deleted" = false
+    and "id" is distinct from sqlc.narg('user_id')
+) or exists(
+    select 1 from "pseudonym_history"
+    where "old_pseudonym_skeleton" = sqlc.arg('pseudonym_skeleton')
+    and "changed_at" > sqlc.arg('released_after')
+    and "user_id" is distinct from sqlc.narg('user_id')
+);
+
+-- name: GetUserByIDForUpdate :one
+select * from "users"
+where id = $1
+and "is_deleted" = false
+for update;
+
+-- name: GetUsersByIDs :many
+select * from "users"
+where id = any(sqlc.arg('ids')::uuid[])
+and "is_deleted" = false;
+
+-- name: SavePseudonymChange :exec
+insert into "pseudonym_history" ("user_id", "old_pseudonym", "old_pseudonym_skeleton", "new_pseudonym", "changed_by")
+values ($1, $2, $3, $4, $5);
+
+-- name: GetLastPseudonymChange :one
+select * from "pseudonym_history"
+where user_id = $1
+order by changed_at desc
+limit 1;
+
+-- name: GetPseudonymHistory :many
+select * from "pseudonym_history"
+where user_id = $1
+order by changed_at;
+
+-- name: ReleaseUsername :exec
+update "users"
+set "username" = 'released_' || "id",
+"updated_at" = now()
+where "username" = sqlc.arg('username')
+and "id" <> sqlc.arg('id')
+and "is_deleted" = false;
+
+-- name: SyncTelegramProfile :execrows
+update "users"
+set "username" = sqlc.arg('username'),
+"first_name" = case when "first_name_source" = 'telegram' then sqlc.arg('first_name') else "first_name" end,
+"last_name" = case when "last_name_source" = 'telegram' then sqlc.arg('last_name') else "last_name" end,
+"telegram_id" = sqlc.narg('telegram_id'),
+"language_code" = sqlc.narg('language_code'),
+"is_premium" = sqlc.arg('is_premium'),
+"photo_url" = sqlc.narg('photo_url'),
+"allows_write_to_pm" = sqlc.arg('allows_write_to_pm'),
+"updated_at" = now()
+where id = sqlc.arg('id')
+and "is_deleted" = false
+and (
+    "username" is distinct from sqlc.arg('username')
+    or ("first_name_source" = 'telegram' and "first_name" is distinct from sqlc.arg('first_name'))
+    or ("last_name_source" = 'telegram' and "last_name" is distinct from sqlc.arg('last_name'))
+    or "telegram_id" is distinct from sqlc.narg('telegram_id')
+    or "language_code" is distinct from sqlc.narg('language_code')
+    or "is_premium" is distinct from sqlc.arg('is_premium')
+    or "photo_url" is distinct from sqlc.narg('photo_url')
+    or "allows_write_to_pm" is distinct from sqlc.arg('allows_write_to_pm')
+);
+
+-- name: GetUserMetadata :one
+select * from "users_metadata"
+where user_id = $1
+and namespace = $2;
+
+-- name: GetUserMetadataByNamespaces :many
+select * from "users_metadata"
+where user_id = sqlc.arg('user_id')
+and namespace = any(sqlc.arg('namespaces')::text[])
+order by namespace;
+
+-- name: GetAllUserMetadata :many
+select * from "users_metadata"
+where user_id = $1
+order by namespace;
+
+-- name: SetUserMetadata :one
+insert into "users_metadata" (user_id, namespace, data)
+values ($1, $2, $3)
+on conflict (user_id, namespace) do update
+set data = excluded.data,
+updated_at = now()
+returning *;
+
+-- name: DeleteUsersMetadata :exec
+delete from "users_metadata"
+where user_id = any(sqlc.arg('user_ids')::uuid[]);
+
+-- name: DeleteUsersPseudonymHistory :exec
+delete from "pseudonym_history"
+where user_id = any(sqlc.arg('user_ids')::uuid[]);
+
+-- name: AnonymizeUsersAuditEvents :exec
+update "audit_events"
+set "ip" = case when actor_id = any(sqlc.arg('user_ids')::uuid[]) then null else "ip" end,
+"user_agent" = case when actor_id = any(sqlc.arg('user_ids')::uuid[]) then null else "user_agent" end,
+"metadata" = case when target_id = any(sqlc.arg('user_ids')::uuid[]) then "metadata" - sqlc.arg('keys')::text[] else "metadata" end
+where actor_id = any(sqlc.arg('user_ids')::uuid[])
+or target_id = any(sqlc.arg('user_ids')::uuid[]);
+
+-- name: SaveOutboxEvents :exec
+insert into "events_outbox" ("type", "user_id")
+select sqlc.arg('type')::varchar, unnest(sqlc.arg('user_ids')::uuid[]);
+
+-- name: GetOutboxEventsForUpdate :many
+select * from "events_outbox"
+order by "occurred_at"
+limit $1
+for update skip locked;
+
+-- name: DeleteOutboxEvent :exec
+delete from "events_outbox"
+where id = $1;
+
+-- name: SaveAuditEvents :copyfrom
+insert into "audit_events" (action, outcome, actor_id, target_id, ip, user_agent, metadata, created_at)
+values ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetUserAuditEvents :many
+select * from "audit_events"
+where actor_id = sqlc.arg('user_id')
+or target_id = sqlc.arg('user_id')
+order by created_at;
+
+-- name: DeleteAuditEventsBefore :execrows
+delete from "audit_events"
+where "created_at" < $1;
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited actions.
+const (
+	AuditActionLogin               = "login"
+	AuditActionSignup              = "signup"
+	AuditActionRefresh             = "refresh"
+	AuditActionAddAdmin            = "add_admin"
+	AuditActionDeleteAdmin         = "delete_admin"
+	AuditActionInitAdmin           = "init_admin"
+	AuditActionApproveAdminRequest = "approve_admin_request"
+	AuditActionRejectAdminRequest  = "reject_admin_request"
+	AuditActionDeleteUser          = "delete_user"
+	AuditActionSetPseudonym        = "set_pseudonym"
+)
+
+// AuditPersonalMetadata are metadata keys of audit events which hold personal data of target, they
+// are removed when target is anonymized. Address and user agent of anonymized actor are removed too.
+var AuditPersonalMetadata = []string{"username", "pseudonym"}
+
+// Outcomes of audited actions, admin changes waiting for approval are pending.
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+	AuditOutcomePending = "pending"
+)
+
+type (
+	// AuditEvent is security relevant action, actor is unknown for failed logins and
+	// target is unknown for admin changes requested by username which failed.
+	AuditEvent struct {
+		Action     string
+		Outcome    string
+		ActorID    *uuid.UUID
+		TargetID   *uuid.UUID
+		Client     Client
+		Metadata   map[string]string
+		OccurredAt time.Time
+	}
+
+	// Client is address and user agent of caller, it is taken from request metadata.
+	Client struct {
+		IP        string
+		UserAgent string
+	}
+
+	GetAuditEventsParams struct {
+		ActorID  *uuid.UUID
+		TargetID *uuid.UUID
+		Action   *string
+		Outcome  *string
+		From     *time.Time
+		To       *time.Time
+		Limit    uint64
+		Offset   uint64
+	}
+)
+
+type clientContextKey struct{}
+
+func ContextWithClient(ctx context.Context, client Client) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, client)
+}
+
+// ClientFromContext returns caller of request, it is empty outside of requests.
+func ClientFromContext(ctx context.Context) Client {
+	client, _ := ctx.Value(clientContextKey{}).(Client)
+	return client
+}
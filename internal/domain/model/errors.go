@@ -3,13 +3,43 @@ package model
 import "errors"
 
 var (
-	ErrUnauthorized           = errors.New("unauthorized")
-	ErrRefreshTokenNotValid   = errors.New("refresh token not valid")
-	ErrUserNotFound           = errors.New("user not found")
-	ErrAdminAlreadyExists     = errors.New("admin already exists")
-	ErrAdminNotMajor          = errors.New("admin must be major")
-	ErrCannotDeleteMajorAdmin = errors.New("cannot delete major admin")
-	ErrOrderByInvalidField    = errors.New("orderBy: invalid field")
-	ErrAdminNotFound          = errors.New("admin not found")
-	ErrEmptyPseudonym         = errors.New("empty pseudonym")
+	ErrUnauthorized               = errors.New("unauthorized")
+	ErrRefreshTokenNotValid       = errors.New("refresh token not valid")
+	ErrUserNotFound               = errors.New("user not found")
+	ErrAdminAlreadyExists         = errors.New("admin already exists")
+	ErrAdminNotMajor              = errors.New("admin must be major")
+	ErrCannotDeleteMajorAdmin     = errors.New("cannot delete major admin")
+	ErrLastMajorAdmin             = errors.New("cannot delete the last major admin")
+	ErrOrderByInvalidField        = errors.New("orderBy: invalid field")
+	ErrAdminNotFound              = errors.New("admin not found")
+	ErrEmptyPseudonym             = errors.New("empty pseudonym")
+	ErrAdminChangePending         = errors.New("admin change pending approval")
+	ErrAdminRequestNotFound       = errors.New("admin request not found")
+	ErrSelfApproval               = errors.New("cannot decide own admin request")
+	ErrOrganizationMemberNotFound = errors.New("organization member not found")
+	ErrOrganizationMemberExists   = errors.New("organization member already exists")
+	ErrOrganizationRoleForbidden  = errors.New("insufficient organization role")
+	ErrCannotRemoveOwner          = errors.New("cannot remove organization owner")
+	ErrTenantMismatch             = errors.New("token is scoped to another organization")
+	ErrExportNotFound             = errors.New("export not found")
+	ErrExportPending              = errors.New("export is not ready yet")
+	ErrExportFailed               = errors.New("export failed")
+	ErrExportUnavailable          = errors.New("export can not be scheduled now")
+	ErrPseudonymTaken             = errors.New("pseudonym already taken")
+	ErrUsernameTaken              = errors.New("username already taken")
+	ErrUserDeletedByAdmin         = errors.New("user was deleted by admin")
+	ErrPseudonymNotAllowed        = errors.New("pseudonym not allowed")
+	ErrPseudonymCooldown          = errors.New("pseudonym was changed recently")
+	ErrBotNotAllowed              = errors.New("bot accounts are not allowed")
+	ErrMetadataNotFound           = errors.New("metadata not found")
+	ErrMetadataNamespaceNotFound  = errors.New("metadata namespace not found")
+	ErrMetadataForbidden          = errors.New("metadata namespace is owned by another client")
+	ErrMetadataInvalid            = errors.New("invalid metadata")
+	ErrMetadataTooLarge           = errors.New("metadata is too large")
+	ErrServiceClientUnknown       = errors.New("unknown service client")
+	ErrInvalidCursor              = errors.New("invalid page cursor")
+	ErrCursorNotSupported         = errors.New("cursor pagination is not supported for search")
+	ErrInvalidFilter              = errors.New("invalid filter")
+	ErrTooManyIDs                 = errors.New("too many ids requested")
+	ErrInvalidFieldMask           = errors.New("invalid field mask")
 )
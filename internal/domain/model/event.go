@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const EventUserAnonymized = "user.anonymized"
+
+// Event is published at least once, consumers deduplicate it by ID.
+type Event struct {
+	ID         uuid.UUID
+	Type       string
+	UserID     uuid.UUID
+	OccurredAt time.Time
+}
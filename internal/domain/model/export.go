@@ -0,0 +1,232 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ExportStatusPending = "pending"
+	ExportStatusReady   = "ready"
+	ExportStatusFailed  = "failed"
+)
+
+type (
+	// UserData is everything stored about user, it is serialized as personal data export.
+	UserData struct {
+		User          ExportUser           `json:"user"`
+		Admin         *ExportAdmin         `json:"admin"`
+		AdminRequests []ExportAdminRequest `json:"admin_requests"`
+		Organizations []ExportOrganization `json:"organizations"`
+		Pseudonyms    []ExportPseudonym    `json:"pseudonym_history"`
+		Metadata      []ExportMetadata     `json:"metadata"`
+		AuditEvents   []ExportAuditEvent   `json:"audit_events"`
+		Sessions      []Session            `json:"sessions"`
+		ExportedAt    time.Time            `json:"exported_at"`
+	}
+
+	ExportUser struct {
+		ID        string     `json:"id"`
+		Username  string     `json:"username"`
+		Pseudonym string     `json:"pseudonym"`
+		FirstName string     `json:"first_name"`
+		LastName  string     `json:"last_name"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+		TelegramID      *int64  `json:"telegram_id,omitempty"`
+		LanguageCode    *string `json:"language_code,omitempty"`
+		IsPremium       bool    `json:"is_premium"`
+		PhotoURL        *string `json:"photo_url,omitempty"`
+		AllowsWriteToPm bool    `json:"allows_write_to_pm"`
+	}
+
+	ExportAdmin struct {
+		Scale     string    `json:"scale"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	ExportAdminRequest struct {
+		ID           string     `json:"id"`
+		Action       string     `json:"action"`
+		TargetUserID string     `json:"target_user_id"`
+		Scale        *string    `json:"scale,omitempty"`
+		RequestedBy  string     `json:"requested_by"`
+		Status       string     `json:"status"`
+		DecidedBy    *string    `json:"decided_by,omitempty"`
+		CreatedAt    time.Time  `json:"created_at"`
+		ExpiresAt    time.Time  `json:"expires_at"`
+		DecidedAt    *time.Time `json:"decided_at,omitempty"`
+	}
+
+	ExportOrganization struct {
+		ID       string    `json:"id"`
+		Name     string    `json:"name"`
+		Role     string    `json:"role"`
+		JoinedAt time.Time `json:"joined_at"`
+	}
+
+	ExportPseudonym struct {
+		OldPseudonym string    `json:"old_pseudonym"`
+		NewPseudonym string    `json:"new_pseudonym"`
+		ChangedBy    string    `json:"changed_by"`
+		ChangedAt    time.Time `json:"changed_at"`
+	}
+
+	ExportMetadata struct {
+		Namespace string          `json:"namespace"`
+		Data      json.RawMessage `json:"data"`
+		UpdatedAt time.Time       `json:"updated_at"`
+	}
+
+	// ExportAuditEvent is audit event where user is actor or target.
+	ExportAuditEvent struct {
+		Action     string          `json:"action"`
+		Outcome    string          `json:"outcome"`
+		ActorID    *string         `json:"actor_id,omitempty"`
+		TargetID   *string         `json:"target_id,omitempty"`
+		IP         *string         `json:"ip,omitempty"`
+		UserAgent  *string         `json:"user_agent,omitempty"`
+		Metadata   json.RawMessage `json:"metadata"`
+		OccurredAt time.Time       `json:"occurred_at"`
+	}
+
+	// Session is active refresh token, token itself is never exposed.
+	Session struct {
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	// Export is asynchronously built personal data export.
+	Export struct {
+		Status      string
+		RequestedBy string
+		Data        []byte
+	}
+)
+
+func ToUserData(
+	user generated.User,
+	admin *generated.UsersAdmin,
+	requests []generated.AdminRequest,
+	organizations []generated.GetUserOrganizationsRow,
+	pseudonyms []generated.PseudonymHistory,
+	metadata []generated.UsersMetadatum,
+	auditEvents []generated.AuditEvent,
+) *UserData {
+	res := &UserData{
+		User: ExportUser{
+			ID:        user.ID.String(),
+			Username:  user.Username,
+			Pseudonym: user.Pseudonym,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			CreatedAt: user.CreatedAt.Time,
+			UpdatedAt: user.UpdatedAt.Time,
+			DeletedAt: timePtr(user.DeletedAt),
+
+			TelegramID:      user.TelegramID,
+			LanguageCode:    user.LanguageCode,
+			IsPremium:       user.IsPremium,
+			PhotoURL:        user.PhotoUrl,
+			AllowsWriteToPm: user.AllowsWriteToPm,
+		},
+		AdminRequests: []ExportAdminRequest{},
+		Organizations: []ExportOrganization{},
+		Pseudonyms:    []ExportPseudonym{},
+		Metadata:      []ExportMetadata{},
+		AuditEvents:   []ExportAuditEvent{},
+		Sessions:      []Session{},
+	}
+
+	if admin != nil {
+		res.Admin = &ExportAdmin{
+			Scale:     string(admin.Scale),
+			CreatedAt: admin.CreatedAt.Time,
+		}
+	}
+
+	for _, r := range requests {
+		request := ExportAdminRequest{
+			ID:           r.ID.String(),
+			Action:       string(r.Action),
+			TargetUserID: r.TargetUserID.String(),
+			RequestedBy:  r.RequestedBy.String(),
+			Status:       string(r.Status),
+			CreatedAt:    r.CreatedAt.Time,
+			ExpiresAt:    r.ExpiresAt.Time,
+			DecidedAt:    timePtr(r.DecidedAt),
+		}
+		if r.Scale.Valid {
+			scale := string(r.Scale.AdminScale)
+			request.Scale = &scale
+		}
+		if r.DecidedBy.Valid {
+			decidedBy := uuid.UUID(r.DecidedBy.Bytes).String()
+			request.DecidedBy = &decidedBy
+		}
+		res.AdminRequests = append(res.AdminRequests, request)
+	}
+
+	for _, o := range organizations {
+		res.Organizations = append(res.Organizations, ExportOrganization{
+			ID:       o.ID.String(),
+			Name:     o.Name,
+			Role:     string(o.Role),
+			JoinedAt: o.CreatedAt.Time,
+		})
+	}
+
+	for _, p := range pseudonyms {
+		res.Pseudonyms = append(res.Pseudonyms, ExportPseudonym{
+			OldPseudonym: p.OldPseudonym,
+			NewPseudonym: p.NewPseudonym,
+			ChangedBy:    p.ChangedBy.String(),
+			ChangedAt:    p.ChangedAt.Time,
+		})
+	}
+
+	for _, m := range metadata {
+		res.Metadata = append(res.Metadata, ExportMetadata{
+			Namespace: m.Namespace,
+			Data:      m.Data,
+			UpdatedAt: m.UpdatedAt.Time,
+		})
+	}
+
+	for _, e := range auditEvents {
+		res.AuditEvents = append(res.AuditEvents, ExportAuditEvent{
+			Action:     e.Action,
+			Outcome:    e.Outcome,
+			ActorID:    uuidPtr(e.ActorID),
+			TargetID:   uuidPtr(e.TargetID),
+			IP:         e.Ip,
+			UserAgent:  e.UserAgent,
+			Metadata:   e.Metadata,
+			OccurredAt: e.CreatedAt.Time,
+		})
+	}
+
+	return res
+}
+
+func uuidPtr(id pgtype.UUID) *string {
+	if !id.Valid {
+		return nil
+	}
+
+	res := uuid.UUID(id.Bytes).String()
+	return &res
+}
+
+func timePtr(t pgtype.Timestamp) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ParseFieldMask parses comma separated paths of top level fields of m, for example
+// "first_name,last_name". Unknown and nested paths are rejected.
+func ParseFieldMask(spec string, m proto.Message) (*fieldmaskpb.FieldMask, error) {
+	var paths []string
+	for _, path := range strings.Split(spec, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" || strings.Contains(path, ".") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFieldMask, path)
+		}
+		paths = append(paths, path)
+	}
+
+	mask, err := fieldmaskpb.New(m, paths...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFieldMask, err)
+	}
+	mask.Normalize()
+
+	return mask, nil
+}
+
+// ApplyUpdateMask keeps only masked fields of update, masked fields missing in request are cleared.
+// Pseudonym can not be cleared.
+func ApplyUpdateMask(params *generated.UpdateUserParams, req *userv1.UpdateUserRequest, mask *fieldmaskpb.FieldMask) error {
+	if mask == nil {
+		return nil
+	}
+
+	masked := make(map[string]bool, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		masked[path] = true
+	}
+
+	params.Pseudonym, params.FirstName, params.LastName = nil, nil, nil
+	if masked["pseudonym"] {
+		if req.Pseudonym == nil {
+			return ErrEmptyPseudonym
+		}
+		params.Pseudonym = req.Pseudonym
+	}
+	if masked["first_name"] {
+		firstName := req.GetFirstName()
+		params.FirstName = &firstName
+	}
+	if masked["last_name"] {
+		lastName := req.GetLastName()
+		params.LastName = &lastName
+	}
+
+	return nil
+}
+
+// ApplyReadMask clears fields of m which are not in mask, nil mask keeps every field.
+func ApplyReadMask(m proto.Message, mask *fieldmaskpb.FieldMask) {
+	if mask == nil {
+		return
+	}
+
+	masked := make(map[string]bool, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		masked[path] = true
+	}
+
+	msg := m.ProtoReflect()
+	fields := msg.Descriptor().Fields()
+	for i := range fields.Len() {
+		if fd := fields.Get(i); !masked[string(fd.Name())] {
+			msg.Clear(fd)
+		}
+	}
+}
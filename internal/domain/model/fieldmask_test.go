@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyUpdateMask_ClearsMaskedField(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	firstName := "Leland"
+	req := &userv1.UpdateUserRequest{FirstName: &firstName, Pseudonym: &firstName}
+
+	mask, err := ParseFieldMask("first_name, last_name", req)
+	require.NoError(t, err)
+
+	params := ToDomainUpdateUserParams(id, req)
+	require.NoError(t, ApplyUpdateMask(params, req, mask))
+
+	empty := ""
+	assert.Equal(t, &generated.UpdateUserParams{ID: id, FirstName: &firstName, LastName: &empty}, params)
+}
+
+func TestApplyUpdateMask_FailClearPseudonym(t *testing.T) {
+	t.Parallel()
+
+	req := &userv1.UpdateUserRequest{}
+	mask, err := ParseFieldMask("pseudonym", req)
+	require.NoError(t, err)
+
+	err = ApplyUpdateMask(ToDomainUpdateUserParams(uuid.New(), req), req, mask)
+	assert.ErrorIs(t, err, ErrEmptyPseudonym)
+}
+
+func TestParseFieldMask_Fail(t *testing.T) {
+	t.Parallel()
+
+	for _, spec := range []string{
+		"email",
+		"first_name,",
+		"created_at.seconds",
+		"user_id,is_deleted",
+	} {
+		t.Run(spec, func(t *testing.T) {
+			_, err := ParseFieldMask(spec, &userv1.User{})
+			require.ErrorIs(t, err, ErrInvalidFieldMask)
+		})
+	}
+}
+
+func TestApplyReadMask(t *testing.T) {
+	t.Parallel()
+
+	mask, err := ParseFieldMask("user_id,pseudonym", &userv1.User{})
+	require.NoError(t, err)
+
+	user := &userv1.User{UserId: uuid.NewString(), Username: "metro", Pseudonym: "Metro", FirstName: "Leland"}
+	ApplyReadMask(user, mask)
+
+	assert.NotEmpty(t, user.UserId)
+	assert.Equal(t, "Metro", user.Pseudonym)
+	assert.Empty(t, user.Username)
+	assert.Empty(t, user.FirstName)
+	assert.Nil(t, user.CreatedAt)
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+)
+
+type (
+	// ServiceClient is internal service authenticated by static key.
+	ServiceClient struct {
+		ID  string
+		Key string
+	}
+
+	// MetadataNamespace is part of user metadata owned by one service client. Schema is
+	// optional JSON Schema of namespace data, with Claims set data is embedded in access token.
+	MetadataNamespace struct {
+		Name   string
+		Owner  string
+		Schema string
+		Claims bool
+	}
+
+	Metadata struct {
+		Namespace string          `json:"namespace"`
+		Data      json.RawMessage `json:"data"`
+		UpdatedAt time.Time       `json:"updated_at"`
+	}
+)
+
+func ToMetadata(metadata generated.UsersMetadatum) *Metadata {
+	return &Metadata{
+		Namespace: metadata.Namespace,
+		Data:      metadata.Data,
+		UpdatedAt: metadata.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,211 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/google/uuid"
+)
+
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+type (
+	// PageParams select keyset pagination instead of offset one, Cursor is nil on the first page.
+	// Total count is expensive on large tables and is skipped with SkipTotal.
+	PageParams struct {
+		UseCursor bool
+		Cursor    *Cursor
+		SkipTotal bool
+	}
+
+	// Cursor points at row page continues from, it is passed to clients as opaque token.
+	// Values are values of sort fields of the row, sort always ends with id.
+	// Backward cursor selects rows before the row instead of after it.
+	Cursor struct {
+		Sort     Sort     `json:"s"`
+		Values   []string `json:"v"`
+		Backward bool     `json:"b,omitempty"`
+	}
+
+	// Page describes returned page, Total is nil when count was skipped and cursors
+	// are nil when there is no page in that direction or offset pagination is used.
+	Page struct {
+		Total      *uint64
+		NextCursor *string
+		PrevCursor *string
+	}
+)
+
+// userSortValues and adminSortValues return values of sort fields of row for cursor.
+var (
+	userSortValues = map[string]func(user generated.User) string{
+		"id":         func(user generated.User) string { return user.ID.String() },
+		"username":   func(user generated.User) string { return user.Username },
+		"pseudonym":  func(user generated.User) string { return user.Pseudonym },
+		"first_name": func(user generated.User) string { return user.FirstName },
+		"last_name":  func(user generated.User) string { return user.LastName },
+		"created_at": func(user generated.User) string { return formatCursorTime(user.CreatedAt.Time) },
+		"updated_at": func(user generated.User) string { return formatCursorTime(user.UpdatedAt.Time) },
+	}
+	adminSortValues = map[string]func(admin Admin) string{
+		"id":         func(admin Admin) string { return admin.ID.String() },
+		"username":   func(admin Admin) string { return admin.Username },
+		"scale":      func(admin Admin) string { return string(admin.Scale) },
+		"created_at": func(admin Admin) string { return formatCursorTime(admin.CreatedAt) },
+	}
+)
+
+var cursorTimeFields = map[string]bool{"created_at": true, "updated_at": true}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c) // nolint
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || len(c.Sort) == 0 || len(c.Values) != len(c.Sort) {
+		return nil, ErrInvalidCursor
+	}
+
+	for _, field := range c.Sort {
+		if field.Order != OrderAsc && field.Order != OrderDesc {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	if c.Sort[len(c.Sort)-1].Field != "id" {
+		return nil, ErrInvalidCursor
+	}
+
+	if _, err := c.SortValues(); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// SortValues returns cursor values typed as columns they were taken from.
+func (c Cursor) SortValues() ([]any, error) {
+	values := make([]any, 0, len(c.Values))
+	for i, field := range c.Sort {
+		switch {
+		case field.Field == "id":
+			id, err := uuid.Parse(c.Values[i])
+			if err != nil {
+				return nil, ErrInvalidCursor
+			}
+			values = append(values, id)
+		case cursorTimeFields[field.Field]:
+			t, err := time.Parse(time.RFC3339Nano, c.Values[i])
+			if err != nil {
+				return nil, ErrInvalidCursor
+			}
+			values = append(values, t)
+		default:
+			values = append(values, c.Values[i])
+		}
+	}
+
+	return values, nil
+}
+
+// Operator returns comparison selecting rows after cursor by field in its direction.
+func (c Cursor) Operator(field SortField) string {
+	if (field.Order == OrderAsc) != c.Backward {
+		return ">"
+	}
+
+	return "<"
+}
+
+// ScanSort returns sort rows are read in, backward pages are read reversed.
+func (c Cursor) ScanSort() Sort {
+	if !c.Backward {
+		return c.Sort
+	}
+
+	sort := make(Sort, 0, len(c.Sort))
+	for _, field := range c.Sort {
+		order := OrderDesc
+		if field.Order == OrderDesc {
+			order = OrderAsc
+		}
+		sort = append(sort, SortField{Field: field.Field, Order: order})
+	}
+
+	return sort
+}
+
+// UserCursor returns cursor pointing at user in given sort.
+func UserCursor(user generated.User, sort Sort, backward bool) Cursor {
+	values := make([]string, 0, len(sort))
+	for _, field := range sort {
+		values = append(values, userSortValues[field.Field](user))
+	}
+
+	return Cursor{Sort: sort, Values: values, Backward: backward}
+}
+
+// AdminCursor returns cursor pointing at admin in given sort.
+func AdminCursor(admin Admin, sort Sort, backward bool) Cursor {
+	values := make([]string, 0, len(sort))
+	for _, field := range sort {
+		values = append(values, adminSortValues[field.Field](admin))
+	}
+
+	return Cursor{Sort: sort, Values: values, Backward: backward}
+}
+
+// NewPage builds page of rows read with limit+1 to find out whether more rows follow.
+// Rows of backward page are reversed back to requested order.
+func NewPage[T any](rows []T, total *uint64, limit uint64, params PageParams, cursor func(row T, backward bool) Cursor) ([]T, *Page) {
+	page := &Page{Total: total}
+	if !params.UseCursor {
+		return rows, page
+	}
+
+	hasMore := uint64(len(rows)) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	backward := params.Cursor != nil && params.Cursor.Backward
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	if len(rows) == 0 {
+		return rows, page
+	}
+
+	// going backward there is always next page the cursor came from,
+	// going forward there is previous page unless this is the first one
+	if hasMore || backward {
+		next := cursor(rows[len(rows)-1], false).Encode()
+		page.NextCursor = &next
+	}
+
+	if (backward && hasMore) || (!backward && params.Cursor != nil) {
+		prev := cursor(rows[0], true).Encode()
+		page.PrevCursor = &prev
+	}
+
+	return rows, page
+}
+
+func formatCursorTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
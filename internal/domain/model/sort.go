@@ -0,0 +1,90 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// maxSortFields limits number of fields rows are sorted by.
+const maxSortFields = 4
+
+type (
+	// SortField orders rows by Field in Order, asc or desc.
+	SortField struct {
+		Field string `json:"f"`
+		Order string `json:"o"`
+	}
+
+	// Sort orders rows by its fields in turn.
+	Sort []SortField
+)
+
+// UserSortFields and AdminSortFields are fields users and admins can be sorted by.
+var (
+	UserSortFields = map[string]bool{
+		"id": true, "username": true, "pseudonym": true, "first_name": true, "last_name": true,
+		"created_at": true, "updated_at": true,
+	}
+	AdminSortFields = map[string]bool{"id": true, "username": true, "scale": true, "created_at": true}
+)
+
+// ParseSort parses comma separated fields with optional direction, for example
+// "created_at desc, username". Direction defaults to asc.
+func ParseSort(spec string, allowed map[string]bool) (Sort, error) {
+	var sort Sort
+	for _, part := range strings.Split(spec, ",") {
+		words := strings.Fields(part)
+		if len(words) == 0 || len(words) > 2 {
+			return nil, fmt.Errorf("%w: %q", ErrOrderByInvalidField, strings.TrimSpace(part))
+		}
+
+		field := SortField{Field: words[0], Order: OrderAsc}
+		if len(words) == 2 {
+			field.Order = words[1]
+		}
+		sort = append(sort, field)
+	}
+
+	return sort.Normalize(allowed)
+}
+
+// Normalize checks fields against allowed ones and lowercases directions, fields must not repeat.
+func (s Sort) Normalize(allowed map[string]bool) (Sort, error) {
+	if len(s) > maxSortFields {
+		return nil, fmt.Errorf("%w: more than %d fields", ErrOrderByInvalidField, maxSortFields)
+	}
+
+	res := make(Sort, 0, len(s))
+	seen := make(map[string]bool, len(s))
+	for _, field := range s {
+		if !allowed[field.Field] || seen[field.Field] {
+			return nil, fmt.Errorf("%w: %q", ErrOrderByInvalidField, field.Field)
+		}
+		seen[field.Field] = true
+
+		order := strings.ToLower(field.Order)
+		if order != OrderAsc && order != OrderDesc {
+			return nil, fmt.Errorf("%w: order of %q must be asc or desc", ErrOrderByInvalidField, field.Field)
+		}
+		res = append(res, SortField{Field: field.Field, Order: order})
+	}
+
+	return res, nil
+}
+
+// WithTieBreaker ends sort with id so rows with equal values keep stable order,
+// fields after id can not change order and are dropped.
+func (s Sort) WithTieBreaker() Sort {
+	for i, field := range s {
+		if field.Field == "id" {
+			return s[:i+1]
+		}
+	}
+
+	return append(s[:len(s):len(s)], SortField{Field: "id", Order: OrderAsc})
+}
+
+func (s Sort) Equal(other Sort) bool {
+	return slices.Equal(s, other)
+}
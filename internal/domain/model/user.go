@@ -11,26 +11,83 @@ import (
 )
 
 type (
-	OrderBy struct {
-		Order string
-		Field string
-	}
-
 	GetUsersParams struct {
 		UserID    *string
 		Username  *string
 		Pseudonym *string
 		FirstName *string
 		LastName  *string
-		OrderBy   *OrderBy
-		Limit     uint64
-		Offset    uint64
+		// FormerPseudonym also matches user who recently changed pseudonym of this skeleton,
+		// so lookup by released pseudonym finds its previous owner
+		FormerPseudonym *FormerPseudonym
+		// Query searches users by username, pseudonym and names ignoring case, accents and typos,
+		// matches with word similarity below SimilarityThreshold are dropped
+		Query               *string
+		SimilarityThreshold float64
+		// Filter restricts users with AIP-160 style expression, see filter package
+		Filter *string
+		// IncludeDeleted lists deleted users too and allows is_deleted in filter, it is set for
+		// admins only. Search always skips deleted users
+		IncludeDeleted bool
+		// Sort orders users, fields are checked against UserSortFields by service
+		Sort   Sort
+		Limit  uint64
+		Offset uint64
+		PageParams
+	}
+
+	FormerPseudonym struct {
+		Skeleton     string
+		ChangedAfter time.Time
+	}
+
+	GetAdminsParams struct {
+		UserID     pgtype.UUID
+		Username   *string
+		AdminScale generated.NullAdminScale
+		// Sort orders admins, fields are checked against AdminSortFields by service
+		Sort   Sort
+		Limit  uint64
+		Offset uint64
+		PageParams
 	}
 
 	AuthConfig struct {
 		Secret          string
 		AccessTokenTTL  int
 		RefreshTokenTTL int
+		AdminApproval   bool
+		AdminRequestTTL int
+		// DeletionGracePeriod in minutes, deleted user can restore account on login during it
+		DeletionGracePeriod int
+		// ExportTTL in minutes, asynchronous export can be downloaded during it
+		ExportTTL int
+		// ReservedPseudonyms can not be taken, BlockedPseudonyms can not be part of pseudonym
+		ReservedPseudonyms []string
+		BlockedPseudonyms  []string
+		// PseudonymCooldown in minutes between pseudonym changes,
+		// FormerPseudonymTTL in minutes during which former pseudonym resolves to its owner
+		PseudonymCooldown  int
+		FormerPseudonymTTL int
+		// SearchSimilarityThreshold is minimal word similarity of fuzzy user search, from 0 to 1
+		SearchSimilarityThreshold float64
+		// ServiceClients own MetadataNamespaces, MetadataMaxSize in bytes limits data of one namespace
+		ServiceClients     []ServiceClient
+		MetadataNamespaces []MetadataNamespace
+		MetadataMaxSize    int
+		// BatchMaxIDs limits number of users requested by ids at once
+		BatchMaxIDs int
+	}
+
+	// TelegramProfile is part of user taken from Telegram init data, User message has no fields for it
+	// so it is served by separate HTTP route.
+	TelegramProfile struct {
+		UserID          uuid.UUID `json:"user_id"`
+		TelegramID      *int64    `json:"telegram_id"`
+		LanguageCode    *string   `json:"language_code"`
+		IsPremium       bool      `json:"is_premium"`
+		PhotoURL        *string   `json:"photo_url"`
+		AllowsWriteToPm bool      `json:"allows_write_to_pm"`
 	}
 
 	Admin struct {
@@ -41,6 +98,17 @@ type (
 	}
 )
 
+func ToTelegramProfile(user generated.User) *TelegramProfile {
+	return &TelegramProfile{
+		UserID:          user.ID,
+		TelegramID:      user.TelegramID,
+		LanguageCode:    user.LanguageCode,
+		IsPremium:       user.IsPremium,
+		PhotoURL:        user.PhotoUrl,
+		AllowsWriteToPm: user.AllowsWriteToPm,
+	}
+}
+
 func ToDomainUpdateUserParams(id uuid.UUID, user *userv1.UpdateUserRequest) *generated.UpdateUserParams {
 	return &generated.UpdateUserParams{
 		ID:        id,
@@ -62,12 +130,9 @@ func ToUpdateUserResponse(user *generated.User) *userv1.UpdateUserResponse {
 }
 
 func ToDomainGetUsersParams(params *userv1.GetUsersRequest) *GetUsersParams {
-	var orderBy *OrderBy
+	var sort Sort
 	if params.OrderBy != nil {
-		orderBy = &OrderBy{
-			Order: params.OrderBy.Order,
-			Field: params.OrderBy.Field,
-		}
+		sort = Sort{{Field: params.OrderBy.Field, Order: params.OrderBy.Order}}
 	}
 
 	return &GetUsersParams{
@@ -76,34 +141,47 @@ func ToDomainGetUsersParams(params *userv1.GetUsersRequest) *GetUsersParams {
 		Pseudonym: params.Pseudonym,
 		FirstName: params.FirstName,
 		LastName:  params.LastName,
-		OrderBy:   orderBy,
+		Sort:      sort,
 		Limit:     params.Limit,
 		Offset:    params.Offset,
 	}
 }
 
-func ToGetUsersResponse(users []generated.User, total uint64, params *GetUsersParams) *userv1.GetUsersResponse {
+func ToUser(user generated.User) *userv1.User {
+	return &userv1.User{
+		UserId:    user.ID.String(),
+		Username:  user.Username,
+		Pseudonym: user.Pseudonym,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		CreatedAt: timestamppb.New(user.CreatedAt.Time),
+	}
+}
+
+func ToGetUsersResponse(users []generated.User, page *Page, params *GetUsersParams) *userv1.GetUsersResponse {
 	var res userv1.GetUsersResponse
 	for _, user := range users {
-		res.Users = append(res.Users, &userv1.User{
-			UserId:    user.ID.String(),
-			Username:  user.Username,
-			Pseudonym: user.Pseudonym,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			CreatedAt: timestamppb.New(user.CreatedAt.Time),
-		})
-	}
-	res.Pagination = &userv1.Pagination{
-		Records:        total,
-		RecordsPerPage: params.Limit,
-		Pages:          (total + params.Limit - 1) / params.Limit,
-		CurPage:        params.Offset/params.Limit + 1,
+		res.Users = append(res.Users, ToUser(user))
 	}
+	res.Pagination = toPagination(page, params.Limit, params.Offset, params.UseCursor)
 	return &res
 }
 
-func ToDomainGetAdminsParams(params *userv1.GetAdminsRequest) (*generated.GetAdminsParams, error) {
+// toPagination fills offset pagination, with cursor pagination current page is unknown
+// and cursors are passed in response metadata as Pagination has no fields for them.
+func toPagination(page *Page, limit, offset uint64, useCursor bool) *userv1.Pagination {
+	res := &userv1.Pagination{RecordsPerPage: limit}
+	if page.Total != nil {
+		res.Records = *page.Total
+		res.Pages = (*page.Total + limit - 1) / limit
+	}
+	if !useCursor {
+		res.CurPage = offset/limit + 1
+	}
+	return res
+}
+
+func ToDomainGetAdminsParams(params *userv1.GetAdminsRequest) (*GetAdminsParams, error) {
 	var userID pgtype.UUID
 	if params.UserId != nil {
 		if err := userID.Scan(*params.UserId); err != nil {
@@ -118,31 +196,26 @@ func ToDomainGetAdminsParams(params *userv1.GetAdminsRequest) (*generated.GetAdm
 		}
 	}
 
-	return &generated.GetAdminsParams{
+	return &GetAdminsParams{
 		UserID:     userID,
 		Username:   params.Username,
 		AdminScale: adminScale,
-		Limit:      int32(params.Limit),
-		Offset:     int32(params.Offset),
+		Limit:      params.Limit,
+		Offset:     params.Offset,
 	}, nil
 }
 
-func ToGetAdminsResponse(admins []generated.GetAdminsRow, total uint64, params *userv1.GetAdminsRequest) *userv1.GetAdminsResponse {
+func ToGetAdminsResponse(admins []Admin, page *Page, params *GetAdminsParams) *userv1.GetAdminsResponse {
 	var res userv1.GetAdminsResponse
 	for _, v := range admins {
 		res.Admins = append(res.Admins, &userv1.Admin{
 			UserId:     v.ID.String(),
 			Username:   v.Username,
 			AdminScale: string(v.Scale),
-			CreatedAt:  timestamppb.New(v.CreatedAt.Time),
+			CreatedAt:  timestamppb.New(v.CreatedAt),
 		})
 	}
 
-	res.Pagination = &userv1.Pagination{
-		Records:        total,
-		RecordsPerPage: params.Limit,
-		Pages:          (total + params.Limit - 1) / params.Limit,
-		CurPage:        params.Offset/params.Limit + 1,
-	}
+	res.Pagination = toPagination(page, params.Limit, params.Offset, params.UseCursor)
 	return &res
 }
@@ -3,103 +3,330 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net"
+	"net/netip"
+	"net/url"
 	"strings"
 
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
-	"github.com/golang-jwt/jwt"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/clientip"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/policy"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/token"
 	"github.com/google/uuid"
 	initdata "github.com/telegram-mini-apps/init-data-golang"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type contextKey string
 
 const (
-	initDataContextKey = contextKey("init-data")
-	userIDContextKey   = contextKey("user-id")
-	adminContextKey    = contextKey("admin")
+	initDataContextKey   = contextKey("init-data")
+	userIDContextKey     = contextKey("user-id")
+	adminContextKey      = contextKey("admin")
+	tenantContextKey     = contextKey("tenant")
+	tenantRoleContextKey = contextKey("tenant-role")
+	claimsContextKey     = contextKey("claims")
 )
 
+// Pagination metadata, Pagination message has no cursor fields and protos are frozen, so cursors
+// are passed in headers. Gateway forwards them as is and exposes response cursors to browsers.
+const (
+	// PaginationHeader set to "cursor" selects keyset pagination
+	PaginationHeader = "x-pagination"
+	// CursorHeader is cursor of requested page, it is omitted for the first page
+	CursorHeader = "x-page-cursor"
+	// IncludeTotalHeader set to "false" skips total count
+	IncludeTotalHeader = "x-include-total"
+	// NextCursorHeader and PrevCursorHeader are returned with cursors of adjacent pages
+	NextCursorHeader = "x-next-cursor"
+	PrevCursorHeader = "x-prev-cursor"
+)
+
+// FilterHeader is AIP-160 style filter of GetUsers, it is available to admins only.
+const FilterHeader = "x-filter"
+
+// SearchQueryHeader searches GetUsers the same way as GET /v1/users/search, value is percent-encoded
+// because metadata is ASCII only. Users are ranked by relevance and cursor pagination is not supported.
+const SearchQueryHeader = "x-search-query"
+
+// IncludeDeletedHeader set to "true" lists deleted users in GetUsers and allows is_deleted in filter,
+// it is available to admins only.
+const IncludeDeletedHeader = "x-include-deleted"
+
+// SortHeader sorts GetUsers and GetAdmins by several fields, for example "created_at desc, username",
+// it replaces order_by of request. order_by takes a single field and protos are frozen, so multi-field
+// sort is passed in metadata.
+const SortHeader = "x-sort"
+
+// Field mask metadata, comma separated paths, for example "first_name,last_name".
+const (
+	// UpdateMaskHeader limits UpdateUser to masked fields, masked fields missing in request are cleared
+	UpdateMaskHeader = "x-update-mask"
+	// ReadMaskHeader limits fields of users returned by GetUser and GetUsers
+	ReadMaskHeader = "x-read-mask"
+)
+
+// RequestIDHeader correlates logs of request, it is generated when caller does not pass it
+// and is returned in response headers and in details of errors.
+const RequestIDHeader = "x-request-id"
+
+// maxRequestIDLength bounds request id passed by caller, longer ids are replaced.
+const maxRequestIDLength = 128
+
 func AuthMiddleware(secrets map[string]string, requireAuth, requireAdmin map[string]bool) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
-		if !requireAuth[info.FullMethod] {
-			return handler(ctx, req)
+		ctx, err = authorize(ctx, info.FullMethod, secrets, requireAuth, requireAdmin)
+		if err != nil {
+			return nil, err
 		}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok || len(md.Get("authorization")) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), "token not provided")
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthMiddleware authenticates streaming calls the same way as AuthMiddleware.
+func StreamAuthMiddleware(secrets map[string]string, requireAuth, requireAdmin map[string]bool) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), info.FullMethod, secrets, requireAuth, requireAdmin)
+		if err != nil {
+			return err
 		}
 
-		data := strings.Split(md.Get("authorization")[0], " ")
-		if len(data) < 2 {
-			return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), "not enough args in header")
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// serverStream replaces context of stream with authenticated one.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize validates credentials of method and returns context with caller.
+func authorize(ctx context.Context, method string, secrets map[string]string, requireAuth, requireAdmin map[string]bool) (context.Context, error) {
+	if !requireAuth[method] {
+		return withOptionalToken(ctx, secrets["bearer"]), nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), "token not provided")
+	}
+
+	data := strings.Split(md.Get("authorization")[0], " ")
+	if len(data) < 2 {
+		return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), "not enough args in header")
+	}
+
+	switch credentials := strings.TrimSpace(data[1]); strings.ToLower(data[0]) {
+	case "bearer":
+		claims, err := token.Validate(credentials, secrets["bearer"])
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), err.Error())
 		}
 
-		switch token := strings.TrimSpace(data[1]); strings.ToLower(data[0]) {
-		case "bearer":
-			id, admin, err := validateToken(token, secrets["bearer"])
-			if err != nil {
-				return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), err.Error())
-			}
-
-			if requireAdmin[info.FullMethod] && (admin == nil || generated.AdminScale(*admin) != generated.AdminScaleMinor && generated.AdminScale(*admin) != generated.AdminScaleMajor) {
-				return nil, status.Errorf(codes.PermissionDenied, "%s: %s", model.ErrUnauthorized, "must be admin")
-			}
-
-			ctx = context.WithValue(ctx, userIDContextKey, *id)
-			ctx = context.WithValue(ctx, adminContextKey, admin)
-		case "tma":
-			if err := initdata.Validate(token, secrets["tma"], -1); err != nil {
-				return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), err.Error())
-			}
-
-			initData, err := initdata.Parse(token)
-			if err != nil {
-				return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), err.Error())
-			}
-
-			ctx = context.WithValue(ctx, initDataContextKey, initData)
-		default:
-			return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), "invalid header format")
+		admin := claims.Admin
+		if requireAdmin[method] && (admin == nil || generated.AdminScale(*admin) != generated.AdminScaleMinor && generated.AdminScale(*admin) != generated.AdminScaleMajor) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s: %s", model.ErrUnauthorized, "must be admin")
 		}
 
-		return handler(ctx, req)
+		ctx = withClaims(ctx, claims)
+	case "tma":
+		if err := initdata.Validate(credentials, secrets["tma"], -1); err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), err.Error())
+		}
+
+		initData, err := initdata.Parse(credentials)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), err.Error())
+		}
+
+		ctx = context.WithValue(ctx, initDataContextKey, initData)
+	default:
+		return nil, status.Errorf(codes.Unauthenticated, "%s: %s", model.ErrUnauthorized.Error(), "invalid header format")
 	}
+
+	return ctx, nil
 }
 
-func validateToken(token, secret string) (id, admin *string, err error) {
-	data, err := jwt.Parse(token, func(t *jwt.Token) (any, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "unexpected signing method")
-		}
+// withOptionalToken authenticates caller of public method when valid bearer token is provided,
+// otherwise request stays anonymous.
+func withOptionalToken(ctx context.Context, secret string) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		return ctx
+	}
 
-		return []byte(secret), nil
-	})
+	scheme, credentials, ok := strings.Cut(md.Get("authorization")[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ctx
+	}
+
+	claims, err := token.Validate(strings.TrimSpace(credentials), secret)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%w: %w", model.ErrUnauthorized, err)
+		return ctx
 	}
 
-	if claims, ok := data.Claims.(jwt.MapClaims); ok && data.Valid {
-		id, ok := claims["id"].(string)
-		if !ok {
-			return nil, nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "invalid id")
+	return withClaims(ctx, claims)
+}
+
+// withClaims stores caller of validated token in context.
+func withClaims(ctx context.Context, claims *token.Claims) context.Context {
+	ctx = context.WithValue(ctx, userIDContextKey, claims.ID)
+	ctx = sl.With(ctx, slog.String("user_id", claims.ID))
+	ctx = context.WithValue(ctx, adminContextKey, claims.Admin)
+	ctx = context.WithValue(ctx, tenantContextKey, claims.Tenant)
+	ctx = context.WithValue(ctx, tenantRoleContextKey, claims.TenantRole)
+	ctx = context.WithValue(ctx, claimsContextKey, claims.Raw)
+	return ctx
+}
+
+// RequestMiddleware stores logger with request id and method in context, so every record
+// of call can be tied to one request.
+func RequestMiddleware(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, id := withRequestID(ctx, info.FullMethod, log)
+
+		resp, err := handler(ctx, req)
+		return resp, withRequestInfo(err, id)
+	}
+}
+
+// StreamRequestMiddleware is RequestMiddleware of streaming calls.
+func StreamRequestMiddleware(log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := withRequestID(ss.Context(), info.FullMethod, log)
+
+		return withRequestInfo(handler(srv, &serverStream{ServerStream: ss, ctx: ctx}), id)
+	}
+}
+
+func withRequestID(ctx context.Context, method string, log *slog.Logger) (context.Context, string) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(RequestIDHeader)) > 0 {
+		id = md.Get(RequestIDHeader)[0]
+	}
+	if id == "" || len(id) > maxRequestIDLength {
+		id = uuid.NewString()
+	}
+
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id)); err != nil {
+		log.Error("failed to set request id header", sl.Err(err))
+	}
+
+	return sl.WithContext(ctx, log.With(slog.String("request_id", id), slog.String("method", method))), id
+}
+
+// withRequestInfo adds request id to details of status error.
+func withRequestInfo(err error, id string) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.New(codes.Unknown, err.Error())
+	}
+
+	detailed, detailsErr := st.WithDetails(&errdetails.RequestInfo{RequestId: id})
+	if detailsErr != nil {
+		return err
+	}
+
+	return detailed.Err()
+}
+
+// ClientMiddleware stores address and user agent of caller in context for audit log, x-forwarded-for
+// is honoured only from gateway and trustedProxies.
+func ClientMiddleware(trustedProxies []netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(model.ContextWithClient(ctx, getClientFromContext(ctx, trustedProxies)), req)
+	}
+}
+
+// PolicyMiddleware evaluates authorization policies against request, token claims and user attributes.
+// In dry run mode decisions are only logged.
+func PolicyMiddleware(engine *policy.Engine, dryRun bool, log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		if err := checkPolicy(ctx, engine, info.FullMethod, req, dryRun, log); err != nil {
+			return nil, err
 		}
 
-		admin, ok := claims["admin"].(string)
-		if !ok {
-			return &id, nil, nil
+		return handler(ctx, req)
+	}
+}
+
+// StreamPolicyMiddleware evaluates policies of streaming calls against the first received message,
+// which is request of server streaming call.
+func StreamPolicyMiddleware(engine *policy.Engine, dryRun bool, log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &policyServerStream{
+			ServerStream: ss,
+			check: func(m any) error {
+				return checkPolicy(ss.Context(), engine, info.FullMethod, m, dryRun, log)
+			},
+		})
+	}
+}
+
+// policyServerStream checks policy once the first message is received.
+type policyServerStream struct {
+	grpc.ServerStream
+	check   func(m any) error
+	checked bool
+}
+
+func (s *policyServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if s.checked {
+		return nil
+	}
+	s.checked = true
+
+	return s.check(m)
+}
+
+func checkPolicy(ctx context.Context, engine *policy.Engine, method string, req any, dryRun bool, log *slog.Logger) error {
+	msg, _ := req.(proto.Message)
+
+	decision, err := engine.Evaluate(policy.Input{
+		Method:  method,
+		Request: msg,
+		Claims:  getClaimsFromContext(ctx),
+		User:    getUserAttributesFromContext(ctx),
+	})
+	if err != nil {
+		sl.FromContext(ctx, log).Error("failed to evaluate policy", sl.Err(err), slog.String("method", method))
+		return status.Error(codes.Internal, "failed to evaluate policy")
+	}
+
+	if !decision.Allowed {
+		if dryRun {
+			sl.FromContext(ctx, log).Info("policy would deny call", slog.String("method", method), slog.String("rule", decision.Rule))
+			return nil
 		}
 
-		return &id, &admin, nil
+		return status.Errorf(codes.PermissionDenied, "%s: denied by policy %s", model.ErrUnauthorized, decision.Rule)
 	}
 
-	return nil, nil, model.ErrUnauthorized
+	return nil
 }
 
 func getUserIDFromContext(ctx context.Context) (*uuid.UUID, error) {
@@ -121,6 +348,38 @@ func getAdminFromContext(ctx context.Context) *string {
 	return admin
 }
 
+// getTenantFromContext returns active organization and member role, both are nil for unscoped tokens.
+func getTenantFromContext(ctx context.Context) (tenant, role *string) {
+	tenant, _ = ctx.Value(tenantContextKey).(*string)
+	role, _ = ctx.Value(tenantRoleContextKey).(*string)
+	return tenant, role
+}
+
+func getClaimsFromContext(ctx context.Context) map[string]any {
+	claims, _ := ctx.Value(claimsContextKey).(map[string]any)
+	return claims
+}
+
+// getUserAttributesFromContext returns caller attributes, missing values are empty strings.
+func getUserAttributesFromContext(ctx context.Context) map[string]any {
+	deref := func(v *string) string {
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+
+	id, _ := ctx.Value(userIDContextKey).(string)
+	tenant, role := getTenantFromContext(ctx)
+
+	return map[string]any{
+		"id":          id,
+		"admin":       deref(getAdminFromContext(ctx)),
+		"tenant":      deref(tenant),
+		"tenant_role": deref(role),
+	}
+}
+
 func getInitDataFromContext(ctx context.Context) (*generated.SaveUserParams, error) {
 	initData, ok := ctx.Value(initDataContextKey).(initdata.InitData)
 	if !ok {
@@ -131,6 +390,18 @@ func getInitDataFromContext(ctx context.Context) (*generated.SaveUserParams, err
 	user.Username = initData.User.Username
 	user.FirstName = initData.User.FirstName
 	user.LastName = initData.User.LastName
+	user.TelegramID = &initData.User.ID
+	user.IsPremium = initData.User.IsPremium
+	user.AllowsWriteToPm = initData.User.AllowsWriteToPm
+	user.IsBot = initData.User.IsBot
+
+	// Optional fields are stored as null when Telegram does not provide them
+	if initData.User.LanguageCode != "" {
+		user.LanguageCode = &initData.User.LanguageCode
+	}
+	if initData.User.PhotoURL != "" {
+		user.PhotoUrl = &initData.User.PhotoURL
+	}
 
 	return &user, nil
 }
@@ -143,3 +414,161 @@ func isLocalhost(addr string) bool {
 
 	return host == "127.0.0.1" || host == "::1"
 }
+
+// getClientFromContext reads caller from metadata. Gateway connects from localhost and appends address
+// of HTTP client to x-forwarded-for, so x-forwarded-for is read only from gateway or trusted proxies,
+// any other caller could set it to anything. Addresses are read from the last one, trusted proxies are
+// skipped, and the first untrusted address is the caller.
+func getClientFromContext(ctx context.Context, trustedProxies []netip.Prefix) model.Client {
+	var client model.Client
+	var forwarded bool
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			client.IP = host
+		}
+		forwarded = isLocalhost(p.Addr.String()) || clientip.IsTrusted(client.IP, trustedProxies)
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return client
+	}
+
+	if forwarded {
+		if ip, ok := clientip.FromForwarded(md.Get("x-forwarded-for"), trustedProxies); ok {
+			client.IP = ip
+		}
+	}
+
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 {
+			client.UserAgent = values[0]
+			break
+		}
+	}
+
+	return client
+}
+
+// getPageParamsFromContext reads pagination mode, cursor and total count flag from metadata.
+func getPageParamsFromContext(ctx context.Context) (model.PageParams, error) {
+	var params model.PageParams
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return params, nil
+	}
+
+	if values := md.Get(IncludeTotalHeader); len(values) > 0 {
+		params.SkipTotal = strings.EqualFold(values[0], "false")
+	}
+
+	if values := md.Get(PaginationHeader); len(values) > 0 {
+		params.UseCursor = strings.EqualFold(values[0], "cursor")
+	}
+
+	if values := md.Get(CursorHeader); len(values) > 0 && values[0] != "" {
+		cursor, err := model.DecodeCursor(values[0])
+		if err != nil {
+			return params, err
+		}
+		params.UseCursor = true
+		params.Cursor = cursor
+	}
+
+	return params, nil
+}
+
+// setPageHeaders returns cursors of adjacent pages in response metadata.
+func setPageHeaders(ctx context.Context, page *model.Page) error {
+	md := metadata.MD{}
+	if page.NextCursor != nil {
+		md.Set(NextCursorHeader, *page.NextCursor)
+	}
+	if page.PrevCursor != nil {
+		md.Set(PrevCursorHeader, *page.PrevCursor)
+	}
+
+	if len(md) == 0 {
+		return nil
+	}
+
+	return grpc.SetHeader(ctx, md)
+}
+
+// getFilterFromContext returns users filter from metadata, it is nil when filter is not provided.
+func getFilterFromContext(ctx context.Context) *string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	if values := md.Get(FilterHeader); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+		return &values[0]
+	}
+
+	return nil
+}
+
+// getSearchQueryFromContext returns decoded search query from metadata, it is nil when query is not provided.
+func getSearchQueryFromContext(ctx context.Context) (*string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	values := md.Get(SearchQueryHeader)
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	query, err := url.QueryUnescape(values[0])
+	if err != nil {
+		return nil, fmt.Errorf("%s must be percent-encoded: %w", SearchQueryHeader, err)
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+
+	return &query, nil
+}
+
+// getIncludeDeletedFromContext reports whether deleted users are requested in metadata.
+func getIncludeDeletedFromContext(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	values := md.Get(IncludeDeletedHeader)
+	return len(values) > 0 && strings.EqualFold(values[0], "true")
+}
+
+// getSortFromContext parses sort from metadata, it is nil when sort is not provided.
+func getSortFromContext(ctx context.Context, allowed map[string]bool) (model.Sort, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	if values := md.Get(SortHeader); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+		return model.ParseSort(values[0], allowed)
+	}
+
+	return nil, nil
+}
+
+// getFieldMaskFromContext parses field mask of m from header, it is nil when mask is not provided.
+func getFieldMaskFromContext(ctx context.Context, header string, m proto.Message) (*fieldmaskpb.FieldMask, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	if values := md.Get(header); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+		return model.ParseFieldMask(values[0], m)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestGetClientFromContext(t *testing.T) {
+	t.Parallel()
+
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		want      string
+	}{
+		{name: "direct caller", peer: "203.0.113.7", want: "203.0.113.7"},
+		{name: "direct caller spoofs forwarded", peer: "203.0.113.7", forwarded: []string{"198.51.100.1"}, want: "203.0.113.7"},
+		{name: "gateway", peer: "127.0.0.1", forwarded: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "gateway, client spoofs forwarded", peer: "127.0.0.1", forwarded: []string{"192.0.2.1, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "gateway behind trusted proxy", peer: "127.0.0.1", forwarded: []string{"192.0.2.1, 198.51.100.1, 10.1.2.3"}, want: "198.51.100.1"},
+		{name: "trusted proxy", peer: "10.1.2.3", forwarded: []string{"192.0.2.1", "198.51.100.1"}, want: "198.51.100.1"},
+		{name: "untrusted proxy", peer: "192.0.2.10", forwarded: []string{"198.51.100.1"}, want: "192.0.2.10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP(tt.peer), Port: 50000},
+			})
+			md := metadata.MD{}
+			if tt.forwarded != nil {
+				md["x-forwarded-for"] = tt.forwarded
+			}
+			ctx = metadata.NewIncomingContext(ctx, md)
+
+			assert.Equal(t, tt.want, getClientFromContext(ctx, trusted).IP)
+		})
+	}
+}
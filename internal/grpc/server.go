@@ -20,15 +20,15 @@ import (
 
 type UserModifier interface {
 	UpdateUser(ctx context.Context, user generated.UpdateUserParams) (*generated.User, error)
-	AddAdmin(ctx context.Context, username string, scale generated.AdminScale) (*model.Admin, error)
-	DeleteAdmin(ctx context.Context, id uuid.UUID, scale generated.AdminScale) error
+	AddAdmin(ctx context.Context, actorID uuid.UUID, username string, scale generated.AdminScale) (*model.Admin, error)
+	DeleteAdmin(ctx context.Context, actorID, id uuid.UUID, scale generated.AdminScale) error
 	InitAdmin(ctx context.Context, username string) (*model.Admin, error)
 }
 
 type UserProvider interface {
-	GetUsers(ctx context.Context, params model.GetUsersParams) (users []generated.User, total *uint64, err error)
+	GetUsers(ctx context.Context, params model.GetUsersParams) (users []generated.User, page *model.Page, err error)
 	GetUser(ctx context.Context, id uuid.UUID) (*generated.User, error)
-	GetAdmins(ctx context.Context, params generated.GetAdminsParams) (admins []generated.GetAdminsRow, total *uint64, err error)
+	GetAdmins(ctx context.Context, params model.GetAdminsParams) (admins []model.Admin, page *model.Page, err error)
 }
 
 type AuthProvider interface {
@@ -36,11 +36,16 @@ type AuthProvider interface {
 	RefreshToken(ctx context.Context, token string) (accessToken, refreshToken *string, err error)
 }
 
+type ReadinessChecker interface {
+	Ready() error
+}
+
 type server struct {
 	userv1.UnimplementedUserServiceServer
 	userModifier UserModifier
 	userProvider UserProvider
 	authProvider AuthProvider
+	checker      ReadinessChecker
 	log          *slog.Logger
 }
 
@@ -49,9 +54,15 @@ func Register(
 	userModifier UserModifier,
 	userProvider UserProvider,
 	authProvider AuthProvider,
+	checker ReadinessChecker,
 	log *slog.Logger,
 ) {
-	userv1.RegisterUserServiceServer(gRPCServer, &server{userModifier: userModifier, userProvider: userProvider, authProvider: authProvider, log: log})
+	userv1.RegisterUserServiceServer(gRPCServer, &server{userModifier: userModifier, userProvider: userProvider, authProvider: authProvider, checker: checker, log: log})
+}
+
+// logger returns logger of request, so records carry its request id, user id and method.
+func (s *server) logger(ctx context.Context) *slog.Logger {
+	return sl.FromContext(ctx, s.log)
 }
 
 func (s *server) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.UpdateUserResponse, error) {
@@ -64,10 +75,26 @@ func (s *server) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest)
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 
+	mask, err := getFieldMaskFromContext(ctx, UpdateMaskHeader, req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	updateUser := model.ToDomainUpdateUserParams(*id, req)
+	if err := model.ApplyUpdateMask(updateUser, req, mask); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	user, err := s.userModifier.UpdateUser(ctx, *updateUser)
 	if err != nil {
-		s.log.Error("internal error", sl.Err(err))
+		if errors.Is(err, model.ErrEmptyPseudonym) || errors.Is(err, model.ErrPseudonymNotAllowed) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		} else if errors.Is(err, model.ErrPseudonymTaken) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		} else if errors.Is(err, model.ErrPseudonymCooldown) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		s.logger(ctx).Error("internal error", sl.Err(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -80,16 +107,61 @@ func (s *server) GetUsers(ctx context.Context, req *userv1.GetUsersRequest) (*us
 	}
 
 	params := model.ToDomainGetUsersParams(req)
-	users, total, err := s.userProvider.GetUsers(ctx, *params)
+	pageParams, err := getPageParamsFromContext(ctx)
 	if err != nil {
-		if errors.Is(err, model.ErrOrderByInvalidField) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	params.PageParams = pageParams
+
+	sort, err := getSortFromContext(ctx, model.UserSortFields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if sort != nil {
+		params.Sort = sort
+	}
+
+	params.Query, err = getSearchQueryFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	params.Filter = getFilterFromContext(ctx)
+	if params.Filter != nil && getAdminFromContext(ctx) == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "%s: %s", model.ErrUnauthorized, "filter requires admin")
+	}
+
+	params.IncludeDeleted = getIncludeDeletedFromContext(ctx)
+	if params.IncludeDeleted && getAdminFromContext(ctx) == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "%s: %s", model.ErrUnauthorized, "deleted users are listed for admins only")
+	}
+
+	readMask, err := getFieldMaskFromContext(ctx, ReadMaskHeader, &userv1.User{})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	users, page, err := s.userProvider.GetUsers(ctx, *params)
+	if err != nil {
+		if errors.Is(err, model.ErrOrderByInvalidField) || errors.Is(err, model.ErrInvalidCursor) || errors.Is(err, model.ErrCursorNotSupported) ||
+			errors.Is(err, model.ErrInvalidFilter) {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
-		s.log.Error("internal error", sl.Err(err))
+		s.logger(ctx).Error("internal error", sl.Err(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if err := setPageHeaders(ctx, page); err != nil {
+		s.logger(ctx).Error("failed to set page headers", sl.Err(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return model.ToGetUsersResponse(users, *total, params), nil
+	res := model.ToGetUsersResponse(users, page, params)
+	for _, user := range res.Users {
+		model.ApplyReadMask(user, readMask)
+	}
+
+	return res, nil
 }
 
 func (s *server) RefreshToken(ctx context.Context, req *userv1.RefreshTokenRequest) (*userv1.RefreshTokenResponse, error) {
@@ -98,7 +170,7 @@ func (s *server) RefreshToken(ctx context.Context, req *userv1.RefreshTokenReque
 		if errors.Is(err, model.ErrRefreshTokenNotValid) {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
-		s.log.Error("internal error", sl.Err(err))
+		s.logger(ctx).Error("internal error", sl.Err(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -124,10 +196,15 @@ func (s *server) Login(ctx context.Context, req *userv1.LoginRequest) (*userv1.L
 
 	accessToken, refreshToken, err := s.authProvider.Login(ctx, *user)
 	if err != nil {
-		if errors.Is(err, model.ErrEmptyPseudonym) {
+		if errors.Is(err, model.ErrBotNotAllowed) || errors.Is(err, model.ErrUserDeletedByAdmin) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		if errors.Is(err, model.ErrEmptyPseudonym) || errors.Is(err, model.ErrPseudonymNotAllowed) {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
+		} else if errors.Is(err, model.ErrPseudonymTaken) || errors.Is(err, model.ErrUsernameTaken) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
 		}
-		s.log.Error("internal error", sl.Err(err))
+		s.logger(ctx).Error("internal error", sl.Err(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -147,23 +224,31 @@ func (s *server) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*user
 		return nil, status.Error(codes.InvalidArgument, "user id must be uuid")
 	}
 
+	readMask, err := getFieldMaskFromContext(ctx, ReadMaskHeader, &userv1.GetUserResponse{})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	user, err := s.userProvider.GetUser(ctx, userID)
 	if err != nil {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
-		s.log.Error("internal error", sl.Err(err))
+		s.logger(ctx).Error("internal error", sl.Err(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return &userv1.GetUserResponse{
+	res := &userv1.GetUserResponse{
 		UserId:    user.ID.String(),
 		Username:  user.Username,
 		Pseudonym: user.Pseudonym,
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		CreatedAt: timestamppb.New(user.CreatedAt.Time),
-	}, nil
+	}
+	model.ApplyReadMask(res, readMask)
+
+	return res, nil
 }
 
 func (s *server) AddAdmin(ctx context.Context, req *userv1.AddAdminRequest) (*userv1.AddAdminResponse, error) {
@@ -176,16 +261,23 @@ func (s *server) AddAdmin(ctx context.Context, req *userv1.AddAdminRequest) (*us
 		return nil, status.Error(codes.Unauthenticated, "must be admin")
 	}
 
-	res, err := s.userModifier.AddAdmin(ctx, req.Username, generated.AdminScale(*admin))
+	actorID, err := getUserIDFromContext(ctx)
 	if err != nil {
-		if errors.Is(err, model.ErrAdminAlreadyExists) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	res, err := s.userModifier.AddAdmin(ctx, *actorID, req.Username, generated.AdminScale(*admin))
+	if err != nil {
+		if errors.Is(err, model.ErrAdminChangePending) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		} else if errors.Is(err, model.ErrAdminAlreadyExists) {
 			return nil, status.Error(codes.AlreadyExists, err.Error())
 		} else if errors.Is(err, model.ErrAdminNotMajor) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		} else if errors.Is(err, model.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
-		s.log.Error("internal error", sl.Err(err))
+		s.logger(ctx).Error("internal error", sl.Err(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -212,11 +304,18 @@ func (s *server) DeleteAdmin(ctx context.Context, req *userv1.DeleteAdminRequest
 		return nil, status.Error(codes.Unauthenticated, "must be admin")
 	}
 
-	if err := s.userModifier.DeleteAdmin(ctx, userID, generated.AdminScale(*admin)); err != nil && !errors.Is(err, model.ErrUserNotFound) {
-		if errors.Is(err, model.ErrAdminNotMajor) || errors.Is(err, model.ErrCannotDeleteMajorAdmin) {
+	actorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if err := s.userModifier.DeleteAdmin(ctx, *actorID, userID, generated.AdminScale(*admin)); err != nil && !errors.Is(err, model.ErrUserNotFound) {
+		if errors.Is(err, model.ErrAdminChangePending) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		} else if errors.Is(err, model.ErrAdminNotMajor) || errors.Is(err, model.ErrCannotDeleteMajorAdmin) {
 			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
-		s.log.Error("internal error", sl.Err(err))
+		s.logger(ctx).Error("internal error", sl.Err(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -230,12 +329,12 @@ func (s *server) InitAdmin(ctx context.Context, req *userv1.InitAdminRequest) (*
 
 	p, ok := peer.FromContext(ctx)
 	if !ok {
-		s.log.Error("internal error", slog.String("error", "could not get peer info"))
+		s.logger(ctx).Error("internal error", slog.String("error", "could not get peer info"))
 		return nil, status.Error(codes.Internal, "could not get peer info")
 	}
 
 	if !isLocalhost(p.Addr.String()) {
-		s.log.Debug("request must be from localhost", slog.String("from", p.Addr.String()))
+		s.logger(ctx).Debug("request must be from localhost", slog.String("from", p.Addr.String()))
 		return nil, status.Error(codes.PermissionDenied, "request must be from localhost")
 	}
 
@@ -246,7 +345,7 @@ func (s *server) InitAdmin(ctx context.Context, req *userv1.InitAdminRequest) (*
 		} else if errors.Is(err, model.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
-		s.log.Error("internal error", sl.Err(err))
+		s.logger(ctx).Error("internal error", sl.Err(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -258,7 +357,13 @@ func (s *server) InitAdmin(ctx context.Context, req *userv1.InitAdminRequest) (*
 	}, nil
 }
 
-func (s *server) Health(context.Context, *userv1.HealthRequest) (*userv1.HealthResponse, error) {
+// Health reports readiness the same way as grpc.health.v1 and /readyz, failed dependencies are
+// logged by checker and not exposed.
+func (s *server) Health(_ context.Context, _ *userv1.HealthRequest) (*userv1.HealthResponse, error) {
+	if err := s.checker.Ready(); err != nil {
+		return nil, status.Error(codes.Unavailable, "service is not ready")
+	}
+
 	return &userv1.HealthResponse{
 		Message: "OK",
 	}, nil
@@ -274,11 +379,29 @@ func (s *server) GetAdmins(ctx context.Context, req *userv1.GetAdminsRequest) (*
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	admins, total, err := s.userProvider.GetAdmins(ctx, *params)
+	params.PageParams, err = getPageParamsFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	params.Sort, err = getSortFromContext(ctx, model.AdminSortFields)
 	if err != nil {
-		s.log.Error("internal error", sl.Err(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	admins, page, err := s.userProvider.GetAdmins(ctx, *params)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidCursor) || errors.Is(err, model.ErrOrderByInvalidField) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger(ctx).Error("internal error", sl.Err(err))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if err := setPageHeaders(ctx, page); err != nil {
+		s.logger(ctx).Error("failed to set page headers", sl.Err(err))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return model.ToGetAdminsResponse(admins, *total, req), nil
+	return model.ToGetAdminsResponse(admins, page, params), nil
 }
@@ -0,0 +1,207 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAdminRequestsLimit = 50
+	maxAdminRequestsLimit     = 100
+)
+
+type AdminRequestDecider interface {
+	GetAdminRequests(ctx context.Context, params generated.GetAdminRequestsParams) (requests []generated.AdminRequest, total *uint64, err error)
+	ApproveAdminRequest(ctx context.Context, id, approverID uuid.UUID, scale generated.AdminScale) (*generated.AdminRequest, error)
+	RejectAdminRequest(ctx context.Context, id, approverID uuid.UUID, scale generated.AdminScale) (*generated.AdminRequest, error)
+}
+
+type adminRequestHandler struct {
+	decider AdminRequestDecider
+	secret  string
+	log     *slog.Logger
+}
+
+type adminRequest struct {
+	ID           uuid.UUID  `json:"id"`
+	Action       string     `json:"action"`
+	TargetUserID uuid.UUID  `json:"target_user_id"`
+	Scale        *string    `json:"scale"`
+	RequestedBy  uuid.UUID  `json:"requested_by"`
+	Status       string     `json:"status"`
+	DecidedBy    *uuid.UUID `json:"decided_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	DecidedAt    *time.Time `json:"decided_at"`
+}
+
+type getAdminRequestsResponse struct {
+	Requests   []adminRequest  `json:"requests"`
+	Pagination auditPagination `json:"pagination"`
+}
+
+func toAdminRequest(request generated.AdminRequest) adminRequest {
+	res := adminRequest{
+		ID:           request.ID,
+		Action:       string(request.Action),
+		TargetUserID: request.TargetUserID,
+		RequestedBy:  request.RequestedBy,
+		Status:       string(request.Status),
+		CreatedAt:    request.CreatedAt.Time,
+		ExpiresAt:    request.ExpiresAt.Time,
+	}
+	if request.Scale.Valid {
+		scale := string(request.Scale.AdminScale)
+		res.Scale = &scale
+	}
+	if request.DecidedBy.Valid {
+		id := uuid.UUID(request.DecidedBy.Bytes)
+		res.DecidedBy = &id
+	}
+	if request.DecidedAt.Valid {
+		res.DecidedAt = &request.DecidedAt.Time
+	}
+
+	return res
+}
+
+// RegisterAdminRequests adds routes of pending admin changes to gateway for admins:
+// GET /v1/admin/requests lists requests filtered by status and paginated with limit and offset,
+// POST /v1/admin/requests/{id}/approve and POST /v1/admin/requests/{id}/reject decide request,
+// only major admins other than requester can decide it.
+func RegisterAdminRequests(mux *Router, decider AdminRequestDecider, secret string, log *slog.Logger) error {
+	h := &adminRequestHandler{decider: decider, secret: secret, log: log}
+
+	if err := mux.HandlePath(http.MethodGet, "/v1/admin/requests", "GetAdminRequests", h.getRequests); err != nil {
+		return err
+	}
+
+	if err := mux.HandlePath(http.MethodPost, "/v1/admin/requests/{id}/approve", "ApproveAdminRequest", h.approve); err != nil {
+		return err
+	}
+
+	return mux.HandlePath(http.MethodPost, "/v1/admin/requests/{id}/reject", "RejectAdminRequest", h.reject)
+}
+
+func (h *adminRequestHandler) getRequests(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	if _, ok := h.authenticateAdmin(w, r); !ok {
+		return
+	}
+
+	params, err := parseAdminRequestsParams(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	requests, total, err := h.decider.GetAdminRequests(r.Context(), *params)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to get admin requests", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	res := getAdminRequestsResponse{
+		Requests: make([]adminRequest, 0, len(requests)),
+		Pagination: auditPagination{
+			Total:  *total,
+			Limit:  uint64(params.Limit),
+			Offset: uint64(params.Offset),
+		},
+	}
+	for _, request := range requests {
+		res.Requests = append(res.Requests, toAdminRequest(request))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *adminRequestHandler) approve(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	h.decide(w, r, params, h.decider.ApproveAdminRequest)
+}
+
+func (h *adminRequestHandler) reject(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	h.decide(w, r, params, h.decider.RejectAdminRequest)
+}
+
+func (h *adminRequestHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	params map[string]string,
+	decide func(ctx context.Context, id, deciderID uuid.UUID, scale generated.AdminScale) (*generated.AdminRequest, error),
+) {
+	actor, ok := h.authenticateAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(params["id"])
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid request id, must be uuid"})
+		return
+	}
+
+	request, err := decide(r.Context(), id, actor.id, actor.admin.AdminScale)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to decide admin request", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAdminRequest(*request))
+}
+
+func (h *adminRequestHandler) authenticateAdmin(w http.ResponseWriter, r *http.Request) (*caller, bool) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return nil, false
+	}
+	if !actor.admin.Valid {
+		writeError(w, model.ErrUnauthorized)
+		return nil, false
+	}
+
+	return actor, true
+}
+
+func parseAdminRequestsParams(r *http.Request) (*generated.GetAdminRequestsParams, error) {
+	values := r.URL.Query()
+	params := &generated.GetAdminRequestsParams{Limit: defaultAdminRequestsLimit}
+
+	if value := values.Get("status"); value != "" {
+		switch status := generated.AdminRequestStatus(value); status {
+		case generated.AdminRequestStatusPending, generated.AdminRequestStatusApproved,
+			generated.AdminRequestStatusRejected, generated.AdminRequestStatusExpired:
+			params.Status = generated.NullAdminRequestStatus{AdminRequestStatus: status, Valid: true}
+		default:
+			return nil, errInvalidParam("status", "must be pending, approved, rejected or expired")
+		}
+	}
+
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || limit <= 0 || limit > maxAdminRequestsLimit {
+			return nil, errInvalidParam("limit", "must be from 1 to "+strconv.Itoa(maxAdminRequestsLimit))
+		}
+		params.Limit = int32(limit)
+	}
+
+	if value := values.Get("offset"); value != "" {
+		offset, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || offset < 0 {
+			return nil, errInvalidParam("offset", "must be non-negative integer")
+		}
+		params.Offset = int32(offset)
+	}
+
+	return params, nil
+}
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 100
+)
+
+type AuditProvider interface {
+	GetAuditEvents(ctx context.Context, params model.GetAuditEventsParams) (events []generated.AuditEvent, total *uint64, err error)
+}
+
+type auditHandler struct {
+	provider AuditProvider
+	secret   string
+	log      *slog.Logger
+}
+
+type auditEvent struct {
+	ID        uuid.UUID       `json:"id"`
+	Action    string          `json:"action"`
+	Outcome   string          `json:"outcome"`
+	ActorID   *uuid.UUID      `json:"actor_id"`
+	TargetID  *uuid.UUID      `json:"target_id"`
+	IP        *string         `json:"ip"`
+	UserAgent *string         `json:"user_agent"`
+	Metadata  json.RawMessage `json:"metadata"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type auditPagination struct {
+	Total  uint64 `json:"total"`
+	Limit  uint64 `json:"limit"`
+	Offset uint64 `json:"offset"`
+}
+
+type getAuditEventsResponse struct {
+	Events     []auditEvent    `json:"events"`
+	Pagination auditPagination `json:"pagination"`
+}
+
+func toAuditEvent(event generated.AuditEvent) auditEvent {
+	res := auditEvent{
+		ID:        event.ID,
+		Action:    event.Action,
+		Outcome:   event.Outcome,
+		IP:        event.Ip,
+		UserAgent: event.UserAgent,
+		Metadata:  event.Metadata,
+		CreatedAt: event.CreatedAt.Time,
+	}
+	if event.ActorID.Valid {
+		id := uuid.UUID(event.ActorID.Bytes)
+		res.ActorID = &id
+	}
+	if event.TargetID.Valid {
+		id := uuid.UUID(event.TargetID.Bytes)
+		res.TargetID = &id
+	}
+
+	return res
+}
+
+// RegisterAudit adds GET /v1/audit/events to gateway for admins, events are filtered by
+// actor_id, target_id, action, outcome and RFC 3339 from and to, and paginated with limit and offset.
+// Protos are frozen, so audit log is queried only over HTTP, there is no RPC for it.
+func RegisterAudit(mux *Router, provider AuditProvider, secret string, log *slog.Logger) error {
+	h := &auditHandler{provider: provider, secret: secret, log: log}
+
+	return mux.HandlePath(http.MethodGet, "/v1/audit/events", "GetAuditEvents", h.getEvents)
+}
+
+func (h *auditHandler) getEvents(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return
+	}
+	if !actor.admin.Valid {
+		writeError(w, model.ErrUnauthorized)
+		return
+	}
+
+	params, err := parseAuditParams(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	events, total, err := h.provider.GetAuditEvents(r.Context(), *params)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to get audit events", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	res := getAuditEventsResponse{
+		Events:     make([]auditEvent, 0, len(events)),
+		Pagination: auditPagination{Total: *total, Limit: params.Limit, Offset: params.Offset},
+	}
+	for _, event := range events {
+		res.Events = append(res.Events, toAuditEvent(event))
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func parseAuditParams(r *http.Request) (*model.GetAuditEventsParams, error) {
+	values := r.URL.Query()
+	params := &model.GetAuditEventsParams{Limit: defaultAuditLimit}
+
+	for name, field := range map[string]**uuid.UUID{"actor_id": &params.ActorID, "target_id": &params.TargetID} {
+		if value := values.Get(name); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return nil, errInvalidParam(name, "must be uuid")
+			}
+			*field = &id
+		}
+	}
+
+	for name, field := range map[string]**string{"action": &params.Action, "outcome": &params.Outcome} {
+		if value := values.Get(name); value != "" {
+			*field = &value
+		}
+	}
+
+	for name, field := range map[string]**time.Time{"from": &params.From, "to": &params.To} {
+		if value := values.Get(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, errInvalidParam(name, "must be RFC 3339 time")
+			}
+			*field = &t
+		}
+	}
+
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || limit == 0 || limit > maxAuditLimit {
+			return nil, errInvalidParam("limit", "must be from 1 to "+strconv.Itoa(maxAuditLimit))
+		}
+		params.Limit = limit
+	}
+
+	if value := values.Get("offset"); value != "" {
+		offset, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, errInvalidParam("offset", "must be non-negative integer")
+		}
+		params.Offset = offset
+	}
+
+	return params, nil
+}
+
+func errInvalidParam(name, reason string) error {
+	return errors.New(name + " " + reason)
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxBatchBodySize limits request body, it fits thousands of ids.
+const maxBatchBodySize = 64 << 10
+
+type BatchUserProvider interface {
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) (users []generated.User, missing []uuid.UUID, err error)
+}
+
+type batchHandler struct {
+	provider BatchUserProvider
+	log      *slog.Logger
+}
+
+type batchGetRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids"`
+}
+
+type batchGetResponse struct {
+	Users      []json.RawMessage `json:"users"`
+	MissingIDs []uuid.UUID       `json:"missing_ids"`
+}
+
+// RegisterBatch adds POST /v1/users/batch to gateway, it takes {"user_ids": [...]} and returns
+// found users in request order with ids of missing users, so services resolve page of authors at once.
+func RegisterBatch(mux *Router, provider BatchUserProvider, log *slog.Logger) error {
+	h := &batchHandler{provider: provider, log: log}
+
+	return mux.HandlePath(http.MethodPost, "/v1/users/batch", "BatchGetUsers", h.batchGet)
+}
+
+func (h *batchHandler) batchGet(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	var req batchGetRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodySize)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "body must be {\"user_ids\": [uuid, ...]}"})
+		return
+	}
+
+	users, missing, err := h.provider.GetUsersByIDs(r.Context(), req.UserIDs)
+	if err != nil {
+		if errors.Is(err, model.ErrTooManyIDs) {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		sl.FromContext(r.Context(), h.log).Error("failed to get users by ids", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	res := batchGetResponse{Users: []json.RawMessage{}, MissingIDs: []uuid.UUID{}}
+	res.MissingIDs = append(res.MissingIDs, missing...)
+	for _, user := range users {
+		data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(model.ToUser(user))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		res.Users = append(res.Users, data)
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger/slogdiscard"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type batchProviderFunc func(ctx context.Context, ids []uuid.UUID) ([]generated.User, []uuid.UUID, error)
+
+func (f batchProviderFunc) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]generated.User, []uuid.UUID, error) {
+	return f(ctx, ids)
+}
+
+func serveBatch(t *testing.T, provider BatchUserProvider, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	mux, router := newTestRouter(t)
+	require.NoError(t, RegisterBatch(router, provider, slogdiscard.NewDiscardLogger()))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/users/batch", strings.NewReader(body)))
+	return rec
+}
+
+func TestBatchGet_Success(t *testing.T) {
+	t.Parallel()
+
+	found, missing := uuid.New(), uuid.New()
+	var requested []uuid.UUID
+	provider := batchProviderFunc(func(_ context.Context, ids []uuid.UUID) ([]generated.User, []uuid.UUID, error) {
+		requested = ids
+		return []generated.User{{ID: found, Username: "qwerty", Pseudonym: "Metro"}}, []uuid.UUID{missing}, nil
+	})
+
+	rec := serveBatch(t, provider, `{"user_ids": ["`+found.String()+`", "`+missing.String()+`"]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []uuid.UUID{found, missing}, requested)
+
+	var res struct {
+		Users []struct {
+			UserID    string `json:"userId"`
+			Username  string `json:"username"`
+			Pseudonym string `json:"pseudonym"`
+		} `json:"users"`
+		MissingIDs []uuid.UUID `json:"missing_ids"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+	require.Len(t, res.Users, 1)
+	assert.Equal(t, found.String(), res.Users[0].UserID)
+	assert.Equal(t, "qwerty", res.Users[0].Username)
+	assert.Equal(t, "Metro", res.Users[0].Pseudonym)
+	assert.Equal(t, []uuid.UUID{missing}, res.MissingIDs)
+}
+
+func TestBatchGet_SuccessEmpty(t *testing.T) {
+	t.Parallel()
+
+	provider := batchProviderFunc(func(context.Context, []uuid.UUID) ([]generated.User, []uuid.UUID, error) {
+		return nil, nil, nil
+	})
+
+	rec := serveBatch(t, provider, `{"user_ids": []}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"users": [], "missing_ids": []}`, rec.Body.String())
+}
+
+func TestBatchGet_Fail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		err  error
+		code int
+	}{
+		{name: "invalid body", body: `{"user_ids": "qwerty"}`, code: http.StatusBadRequest},
+		{name: "invalid id", body: `{"user_ids": ["qwerty"]}`, code: http.StatusBadRequest},
+		{name: "too many ids", body: `{"user_ids": []}`, err: model.ErrTooManyIDs, code: http.StatusBadRequest},
+		{name: "internal error", body: `{"user_ids": []}`, err: errors.New("internal error"), code: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := batchProviderFunc(func(context.Context, []uuid.UUID) ([]generated.User, []uuid.UUID, error) {
+				return nil, nil, tt.err
+			})
+
+			rec := serveBatch(t, provider, tt.body)
+			assert.Equal(t, tt.code, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+type Exporter interface {
+	ExportUserData(ctx context.Context, actorID, id uuid.UUID, scale generated.NullAdminScale) (*model.UserData, error)
+	RequestUserDataExport(ctx context.Context, actorID, id uuid.UUID, scale generated.NullAdminScale) (*string, error)
+	GetUserDataExport(ctx context.Context, actorID uuid.UUID, token string) ([]byte, error)
+}
+
+type exportHandler struct {
+	exporter Exporter
+	secret   string
+	log      *slog.Logger
+}
+
+// RegisterExport adds personal data export routes to gateway:
+// GET /v1/users/{user_id}/export returns export right away,
+// POST /v1/users/{user_id}/export starts asynchronous export and returns download token,
+// GET /v1/exports/{token} downloads asynchronous export.
+func RegisterExport(mux *Router, exporter Exporter, secret string, log *slog.Logger) error {
+	h := &exportHandler{exporter: exporter, secret: secret, log: log}
+
+	if err := mux.HandlePath(http.MethodGet, "/v1/users/{user_id}/export", "GetExport", h.export); err != nil {
+		return err
+	}
+
+	if err := mux.HandlePath(http.MethodPost, "/v1/users/{user_id}/export", "RequestExport", h.requestExport); err != nil {
+		return err
+	}
+
+	return mux.HandlePath(http.MethodGet, "/v1/exports/{token}", "DownloadExport", h.download)
+}
+
+func (h *exportHandler) export(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	actor, id, ok := h.parseRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	data, err := h.exporter.ExportUserData(r.Context(), actor.id, id, actor.admin)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to export user data", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="`+id.String()+`.json"`)
+	writeJSON(w, http.StatusOK, data)
+}
+
+func (h *exportHandler) requestExport(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	actor, id, ok := h.parseRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	token, err := h.exporter.RequestUserDataExport(r.Context(), actor.id, id, actor.admin)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to request user data export", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]string{"token": *token})
+}
+
+func (h *exportHandler) download(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return
+	}
+
+	data, err := h.exporter.GetUserDataExport(r.Context(), actor.id, params["token"])
+	if errors.Is(err, model.ErrExportPending) {
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": model.ExportStatusPending})
+		return
+	} else if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="export.json"`)
+	w.Write(data) // nolint
+}
+
+func (h *exportHandler) parseRequest(w http.ResponseWriter, r *http.Request, params map[string]string) (*caller, uuid.UUID, bool) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid user id, must be uuid"})
+		return nil, uuid.Nil, false
+	}
+
+	return actor, id, true
+}
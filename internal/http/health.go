@@ -0,0 +1,39 @@
+package http
+
+import "net/http"
+
+type ReadinessChecker interface {
+	Ready() error
+}
+
+type healthHandler struct {
+	checker ReadinessChecker
+}
+
+type healthResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// RegisterHealth adds probes to mux, /livez reports that process serves requests and
+// /readyz that dependencies respond and service is not shutting down.
+func RegisterHealth(mux *http.ServeMux, checker ReadinessChecker) {
+	h := &healthHandler{checker: checker}
+
+	mux.HandleFunc("GET /livez", h.live)
+	mux.HandleFunc("GET /readyz", h.ready)
+}
+
+func (h *healthHandler) live(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// ready reports status cached by checker, failed dependencies are logged by checker and not exposed.
+func (h *healthHandler) ready(w http.ResponseWriter, _ *http.Request) {
+	if err := h.checker.Ready(); err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, healthResponse{Status: "unavailable", Message: "service is not ready"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, healthResponse{Status: "ok"})
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/token"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// RequestIDHeader correlates logs of request in gateway and gRPC server.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds request id passed by client, longer ids are replaced.
+const maxRequestIDLength = 128
+
+// RequestID accepts request id of client or generates one, passes it to gRPC server in header,
+// returns it in response and stores logger with it in context of request.
+func RequestID(next http.Handler, log *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = uuid.NewString()
+			r.Header.Set(RequestIDHeader, id)
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := sl.WithContext(r.Context(), log.With(
+			slog.String("request_id", id),
+			slog.String("method", r.Method+" "+r.URL.Path),
+		))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+type caller struct {
+	id    uuid.UUID
+	admin generated.NullAdminScale
+	// tenant is organization of scoped token, it is nil for unscoped tokens
+	tenant     *uuid.UUID
+	tenantRole string
+	claims     map[string]any
+}
+
+// attributes returns caller attributes the way policies see them, missing values are empty strings.
+func (c *caller) attributes() map[string]any {
+	var id, tenant string
+	if c.id != uuid.Nil {
+		id = c.id.String()
+	}
+	if c.tenant != nil {
+		tenant = c.tenant.String()
+	}
+
+	return map[string]any{
+		"id":          id,
+		"admin":       string(c.admin.AdminScale),
+		"tenant":      tenant,
+		"tenant_role": c.tenantRole,
+	}
+}
+
+// authenticate validates bearer token of request, routes outside of gRPC gateway
+// do not pass auth interceptor.
+func authenticate(r *http.Request, secret string) (*caller, error) {
+	data := strings.Split(r.Header.Get("Authorization"), " ")
+	if len(data) < 2 || strings.ToLower(data[0]) != "bearer" {
+		return nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "bearer token not provided")
+	}
+
+	claims, err := token.Validate(strings.TrimSpace(data[1]), secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ids of validated claims are uuids
+	res := &caller{id: uuid.MustParse(claims.ID), claims: claims.Raw}
+	if claims.Admin != nil {
+		if err := res.admin.Scan(*claims.Admin); err != nil {
+			return nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "invalid admin")
+		}
+	}
+
+	if claims.Tenant != nil {
+		tenant := uuid.MustParse(*claims.Tenant)
+		res.tenant = &tenant
+		res.tenantRole = *claims.TenantRole
+	}
+
+	return res, nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v) // nolint
+}
+
+// writeProto writes message the same way gateway does, so custom routes return
+// responses of the same shape as generated ones.
+func writeProto(w http.ResponseWriter, code int, m proto.Message) {
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(data) // nolint
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, model.ErrUnauthorized), errors.Is(err, model.ErrMetadataForbidden),
+		errors.Is(err, model.ErrAdminNotMajor), errors.Is(err, model.ErrSelfApproval),
+		errors.Is(err, model.ErrCannotDeleteMajorAdmin), errors.Is(err, model.ErrLastMajorAdmin),
+		errors.Is(err, model.ErrOrganizationRoleForbidden),
+		errors.Is(err, model.ErrCannotRemoveOwner), errors.Is(err, model.ErrTenantMismatch):
+		code = http.StatusForbidden
+	case errors.Is(err, model.ErrUserNotFound), errors.Is(err, model.ErrExportNotFound),
+		errors.Is(err, model.ErrMetadataNotFound), errors.Is(err, model.ErrMetadataNamespaceNotFound),
+		errors.Is(err, model.ErrAdminRequestNotFound), errors.Is(err, model.ErrOrganizationMemberNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, model.ErrAdminAlreadyExists), errors.Is(err, model.ErrOrganizationMemberExists),
+		errors.Is(err, model.ErrPseudonymTaken):
+		code = http.StatusConflict
+	case errors.Is(err, model.ErrMetadataInvalid), errors.Is(err, model.ErrPseudonymNotAllowed),
+		errors.Is(err, model.ErrEmptyPseudonym):
+		code = http.StatusBadRequest
+	case errors.Is(err, model.ErrMetadataTooLarge):
+		code = http.StatusRequestEntityTooLarge
+	case errors.Is(err, model.ErrExportUnavailable):
+		code = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, code, map[string]string{"message": err.Error()})
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+// serviceKeyHeader carries static key of service client.
+const serviceKeyHeader = "X-Service-Key"
+
+// maxMetadataBody limits request body, namespace size limit is checked by service.
+const maxMetadataBody = 1 << 20
+
+type MetadataManager interface {
+	AuthenticateServiceClient(key string) (string, error)
+	GetUserMetadata(ctx context.Context, clientID string, userID uuid.UUID, namespace string) (*model.Metadata, error)
+	SetUserMetadata(ctx context.Context, clientID string, userID uuid.UUID, namespace string, data []byte) (*model.Metadata, error)
+	PatchUserMetadata(ctx context.Context, clientID string, userID uuid.UUID, namespace string, patch []byte) (*model.Metadata, error)
+}
+
+type metadataHandler struct {
+	manager MetadataManager
+	log     *slog.Logger
+}
+
+// RegisterMetadata adds user metadata routes to gateway, they are called by service
+// clients owning the namespace and authenticated by X-Service-Key header. Protos are frozen,
+// so metadata has no RPCs and is served over HTTP only:
+// GET /v1/users/{user_id}/metadata/{namespace} returns namespace data,
+// PUT /v1/users/{user_id}/metadata/{namespace} replaces it,
+// PATCH /v1/users/{user_id}/metadata/{namespace} applies JSON Merge Patch to it.
+func RegisterMetadata(mux *Router, manager MetadataManager, log *slog.Logger) error {
+	h := &metadataHandler{manager: manager, log: log}
+
+	const path = "/v1/users/{user_id}/metadata/{namespace}"
+	if err := mux.HandlePath(http.MethodGet, path, "GetUserMetadata", h.get); err != nil {
+		return err
+	}
+
+	if err := mux.HandlePath(http.MethodPut, path, "SetUserMetadata", h.set); err != nil {
+		return err
+	}
+
+	return mux.HandlePath(http.MethodPatch, path, "PatchUserMetadata", h.patch)
+}
+
+func (h *metadataHandler) get(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	clientID, userID, ok := h.parseRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	res, err := h.manager.GetUserMetadata(r.Context(), clientID, userID, params["namespace"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *metadataHandler) set(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	h.update(w, r, params, h.manager.SetUserMetadata)
+}
+
+func (h *metadataHandler) patch(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	h.update(w, r, params, h.manager.PatchUserMetadata)
+}
+
+func (h *metadataHandler) update(
+	w http.ResponseWriter,
+	r *http.Request,
+	params map[string]string,
+	update func(ctx context.Context, clientID string, userID uuid.UUID, namespace string, data []byte) (*model.Metadata, error),
+) {
+	clientID, userID, ok := h.parseRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMetadataBody))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, model.ErrMetadataTooLarge)
+			return
+		}
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	res, err := update(r.Context(), clientID, userID, params["namespace"], body)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Debug("failed to update user metadata", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *metadataHandler) parseRequest(w http.ResponseWriter, r *http.Request, params map[string]string) (string, uuid.UUID, bool) {
+	clientID, err := h.manager.AuthenticateServiceClient(r.Header.Get(serviceKeyHeader))
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return "", uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid user id, must be uuid"})
+		return "", uuid.Nil, false
+	}
+
+	return clientID, id, true
+}
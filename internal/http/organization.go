@@ -0,0 +1,233 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	maxOrganizationBodySize = 4 << 10
+	maxOrganizationName     = 128
+)
+
+type OrganizationManager interface {
+	CreateOrganization(ctx context.Context, ownerID uuid.UUID, name string) (*generated.Organization, error)
+	GetUserOrganizations(ctx context.Context, userID uuid.UUID) ([]generated.GetUserOrganizationsRow, error)
+	InviteOrganizationMember(ctx context.Context, actorID, organizationID uuid.UUID, username string, role generated.OrganizationRole) error
+	RemoveOrganizationMember(ctx context.Context, actorID, organizationID, userID uuid.UUID) error
+	OrganizationToken(ctx context.Context, userID, organizationID uuid.UUID) (*string, error)
+}
+
+type organizationHandler struct {
+	manager OrganizationManager
+	secret  string
+	log     *slog.Logger
+}
+
+type organization struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type createOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+type inviteOrganizationMemberRequest struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// RegisterOrganizations adds organization routes to gateway:
+// POST /v1/organizations creates organization owned by caller,
+// GET /v1/organizations lists organizations of caller,
+// POST /v1/organizations/{organization_id}/members invites user by username,
+// DELETE /v1/organizations/{organization_id}/members/{user_id} removes member,
+// POST /v1/organizations/{organization_id}/token issues access token scoped to organization.
+// Token scoped to organization reaches only routes of that organization.
+func RegisterOrganizations(mux *Router, manager OrganizationManager, secret string, log *slog.Logger) error {
+	h := &organizationHandler{manager: manager, secret: secret, log: log}
+
+	if err := mux.HandlePath(http.MethodPost, "/v1/organizations", "CreateOrganization", h.create); err != nil {
+		return err
+	}
+
+	if err := mux.HandlePath(http.MethodGet, "/v1/organizations", "GetOrganizations", h.list); err != nil {
+		return err
+	}
+
+	if err := mux.HandlePath(http.MethodPost, "/v1/organizations/{organization_id}/members", "InviteOrganizationMember", h.invite); err != nil {
+		return err
+	}
+
+	if err := mux.HandlePath(http.MethodDelete, "/v1/organizations/{organization_id}/members/{user_id}", "RemoveOrganizationMember", h.remove); err != nil {
+		return err
+	}
+
+	return mux.HandlePath(http.MethodPost, "/v1/organizations/{organization_id}/token", "IssueOrganizationToken", h.token)
+}
+
+func (h *organizationHandler) create(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return
+	}
+
+	// Scoped token must not escape its organization
+	if actor.tenant != nil {
+		writeError(w, model.ErrTenantMismatch)
+		return
+	}
+
+	var req createOrganizationRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxOrganizationBodySize)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "body must be {\"name\": string}"})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxOrganizationName {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "name must be from 1 to 128 characters"})
+		return
+	}
+
+	created, err := h.manager.CreateOrganization(r.Context(), actor.id, name)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to create organization", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, organization{
+		ID:       created.ID,
+		Name:     created.Name,
+		Role:     string(generated.OrganizationRoleOwner),
+		JoinedAt: created.CreatedAt.Time,
+	})
+}
+
+func (h *organizationHandler) list(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return
+	}
+
+	organizations, err := h.manager.GetUserOrganizations(r.Context(), actor.id)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to get organizations", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	res := make([]organization, 0, len(organizations))
+	for _, o := range organizations {
+		if actor.tenant != nil && *actor.tenant != o.ID {
+			continue
+		}
+		res = append(res, organization{ID: o.ID, Name: o.Name, Role: string(o.Role), JoinedAt: o.CreatedAt.Time})
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]organization{"organizations": res})
+}
+
+func (h *organizationHandler) invite(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	actor, organizationID, ok := h.parseRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	var req inviteOrganizationMemberRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxOrganizationBodySize)).Decode(&req); err != nil || req.Username == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "body must be {\"username\": string, \"role\": string}"})
+		return
+	}
+
+	role := generated.OrganizationRole(req.Role)
+	if role != generated.OrganizationRoleAdmin && role != generated.OrganizationRoleMember {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "role must be admin or member"})
+		return
+	}
+
+	err := h.manager.InviteOrganizationMember(r.Context(), actor.id, organizationID, req.Username, role)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to invite organization member", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *organizationHandler) remove(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	actor, organizationID, ok := h.parseRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid user id, must be uuid"})
+		return
+	}
+
+	if err := h.manager.RemoveOrganizationMember(r.Context(), actor.id, organizationID, userID); err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to remove organization member", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *organizationHandler) token(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	actor, organizationID, ok := h.parseRequest(w, r, params)
+	if !ok {
+		return
+	}
+
+	token, err := h.manager.OrganizationToken(r.Context(), actor.id, organizationID)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to issue organization token", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"access_token": *token})
+}
+
+// parseRequest authenticates caller of organization route, token scoped to another
+// organization is rejected.
+func (h *organizationHandler) parseRequest(w http.ResponseWriter, r *http.Request, params map[string]string) (*caller, uuid.UUID, bool) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return nil, uuid.Nil, false
+	}
+
+	organizationID, err := uuid.Parse(params["organization_id"])
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid organization id, must be uuid"})
+		return nil, uuid.Nil, false
+	}
+
+	if actor.tenant != nil && *actor.tenant != organizationID {
+		writeError(w, model.ErrTenantMismatch)
+		return nil, uuid.Nil, false
+	}
+
+	return actor, organizationID, true
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/policy"
+)
+
+const maxExplainBodySize = 64 << 10
+
+type policyHandler struct {
+	engine *policy.Engine
+	secret string
+	log    *slog.Logger
+}
+
+type explainRequest struct {
+	Method  string          `json:"method"`
+	Request json.RawMessage `json:"request"`
+}
+
+type ruleResult struct {
+	Rule    string `json:"rule"`
+	Effect  string `json:"effect"`
+	Matched bool   `json:"matched"`
+	Error   string `json:"error,omitempty"`
+}
+
+type explainResponse struct {
+	Allowed bool         `json:"allowed"`
+	Rule    string       `json:"rule"`
+	Results []ruleResult `json:"results"`
+}
+
+// RegisterPolicy adds POST /v1/policies/explain to gateway for admins, it takes
+// {"method": "/user.UserService/GetAdmins", "request": {...}} and returns decision of policies
+// for the call made by caller with result of every rule, the call itself is not made. Routes
+// without RPC are named "/http/DeleteUser" and their request is object of path parameters.
+func RegisterPolicy(mux *Router, engine *policy.Engine, secret string, log *slog.Logger) error {
+	h := &policyHandler{engine: engine, secret: secret, log: log}
+
+	return mux.HandlePath(http.MethodPost, "/v1/policies/explain", "ExplainPolicy", h.explain)
+}
+
+func (h *policyHandler) explain(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return
+	}
+	if !actor.admin.Valid {
+		writeError(w, model.ErrUnauthorized)
+		return
+	}
+
+	var req explainRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxExplainBodySize)).Decode(&req); err != nil || req.Method == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "body must be {\"method\": string, \"request\": object}"})
+		return
+	}
+
+	msg, err := policy.NewRequest(req.Method, req.Request)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	decision, err := h.engine.Evaluate(policy.Input{
+		Method:  req.Method,
+		Request: msg,
+		Claims:  actor.claims,
+		User:    actor.attributes(),
+	})
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to evaluate policy", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	res := explainResponse{Allowed: decision.Allowed, Rule: decision.Rule, Results: make([]ruleResult, 0, len(decision.Results))}
+	for _, result := range decision.Results {
+		rr := ruleResult{Rule: result.Rule, Effect: result.Effect, Matched: result.Matched}
+		if result.Err != nil {
+			rr.Error = result.Err.Error()
+		}
+		res.Results = append(res.Results, rr)
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
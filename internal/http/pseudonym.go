@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+type PseudonymChecker interface {
+	CheckPseudonymAvailability(ctx context.Context, userID *uuid.UUID, pseudonym string) (*string, error)
+}
+
+type PseudonymSetter interface {
+	SetUserPseudonym(ctx context.Context, actorID, id uuid.UUID, pseudonym string, scale generated.NullAdminScale) (*generated.User, error)
+}
+
+// maxPseudonymBodySize bounds body of pseudonym override.
+const maxPseudonymBodySize = 1 << 10
+
+type pseudonymHandler struct {
+	checker PseudonymChecker
+	setter  PseudonymSetter
+	secret  string
+	log     *slog.Logger
+}
+
+type setPseudonymRequest struct {
+	Pseudonym string `json:"pseudonym"`
+}
+
+type pseudonymAvailability struct {
+	Pseudonym string `json:"pseudonym"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// RegisterPseudonym adds GET /v1/pseudonyms/{pseudonym}/availability to gateway,
+// mini app calls it while user types. Bearer token is optional, with it caller's
+// own pseudonym is reported as available. Protos are frozen, so availability is checked
+// only over HTTP, there is no RPC for it.
+func RegisterPseudonym(mux *Router, checker PseudonymChecker, secret string, log *slog.Logger) error {
+	h := &pseudonymHandler{checker: checker, secret: secret, log: log}
+
+	return mux.HandlePath(http.MethodGet, "/v1/pseudonyms/{pseudonym}/availability", "CheckPseudonymAvailability", h.checkAvailability)
+}
+
+func (h *pseudonymHandler) checkAvailability(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	var userID *uuid.UUID
+	if r.Header.Get("Authorization") != "" {
+		actor, err := authenticate(r, h.secret)
+		if err != nil {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+			return
+		}
+		userID = &actor.id
+	}
+
+	res := pseudonymAvailability{Pseudonym: params["pseudonym"]}
+	normalized, err := h.checker.CheckPseudonymAvailability(r.Context(), userID, params["pseudonym"])
+	switch {
+	case err == nil:
+		res.Pseudonym = *normalized
+		res.Available = true
+	case errors.Is(err, model.ErrPseudonymTaken),
+		errors.Is(err, model.ErrPseudonymNotAllowed),
+		errors.Is(err, model.ErrEmptyPseudonym):
+		res.Reason = err.Error()
+	default:
+		sl.FromContext(r.Context(), h.log).Error("failed to check pseudonym availability", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// RegisterSetPseudonym adds PUT /v1/users/{user_id}/pseudonym to gateway. Admins override
+// pseudonym of any user, e.g. to resolve impersonation, cooldown does not apply to them.
+func RegisterSetPseudonym(mux *Router, setter PseudonymSetter, secret string, log *slog.Logger) error {
+	h := &pseudonymHandler{setter: setter, secret: secret, log: log}
+
+	return mux.HandlePath(http.MethodPut, "/v1/users/{user_id}/pseudonym", "SetUserPseudonym", h.set)
+}
+
+func (h *pseudonymHandler) set(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return
+	}
+
+	if !actor.admin.Valid {
+		writeError(w, model.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid user id, must be uuid"})
+		return
+	}
+
+	var req setPseudonymRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPseudonymBodySize)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "body must be {\"pseudonym\": string}"})
+		return
+	}
+
+	user, err := h.setter.SetUserPseudonym(r.Context(), actor.id, id, req.Pseudonym, actor.admin)
+	if err != nil {
+		if errors.Is(err, model.ErrPseudonymTaken) || errors.Is(err, model.ErrPseudonymNotAllowed) ||
+			errors.Is(err, model.ErrEmptyPseudonym) {
+			sl.FromContext(r.Context(), h.log).Debug("pseudonym not set", sl.Err(err))
+		} else {
+			sl.FromContext(r.Context(), h.log).Error("failed to set pseudonym", sl.Err(err))
+		}
+		writeError(w, err)
+		return
+	}
+
+	writeProto(w, http.StatusOK, model.ToUser(*user))
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/clientip"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/metrics"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/policy"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Router adds routes without RPC to gateway. They do not pass gRPC interceptors, so router does
+// their work for every route: records metrics, stores client for audit log and evaluates policies.
+// Route is known to metrics and policies by stable name policy.HTTPMethodPrefix + name.
+type Router struct {
+	mux            *runtime.ServeMux
+	engine         *policy.Engine
+	dryRun         bool
+	secret         string
+	trustedProxies []netip.Prefix
+	log            *slog.Logger
+}
+
+func NewRouter(mux *runtime.ServeMux, engine *policy.Engine, dryRun bool, secret string, trustedProxies []netip.Prefix, log *slog.Logger) *Router {
+	return &Router{
+		mux:            mux,
+		engine:         engine,
+		dryRun:         dryRun,
+		secret:         secret,
+		trustedProxies: trustedProxies,
+		log:            log,
+	}
+}
+
+// HandlePath adds route with handler h, name must be unique.
+func (rt *Router) HandlePath(meth, pathPattern, name string, h runtime.HandlerFunc) error {
+	method := policy.HTTPMethodPrefix + name
+
+	return rt.mux.HandlePath(meth, pathPattern, func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		defer func() {
+			metrics.ObserveHTTP(method, start, rec.code)
+		}()
+
+		r = r.WithContext(model.ContextWithClient(r.Context(), rt.client(r)))
+
+		if !rt.allowed(rec, r, method, params) {
+			return
+		}
+
+		h(rec, r, params)
+	})
+}
+
+// client returns caller of request, X-Forwarded-For is read only from trusted proxies.
+func (rt *Router) client(r *http.Request) model.Client {
+	client := model.Client{IP: r.RemoteAddr, UserAgent: r.UserAgent()}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		client.IP = host
+	}
+
+	if clientip.IsTrusted(client.IP, rt.trustedProxies) {
+		if ip, ok := clientip.FromForwarded(r.Header.Values("X-Forwarded-For"), rt.trustedProxies); ok {
+			client.IP = ip
+		}
+	}
+
+	return client
+}
+
+// allowed evaluates policies of route against its path parameters and caller of bearer token,
+// request without valid token is anonymous. It writes error if call is denied. In dry run mode
+// decisions are only logged.
+func (rt *Router) allowed(w http.ResponseWriter, r *http.Request, method string, params map[string]string) bool {
+	request := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(params))}
+	for key, value := range params {
+		request.Fields[key] = structpb.NewStringValue(value)
+	}
+
+	input := policy.Input{
+		Method:  method,
+		Request: request,
+		User:    (&caller{}).attributes(),
+	}
+	if actor, err := authenticate(r, rt.secret); err == nil {
+		input.Claims = actor.claims
+		input.User = actor.attributes()
+	}
+
+	decision, err := rt.engine.Evaluate(input)
+	if err != nil {
+		sl.FromContext(r.Context(), rt.log).Error("failed to evaluate policy", sl.Err(err), slog.String("method", method))
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"message": "failed to evaluate policy"})
+		return false
+	}
+
+	if !decision.Allowed {
+		if rt.dryRun {
+			sl.FromContext(r.Context(), rt.log).Info("policy would deny call", slog.String("method", method), slog.String("rule", decision.Rule))
+			return true
+		}
+
+		writeJSON(w, http.StatusForbidden, map[string]string{"message": fmt.Sprintf("%s: denied by policy %s", model.ErrUnauthorized, decision.Rule)})
+		return false
+	}
+
+	return true
+}
+
+// statusRecorder keeps status code of response for metrics, Unwrap lets response controller flush
+// streamed responses.
+type statusRecorder struct {
+	http.ResponseWriter
+	code        int
+	wroteHeader bool
+}
+
+func (rec *statusRecorder) WriteHeader(code int) {
+	if !rec.wroteHeader {
+		rec.code = code
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
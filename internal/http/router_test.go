@@ -0,0 +1,104 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/policy"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter(t *testing.T, rules ...policy.Rule) (*runtime.ServeMux, *Router) {
+	t.Helper()
+
+	engine, err := policy.New(rules)
+	require.NoError(t, err)
+
+	mux := runtime.NewServeMux()
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	return mux, NewRouter(mux, engine, false, testSecret, trusted, slogdiscard.NewDiscardLogger())
+}
+
+func TestRouter_Policy(t *testing.T) {
+	t.Parallel()
+
+	mux, router := newTestRouter(t, policy.Rule{
+		Name:       "admins-only",
+		Methods:    []string{policy.HTTPMethodPrefix + "GetThing"},
+		Effect:     policy.EffectAllow,
+		Expression: `user.admin != "" && request.id == "1"`,
+	})
+	require.NoError(t, router.HandlePath(http.MethodGet, "/v1/things/{id}", "GetThing", func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name  string
+		path  string
+		token bool
+		want  int
+	}{
+		{name: "admin", path: "/v1/things/1", token: true, want: http.StatusNoContent},
+		{name: "other path parameter", path: "/v1/things/2", token: true, want: http.StatusForbidden},
+		{name: "anonymous", path: "/v1/things/1", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token {
+				req.Header.Set("Authorization", "Bearer "+adminToken(t))
+			}
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestRouter_Client(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded string
+		want      string
+	}{
+		{name: "direct caller", remote: "203.0.113.7:50000", want: "203.0.113.7"},
+		{name: "direct caller spoofs forwarded", remote: "203.0.113.7:50000", forwarded: "198.51.100.1", want: "203.0.113.7"},
+		{name: "trusted proxy", remote: "10.1.2.3:50000", forwarded: "192.0.2.1, 198.51.100.1, 10.4.5.6", want: "198.51.100.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mux, router := newTestRouter(t)
+			var got model.Client
+			require.NoError(t, router.HandlePath(http.MethodGet, "/v1/things", "GetThings", func(_ http.ResponseWriter, r *http.Request, _ map[string]string) {
+				got = model.ClientFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/things", nil)
+			req.RemoteAddr = tt.remote
+			req.Header.Set("User-Agent", "test")
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+
+			mux.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, model.Client{IP: tt.want, UserAgent: "test"}, got)
+		})
+	}
+}
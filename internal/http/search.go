@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type UserSearcher interface {
+	GetUsers(ctx context.Context, params model.GetUsersParams) (users []generated.User, page *model.Page, err error)
+}
+
+type searchHandler struct {
+	searcher UserSearcher
+	log      *slog.Logger
+}
+
+// RegisterSearch adds GET /v1/users/search?query=metr&limit=20&offset=0 to gateway,
+// users are ranked by relevance and response has the same shape as GET /v1/users. Protos are frozen,
+// so there is no search field in GetUsersRequest, gRPC clients pass the query in x-search-query metadata.
+func RegisterSearch(mux *Router, searcher UserSearcher, log *slog.Logger) error {
+	h := &searchHandler{searcher: searcher, log: log}
+
+	return mux.HandlePath(http.MethodGet, "/v1/users/search", "SearchUsers", h.search)
+}
+
+func (h *searchHandler) search(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	values := r.URL.Query()
+
+	query := strings.TrimSpace(values.Get("query"))
+	if query == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "query is required"})
+		return
+	}
+
+	limit, err := parseUint(values.Get("limit"), defaultSearchLimit)
+	if err != nil || limit == 0 || limit > maxSearchLimit {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "limit must be from 1 to 100"})
+		return
+	}
+
+	offset, err := parseUint(values.Get("offset"), 0)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "offset must be non-negative integer"})
+		return
+	}
+
+	params := &model.GetUsersParams{Query: &query, Limit: limit, Offset: offset}
+	users, page, err := h.searcher.GetUsers(r.Context(), *params)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to search users", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	writeProto(w, http.StatusOK, model.ToGetUsersResponse(users, page, params))
+}
+
+func parseUint(value string, def uint64) (uint64, error) {
+	if value == "" {
+		return def, nil
+	}
+
+	return strconv.ParseUint(value, 10, 64)
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+type UserDeleter interface {
+	DeleteUser(ctx context.Context, actorID, id uuid.UUID, scale generated.NullAdminScale) error
+}
+
+type TelegramProfileProvider interface {
+	GetTelegramProfile(ctx context.Context, actorID, id uuid.UUID, scale generated.NullAdminScale) (*model.TelegramProfile, error)
+}
+
+type userHandler struct {
+	deleter  UserDeleter
+	profiles TelegramProfileProvider
+	secret   string
+	log      *slog.Logger
+}
+
+// RegisterDeleteUser adds DELETE /v1/users/{user_id} to gateway. Users delete own account,
+// admins delete accounts of others. Account is restored when user logs in within grace period.
+// Deleting minor admin with admin approval enabled requests removal of admin role and responds
+// with 202, account is deleted by repeating the call once request is approved.
+func RegisterDeleteUser(mux *Router, deleter UserDeleter, secret string, log *slog.Logger) error {
+	h := &userHandler{deleter: deleter, secret: secret, log: log}
+
+	return mux.HandlePath(http.MethodDelete, "/v1/users/{user_id}", "DeleteUser", h.delete)
+}
+
+func (h *userHandler) delete(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return
+	}
+
+	id, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid user id, must be uuid"})
+		return
+	}
+
+	if err := h.deleter.DeleteUser(r.Context(), actor.id, id, actor.admin); err != nil {
+		if errors.Is(err, model.ErrAdminChangePending) {
+			writeJSON(w, http.StatusAccepted, map[string]string{"message": err.Error()})
+			return
+		}
+		sl.FromContext(r.Context(), h.log).Error("failed to delete user", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterTelegramProfile adds GET /v1/users/{user_id}/telegram to gateway. It returns fields
+// stored from Telegram init data which User message has no fields for:
+// {"user_id", "telegram_id", "language_code", "is_premium", "photo_url", "allows_write_to_pm"},
+// missing optional values are null. Users read own profile, admins read any profile.
+func RegisterTelegramProfile(mux *Router, profiles TelegramProfileProvider, secret string, log *slog.Logger) error {
+	h := &userHandler{profiles: profiles, secret: secret, log: log}
+
+	return mux.HandlePath(http.MethodGet, "/v1/users/{user_id}/telegram", "GetTelegramProfile", h.telegramProfile)
+}
+
+func (h *userHandler) telegramProfile(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return
+	}
+
+	id, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid user id, must be uuid"})
+		return
+	}
+
+	profile, err := h.profiles.GetTelegramProfile(r.Context(), actor.id, id, actor.admin)
+	if err != nil {
+		sl.FromContext(r.Context(), h.log).Error("failed to get telegram profile", sl.Err(err))
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, profile)
+}
@@ -0,0 +1,258 @@
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+// usersExportFlushSize is number of rows written between flushes of response.
+const usersExportFlushSize = 500
+
+type UsersExporter interface {
+	ExportUsers(ctx context.Context, params model.GetUsersParams, fn func(generated.User) error) error
+}
+
+type usersExportHandler struct {
+	exporter UsersExporter
+	secret   string
+	log      *slog.Logger
+}
+
+type exportedUser struct {
+	ID           uuid.UUID  `json:"id"`
+	Username     string     `json:"username"`
+	Pseudonym    string     `json:"pseudonym"`
+	FirstName    string     `json:"first_name"`
+	LastName     string     `json:"last_name"`
+	LanguageCode *string    `json:"language_code"`
+	IsPremium    bool       `json:"is_premium"`
+	IsDeleted    bool       `json:"is_deleted"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+}
+
+var exportedUserHeader = []string{
+	"id", "username", "pseudonym", "first_name", "last_name", "language_code",
+	"is_premium", "is_deleted", "created_at", "updated_at", "deleted_at",
+}
+
+func toExportedUser(user generated.User) exportedUser {
+	res := exportedUser{
+		ID:           user.ID,
+		Username:     user.Username,
+		Pseudonym:    user.Pseudonym,
+		FirstName:    user.FirstName,
+		LastName:     user.LastName,
+		LanguageCode: user.LanguageCode,
+		IsPremium:    user.IsPremium,
+		IsDeleted:    user.IsDeleted,
+		CreatedAt:    user.CreatedAt.Time,
+		UpdatedAt:    user.UpdatedAt.Time,
+	}
+	if user.DeletedAt.Valid {
+		res.DeletedAt = &user.DeletedAt.Time
+	}
+
+	return res
+}
+
+func (u exportedUser) record() []string {
+	var languageCode, deletedAt string
+	if u.LanguageCode != nil {
+		languageCode = *u.LanguageCode
+	}
+	if u.DeletedAt != nil {
+		deletedAt = u.DeletedAt.Format(time.RFC3339Nano)
+	}
+
+	return []string{
+		u.ID.String(), u.Username, u.Pseudonym, u.FirstName, u.LastName, languageCode,
+		strconv.FormatBool(u.IsPremium), strconv.FormatBool(u.IsDeleted),
+		u.CreatedAt.Format(time.RFC3339Nano), u.UpdatedAt.Format(time.RFC3339Nano), deletedAt,
+	}
+}
+
+// usersExportMaxLengths limits filter fields by the same rules as GetUsersRequest.
+var usersExportMaxLengths = map[string]int{
+	"username":   30,
+	"pseudonym":  30,
+	"first_name": 50,
+	"last_name":  50,
+}
+
+// includeDeletedHeader set to "true" exports deleted users too, the same as in GET /v1/users.
+const includeDeletedHeader = "X-Include-Deleted"
+
+// RegisterUsersExport adds GET /v1/users/export?format=ndjson|csv to gateway for admins,
+// it takes the same filters as GET /v1/users plus filter expression and sort like
+// "created_at desc, username", and streams every matching user from one snapshot.
+// Protos are frozen and have no streaming RPCs, so export is served over HTTP only.
+func RegisterUsersExport(mux *Router, exporter UsersExporter, secret string, log *slog.Logger) error {
+	h := &usersExportHandler{exporter: exporter, secret: secret, log: log}
+
+	return mux.HandlePath(http.MethodGet, "/v1/users/export", "ExportUsers", h.export)
+}
+
+func (h *usersExportHandler) export(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	actor, err := authenticate(r, h.secret)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": err.Error()})
+		return
+	}
+	if !actor.admin.Valid {
+		writeError(w, model.ErrUnauthorized)
+		return
+	}
+
+	values := r.URL.Query()
+	format := values.Get("format")
+	if format == "" {
+		format = "ndjson"
+	}
+	if format != "ndjson" && format != "csv" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "format must be ndjson or csv"})
+		return
+	}
+
+	params := model.GetUsersParams{IncludeDeleted: strings.EqualFold(r.Header.Get(includeDeletedHeader), "true")}
+	for name, field := range map[string]**string{
+		"user_id":    &params.UserID,
+		"username":   &params.Username,
+		"pseudonym":  &params.Pseudonym,
+		"first_name": &params.FirstName,
+		"last_name":  &params.LastName,
+		"filter":     &params.Filter,
+	} {
+		value := values.Get(name)
+		if value == "" {
+			continue
+		}
+		if limit, ok := usersExportMaxLengths[name]; ok && utf8.RuneCountInString(value) > limit {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": fmt.Sprintf("%s must be at most %d characters", name, limit)})
+			return
+		}
+		*field = &value
+	}
+	if params.UserID != nil {
+		if _, err := uuid.Parse(*params.UserID); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": "user_id must be uuid"})
+			return
+		}
+	}
+	if spec := values.Get("sort"); spec != "" {
+		if params.Sort, err = model.ParseSort(spec, model.UserSortFields); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+	}
+
+	// headers are sent with the first row, so errors found before it still get status code
+	rw := newUserRowWriter(w, format)
+	started, rows := false, 0
+	err = h.exporter.ExportUsers(r.Context(), params, func(user generated.User) error {
+		if !started {
+			started = true
+			if err := rw.start(); err != nil {
+				return err
+			}
+		}
+		if err := rw.write(user); err != nil {
+			return err
+		}
+		if rows++; rows%usersExportFlushSize == 0 {
+			return rw.flush()
+		}
+		return nil
+	})
+	if err == nil && !started {
+		started = true
+		err = rw.start()
+	}
+	if err == nil {
+		err = rw.flush()
+	}
+	if err == nil {
+		return
+	}
+
+	if r.Context().Err() != nil {
+		// client went away, there is nobody to report error to
+		sl.FromContext(r.Context(), h.log).Debug("users export canceled", sl.Err(err), slog.Int("rows", rows))
+		return
+	}
+
+	switch {
+	case started:
+		// response is already partly sent, aborting it lets client see truncated download
+		sl.FromContext(r.Context(), h.log).Error("failed to export users", sl.Err(err), slog.Int("rows", rows))
+		panic(http.ErrAbortHandler)
+	case errors.Is(err, model.ErrOrderByInvalidField), errors.Is(err, model.ErrInvalidFilter):
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+	default:
+		sl.FromContext(r.Context(), h.log).Error("failed to export users", sl.Err(err))
+		writeError(w, err)
+	}
+}
+
+// userRowWriter writes exported users as NDJSON or CSV.
+type userRowWriter struct {
+	w      http.ResponseWriter
+	rc     *http.ResponseController
+	format string
+	csv    *csv.Writer
+	json   *json.Encoder
+}
+
+func newUserRowWriter(w http.ResponseWriter, format string) *userRowWriter {
+	return &userRowWriter{w: w, rc: http.NewResponseController(w), format: format, csv: csv.NewWriter(w), json: json.NewEncoder(w)}
+}
+
+func (rw *userRowWriter) start() error {
+	contentType := "application/x-ndjson"
+	if rw.format == "csv" {
+		contentType = "text/csv"
+	}
+
+	rw.w.Header().Set("Content-Type", contentType)
+	rw.w.Header().Set("Content-Disposition", `attachment; filename="users.`+rw.format+`"`)
+	rw.w.WriteHeader(http.StatusOK)
+
+	if rw.format == "csv" {
+		return rw.csv.Write(exportedUserHeader)
+	}
+	return nil
+}
+
+func (rw *userRowWriter) write(user generated.User) error {
+	if rw.format == "csv" {
+		return rw.csv.Write(toExportedUser(user).record())
+	}
+	return rw.json.Encode(toExportedUser(user))
+}
+
+func (rw *userRowWriter) flush() error {
+	rw.csv.Flush()
+	if err := rw.csv.Error(); err != nil {
+		return err
+	}
+
+	if err := rw.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
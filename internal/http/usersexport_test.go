@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger/slogdiscard"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "secret"
+
+type usersExporterFunc func(ctx context.Context, params model.GetUsersParams, fn func(generated.User) error) error
+
+func (f usersExporterFunc) ExportUsers(ctx context.Context, params model.GetUsersParams, fn func(generated.User) error) error {
+	return f(ctx, params, fn)
+}
+
+func adminToken(t *testing.T) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":    uuid.NewString(),
+		"admin": string(generated.AdminScaleMinor),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	return token
+}
+
+func serveUsersExport(t *testing.T, exporter UsersExporter, vals url.Values) *httptest.ResponseRecorder {
+	t.Helper()
+
+	mux, router := newTestRouter(t)
+	require.NoError(t, RegisterUsersExport(router, exporter, testSecret, slogdiscard.NewDiscardLogger()))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/export?"+vals.Encode(), nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken(t))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUsersExport_Success(t *testing.T) {
+	t.Parallel()
+
+	var got model.GetUsersParams
+	exporter := usersExporterFunc(func(_ context.Context, params model.GetUsersParams, fn func(generated.User) error) error {
+		got = params
+		return fn(generated.User{ID: uuid.New(), Username: "qwerty"})
+	})
+
+	rec := serveUsersExport(t, exporter, url.Values{"username": {"qwerty"}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"username":"qwerty"`)
+	require.NotNil(t, got.Username)
+	assert.Equal(t, "qwerty", *got.Username)
+}
+
+func TestUsersExport_FailValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		vals url.Values
+	}{
+		{name: "invalid format", vals: url.Values{"format": {"xml"}}},
+		{name: "invalid user id", vals: url.Values{"user_id": {"qwerty"}}},
+		{name: "long username", vals: url.Values{"username": {strings.Repeat("a", 31)}}},
+		{name: "long pseudonym", vals: url.Values{"pseudonym": {strings.Repeat("я", 31)}}},
+		{name: "long first name", vals: url.Values{"first_name": {strings.Repeat("a", 51)}}},
+		{name: "long last name", vals: url.Values{"last_name": {strings.Repeat("a", 51)}}},
+		{name: "invalid sort", vals: url.Values{"sort": {"password"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			exporter := usersExporterFunc(func(context.Context, model.GetUsersParams, func(generated.User) error) error {
+				t.Error("exporter must not be called")
+				return nil
+			})
+
+			rec := serveUsersExport(t, exporter, tt.vals)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
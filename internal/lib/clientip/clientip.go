@@ -0,0 +1,62 @@
+package clientip
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// ParsePrefixes parses addresses and CIDRs of trusted proxies, address is a single host prefix.
+func ParsePrefixes(proxies []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, proxy := range proxies {
+		if addr, err := netip.ParseAddr(proxy); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+// IsTrusted reports whether addr is in one of trustedProxies.
+func IsTrusted(addr string, trustedProxies []netip.Prefix) bool {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(ip.Unmap()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FromForwarded returns caller of request passed through proxies. Values of x-forwarded-for are read
+// from the last address, trusted proxies are skipped and the first untrusted address is the caller.
+// Caller must check that request came from proxy which sets x-forwarded-for, any other client could
+// set it to anything. It returns false if there are no addresses.
+func FromForwarded(forwarded []string, trustedProxies []netip.Prefix) (string, bool) {
+	var addrs []string
+	for _, value := range forwarded {
+		addrs = append(addrs, strings.Split(value, ",")...)
+	}
+
+	var ip string
+	for i := len(addrs) - 1; i >= 0; i-- {
+		ip = strings.TrimSpace(addrs[i])
+		if !IsTrusted(ip, trustedProxies) {
+			break
+		}
+	}
+
+	return ip, ip != ""
+}
@@ -0,0 +1,363 @@
+// Package filter parses subset of AIP-160 filter syntax, for example
+//
+//	created_at >= "2024-01-01T00:00:00Z" AND (is_admin = true OR NOT username = "bob")
+//	id IN ("a", "b") is_deleted = false
+//
+// Restrictions compare field with value using = != < <= > >= or with list of values using IN,
+// they are combined with AND, OR, NOT and parentheses, whitespace between restrictions means AND.
+// AND binds tighter than OR. Values are double quoted strings or bare words.
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var ErrSyntax = errors.New("invalid filter")
+
+const (
+	// MaxLength limits filter length in bytes.
+	MaxLength = 2048
+	// MaxRestrictions limits number of restrictions in filter.
+	MaxRestrictions = 32
+	// MaxValues limits number of values in IN list.
+	MaxValues = 100
+	// maxDepth limits nesting of parentheses and negations.
+	maxDepth = 8
+)
+
+type Op string
+
+const (
+	OpEq Op = "="
+	OpNe Op = "!="
+	OpLt Op = "<"
+	OpLe Op = "<="
+	OpGt Op = ">"
+	OpGe Op = ">="
+	OpIn Op = "IN"
+)
+
+// Expr is node of parsed filter: And, Or, Not or Restriction.
+type Expr interface {
+	expr()
+}
+
+type And []Expr
+
+type Or []Expr
+
+type Not struct {
+	Expr Expr
+}
+
+// Restriction compares Field with Values, only OpIn may have more than one value.
+type Restriction struct {
+	Field  string
+	Op     Op
+	Values []string
+}
+
+func (And) expr()         {}
+func (Or) expr()          {}
+func (Not) expr()         {}
+func (Restriction) expr() {}
+
+// Parse parses filter, empty filter returns nil expression.
+func Parse(filter string) (Expr, error) {
+	if len(filter) > MaxLength {
+		return nil, fmt.Errorf("%w: longer than %d bytes", ErrSyntax, MaxLength)
+	}
+
+	tokens, err := lex(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.unexpected(tok)
+	}
+
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func lex(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, value: ",", pos: i})
+			i++
+		case c == '=':
+			tokens = append(tokens, token{kind: tokenOp, value: "=", pos: i})
+			i++
+		case c == '!' || c == '<' || c == '>':
+			op := string(c)
+			if i+1 < len(s) && s[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("%w: unexpected %q at %d", ErrSyntax, c, i)
+			}
+			tokens = append(tokens, token{kind: tokenOp, value: op, pos: i})
+			i += len(op)
+		case c == '"':
+			value, n, err := lexString(s[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%w at %d", err, i)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: i})
+			i += n
+		default:
+			start := i
+			for i < len(s) && isWordByte(s[i]) {
+				i++
+			}
+			if start == i {
+				return nil, fmt.Errorf("%w: unexpected %q at %d", ErrSyntax, c, i)
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: s[start:i], pos: start})
+		}
+	}
+
+	return tokens, nil
+}
+
+func isWordByte(c byte) bool {
+	return c >= 0x80 || c == '_' || c == '.' || c == '-' || c == '+' || c == ':' ||
+		unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+// lexString reads double quoted string supporting \" and \\ escapes, it returns
+// unquoted value and number of bytes consumed.
+func lexString(s string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 == len(s) || (s[i+1] != '"' && s[i+1] != '\\') {
+				return "", 0, fmt.Errorf("%w: invalid escape", ErrSyntax)
+			}
+			i++
+			b.WriteByte(s[i])
+		case '"':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: unterminated string", ErrSyntax)
+}
+
+type parser struct {
+	tokens       []token
+	pos          int
+	restrictions int
+}
+
+func (p *parser) peek() token {
+	if p.pos == len(p.tokens) {
+		return token{kind: tokenEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.peek()
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) unexpected(tok token) error {
+	if tok.kind == tokenEOF {
+		return fmt.Errorf("%w: unexpected end", ErrSyntax)
+	}
+	return fmt.Errorf("%w: unexpected %q at %d", ErrSyntax, tok.value, tok.pos)
+}
+
+func isKeyword(tok token, keyword string) bool {
+	return tok.kind == tokenWord && tok.value == keyword
+}
+
+func (p *parser) parseOr(depth int) (Expr, error) {
+	expr, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	or := Or{expr}
+	for isKeyword(p.peek(), "OR") {
+		p.next()
+		expr, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, expr)
+	}
+
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *parser) parseAnd(depth int) (Expr, error) {
+	expr, err := p.parseTerm(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	and := And{expr}
+	for {
+		tok := p.peek()
+		if isKeyword(tok, "AND") {
+			p.next()
+		} else if tok.kind == tokenEOF || tok.kind == tokenRParen || isKeyword(tok, "OR") {
+			break
+		}
+
+		expr, err := p.parseTerm(depth)
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, expr)
+	}
+
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *parser) parseTerm(depth int) (Expr, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: nested deeper than %d", ErrSyntax, maxDepth)
+	}
+
+	tok := p.peek()
+	switch {
+	case isKeyword(tok, "NOT"):
+		p.next()
+		expr, err := p.parseTerm(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	case tok.kind == tokenLParen:
+		p.next()
+		expr, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, p.unexpected(tok)
+		}
+		return expr, nil
+	default:
+		return p.parseRestriction()
+	}
+}
+
+func (p *parser) parseRestriction() (Expr, error) {
+	field := p.next()
+	if field.kind != tokenWord || isKeyword(field, "AND") || isKeyword(field, "OR") || isKeyword(field, "IN") {
+		return nil, p.unexpected(field)
+	}
+
+	p.restrictions++
+	if p.restrictions > MaxRestrictions {
+		return nil, fmt.Errorf("%w: more than %d restrictions", ErrSyntax, MaxRestrictions)
+	}
+
+	op := p.next()
+	switch {
+	case op.kind == tokenOp:
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return Restriction{Field: field.value, Op: Op(op.value), Values: []string{value}}, nil
+	case isKeyword(op, "IN"):
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return Restriction{Field: field.value, Op: OpIn, Values: values}, nil
+	default:
+		return nil, p.unexpected(op)
+	}
+}
+
+func (p *parser) parseValue() (string, error) {
+	tok := p.next()
+	if tok.kind != tokenString && tok.kind != tokenWord {
+		return "", p.unexpected(tok)
+	}
+	return tok.value, nil
+}
+
+func (p *parser) parseList() ([]string, error) {
+	if tok := p.next(); tok.kind != tokenLParen {
+		return nil, p.unexpected(tok)
+	}
+
+	var values []string
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if len(values) > MaxValues {
+			return nil, fmt.Errorf("%w: more than %d values in list", ErrSyntax, MaxValues)
+		}
+
+		switch tok := p.next(); tok.kind {
+		case tokenComma:
+		case tokenRParen:
+			return values, nil
+		default:
+			return nil, p.unexpected(tok)
+		}
+	}
+}
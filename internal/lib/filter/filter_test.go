@@ -0,0 +1,112 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter string
+		want   Expr
+	}{
+		{name: "empty", filter: "  "},
+		{
+			name:   "comparison",
+			filter: `created_at >= "2024-01-01T00:00:00Z"`,
+			want:   Restriction{Field: "created_at", Op: OpGe, Values: []string{"2024-01-01T00:00:00Z"}},
+		},
+		{
+			name:   "bare value",
+			filter: `is_admin=true`,
+			want:   Restriction{Field: "is_admin", Op: OpEq, Values: []string{"true"}},
+		},
+		{
+			name:   "in list",
+			filter: `username IN ("bob", alice)`,
+			want:   Restriction{Field: "username", Op: OpIn, Values: []string{"bob", "alice"}},
+		},
+		{
+			name:   "escaped string",
+			filter: `username != "a \"b\" \\"`,
+			want:   Restriction{Field: "username", Op: OpNe, Values: []string{`a "b" \`}},
+		},
+		{
+			name:   "implicit and",
+			filter: `is_deleted = false is_admin = true`,
+			want: And{
+				Restriction{Field: "is_deleted", Op: OpEq, Values: []string{"false"}},
+				Restriction{Field: "is_admin", Op: OpEq, Values: []string{"true"}},
+			},
+		},
+		{
+			name:   "and binds tighter than or",
+			filter: `a = 1 OR b = 2 AND c = 3`,
+			want: Or{
+				Restriction{Field: "a", Op: OpEq, Values: []string{"1"}},
+				And{
+					Restriction{Field: "b", Op: OpEq, Values: []string{"2"}},
+					Restriction{Field: "c", Op: OpEq, Values: []string{"3"}},
+				},
+			},
+		},
+		{
+			name:   "parentheses and not",
+			filter: `NOT (a < 1 OR a > 5) AND b <= 2`,
+			want: And{
+				Not{Expr: Or{
+					Restriction{Field: "a", Op: OpLt, Values: []string{"1"}},
+					Restriction{Field: "a", Op: OpGt, Values: []string{"5"}},
+				}},
+				Restriction{Field: "b", Op: OpLe, Values: []string{"2"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter string
+	}{
+		{name: "missing value", filter: `username =`},
+		{name: "missing operator", filter: `username "bob"`},
+		{name: "unknown operator", filter: `username ! "bob"`},
+		{name: "unterminated string", filter: `username = "bob`},
+		{name: "invalid escape", filter: `username = "\n"`},
+		{name: "unbalanced parentheses", filter: `(a = 1`},
+		{name: "extra parenthesis", filter: `a = 1)`},
+		{name: "dangling and", filter: `a = 1 AND`},
+		{name: "empty list", filter: `a IN ()`},
+		{name: "list without parentheses", filter: `a IN 1`},
+		{name: "keyword as field", filter: `OR = 1`},
+		{name: "unexpected character", filter: `a = 1; drop table users`},
+		{name: "too deep", filter: strings.Repeat("(", maxDepth+2) + "a = 1" + strings.Repeat(")", maxDepth+2)},
+		{name: "too many restrictions", filter: strings.Repeat("a = 1 ", MaxRestrictions+1)},
+		{name: "too many values", filter: "a IN (" + strings.Repeat("1, ", MaxValues) + "1)"},
+		{name: "too long", filter: "a = " + strings.Repeat("1", MaxLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.filter)
+			assert.ErrorIs(t, err, ErrSyntax)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package health checks readiness of the service by pinging its dependencies and
+// reports it to grpc.health.v1 clients and HTTP probes.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var (
+	ErrNotReady     = errors.New("service is not ready")
+	ErrShuttingDown = errors.New("service is shutting down")
+)
+
+// Check pings dependency, it must return once context is done.
+type Check func(ctx context.Context) error
+
+// Checker runs checks of dependencies with timeout. Status of grpc.health.v1 server and Ready are
+// updated by Run every interval and turn NOT_SERVING for good on Shutdown.
+type Checker struct {
+	checks   map[string]Check
+	timeout  time.Duration
+	interval time.Duration
+	server   *health.Server
+	ready    atomic.Bool
+	shutdown atomic.Bool
+	done     chan struct{}
+	log      *slog.Logger
+}
+
+func New(checks map[string]Check, timeout, interval time.Duration, log *slog.Logger) *Checker {
+	server := health.NewServer()
+	// Service is not ready until dependencies are checked
+	server.SetServingStatus("", healthv1.HealthCheckResponse_NOT_SERVING)
+
+	return &Checker{
+		checks:   checks,
+		timeout:  timeout,
+		interval: interval,
+		server:   server,
+		done:     make(chan struct{}),
+		log:      log,
+	}
+}
+
+// Server returns grpc.health.v1 server, empty service name reports status of whole service.
+func (c *Checker) Server() healthv1.HealthServer {
+	return c.server
+}
+
+// Check runs all checks concurrently and returns errors of failed ones by name.
+func (c *Checker) Check(ctx context.Context) map[string]error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		failed = make(map[string]error)
+	)
+	for name, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := check(ctx); err != nil {
+				mu.Lock()
+				failed[name] = err
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return failed
+}
+
+// Ready returns error when service should not receive traffic. It reports status of the last check
+// made by Run and does not ping dependencies, so probes can not overload them.
+func (c *Checker) Ready() error {
+	if c.shutdown.Load() {
+		return ErrShuttingDown
+	}
+	if !c.ready.Load() {
+		return ErrNotReady
+	}
+
+	return nil
+}
+
+func (c *Checker) MustRun(ctx context.Context) {
+	if err := c.Run(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Run updates status of grpc.health.v1 server until Shutdown.
+func (c *Checker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.update(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-c.done:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *Checker) update(ctx context.Context) {
+	var errs []error
+	for name, err := range c.Check(ctx) {
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+	}
+
+	status := healthv1.HealthCheckResponse_SERVING
+	if err := errors.Join(errs...); err != nil {
+		status = healthv1.HealthCheckResponse_NOT_SERVING
+		c.log.Warn("service is not ready", sl.Err(err))
+	}
+	c.ready.Store(len(errs) == 0)
+	c.server.SetServingStatus("", status)
+}
+
+// Shutdown reports NOT_SERVING to all clients and probes, so load balancers drain
+// the service before servers are stopped.
+func (c *Checker) Shutdown() {
+	if c.shutdown.Swap(true) {
+		return
+	}
+
+	c.log.Info("service is shutting down, health status is not serving")
+
+	close(c.done)
+	c.server.Shutdown()
+}
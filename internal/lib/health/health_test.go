@@ -0,0 +1,90 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger/slogdiscard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func status(t *testing.T, c *Checker) healthv1.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	res, err := c.Server().Check(context.Background(), &healthv1.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	return res.GetStatus()
+}
+
+func TestChecker_Ready(t *testing.T) {
+	t.Parallel()
+
+	var pings atomic.Int32
+	c := New(map[string]Check{
+		"postgres": func(context.Context) error {
+			pings.Add(1)
+			return nil
+		},
+	}, time.Second, time.Hour, slogdiscard.NewDiscardLogger())
+
+	assert.ErrorIs(t, c.Ready(), ErrNotReady)
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, c))
+
+	c.update(context.Background())
+	assert.NoError(t, c.Ready())
+	assert.Equal(t, healthv1.HealthCheckResponse_SERVING, status(t, c))
+
+	// readiness is cached, probes do not ping dependencies
+	for range 3 {
+		assert.NoError(t, c.Ready())
+	}
+	assert.Equal(t, int32(1), pings.Load())
+}
+
+func TestChecker_FailTimeout(t *testing.T) {
+	t.Parallel()
+
+	errRedis := errors.New("redis is down")
+	c := New(map[string]Check{
+		"postgres": func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		"redis": func(context.Context) error { return errRedis },
+	}, 10*time.Millisecond, time.Hour, slogdiscard.NewDiscardLogger())
+
+	failed := c.Check(context.Background())
+	require.Len(t, failed, 2)
+	assert.ErrorIs(t, failed["postgres"], context.DeadlineExceeded)
+	assert.ErrorIs(t, failed["redis"], errRedis)
+
+	c.update(context.Background())
+	assert.ErrorIs(t, c.Ready(), ErrNotReady)
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, c))
+}
+
+func TestChecker_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	c := New(map[string]Check{}, time.Second, time.Hour, slogdiscard.NewDiscardLogger())
+	c.update(context.Background())
+	require.Equal(t, healthv1.HealthCheckResponse_SERVING, status(t, c))
+
+	c.Shutdown()
+	c.Shutdown()
+	assert.ErrorIs(t, c.Ready(), ErrShuttingDown)
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, c))
+
+	// status is not restored after shutdown
+	c.update(context.Background())
+	assert.Equal(t, healthv1.HealthCheckResponse_NOT_SERVING, status(t, c))
+
+	// run returns once checker is shut down
+	assert.NoError(t, c.Run(context.Background()))
+}
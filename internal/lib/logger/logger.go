@@ -1,8 +1,11 @@
 package logger
 
 import (
+	"context"
 	"log/slog"
 	"os"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -18,10 +21,10 @@ func New(env string) *slog.Logger {
 	switch env {
 	case envLocal:
 		opts.Level = slog.LevelDebug
-		log = slog.New(slog.NewTextHandler(os.Stdout, opts))
+		log = slog.New(traceHandler{slog.NewTextHandler(os.Stdout, opts)})
 	case envProd:
 		opts.Level = slog.LevelInfo
-		log = slog.New(slog.NewJSONHandler(os.Stdout, opts))
+		log = slog.New(traceHandler{slog.NewJSONHandler(os.Stdout, opts)})
 	default:
 		panic("unknown env")
 	}
@@ -29,6 +32,56 @@ func New(env string) *slog.Logger {
 	return log
 }
 
+// traceHandler adds trace and span ids of span in context to records.
+type traceHandler struct {
+	slog.Handler
+}
+
+func (h traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{h.Handler.WithGroup(name)}
+}
+
+type loggerContextKey struct{}
+
+// WithContext returns context carrying log, so records of request share its attributes.
+func WithContext(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, log)
+}
+
+// FromContext returns logger of request or fallback when context has none.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if log, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok {
+		return log
+	}
+
+	return fallback
+}
+
+// With adds attributes to logger of request, context without logger is returned as is.
+func With(ctx context.Context, args ...any) context.Context {
+	log, ok := ctx.Value(loggerContextKey{}).(*slog.Logger)
+	if !ok {
+		return ctx
+	}
+
+	return WithContext(ctx, log.With(args...))
+}
+
 func Err(err error) slog.Attr {
 	return slog.Attr{
 		Key:   "error",
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceHandler_AddsTraceIDs(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := slog.New(traceHandler{slog.NewJSONHandler(&buf, nil)}).With(slog.String("method", "Login"))
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	log.InfoContext(trace.ContextWithSpanContext(context.Background(), sc), "login")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, sc.TraceID().String(), record["trace_id"])
+	assert.Equal(t, sc.SpanID().String(), record["span_id"])
+	assert.Equal(t, "Login", record["method"])
+}
+
+func TestTraceHandler_WithoutSpan(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := slog.New(traceHandler{slog.NewJSONHandler(&buf, nil)})
+	log.InfoContext(context.Background(), "login")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.NotContains(t, record, "trace_id")
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	fallback := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	assert.Same(t, fallback, FromContext(context.Background(), fallback))
+	assert.Equal(t, context.Background(), With(context.Background(), slog.String("user_id", "1")))
+
+	ctx := WithContext(context.Background(), fallback.With(slog.String("request_id", "abc")))
+	ctx = With(ctx, slog.String("user_id", "1"))
+	FromContext(ctx, fallback).Info("login")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "abc", record["request_id"])
+	assert.Equal(t, "1", record["user_id"])
+}
@@ -0,0 +1,119 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+var (
+	ErrNotObject = errors.New("metadata must be a JSON object")
+	ErrInvalid   = errors.New("metadata does not match schema")
+)
+
+// Validator checks namespace metadata against JSON Schema of the namespace.
+type Validator struct {
+	schemas map[string]*jsonschema.Schema
+}
+
+// New compiles schemas by namespace, namespaces without schema accept any object.
+func New(schemas map[string]string) (*Validator, error) {
+	v := &Validator{schemas: make(map[string]*jsonschema.Schema)}
+
+	c := jsonschema.NewCompiler()
+	for namespace, schema := range schemas {
+		if strings.TrimSpace(schema) == "" {
+			continue
+		}
+
+		doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+		if err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", namespace, err)
+		}
+
+		url := "metadata://" + namespace
+		if err := c.AddResource(url, doc); err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", namespace, err)
+		}
+
+		if v.schemas[namespace], err = c.Compile(url); err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", namespace, err)
+		}
+	}
+
+	return v, nil
+}
+
+// Validate checks that data is JSON object matching schema of namespace.
+func (v *Validator) Validate(namespace string, data []byte) error {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrNotObject, err)
+	}
+
+	if _, ok := doc.(map[string]any); !ok {
+		return ErrNotObject
+	}
+
+	schema, ok := v.schemas[namespace]
+	if !ok {
+		return nil
+	}
+
+	if err := schema.Validate(doc); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	return nil
+}
+
+// MergePatch applies JSON Merge Patch (RFC 7396) to target object: null removes key,
+// objects are merged recursively and any other value replaces the old one.
+func MergePatch(target, patch []byte) ([]byte, error) {
+	doc := map[string]any{}
+	if len(target) > 0 {
+		if err := unmarshal(target, &doc); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes map[string]any
+	if err := unmarshal(patch, &changes); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(merge(doc, changes))
+}
+
+func merge(doc, patch map[string]any) map[string]any {
+	for key, value := range patch {
+		switch value := value.(type) {
+		case nil:
+			delete(doc, key)
+		case map[string]any:
+			current, ok := doc[key].(map[string]any)
+			if !ok {
+				current = map[string]any{}
+			}
+			doc[key] = merge(current, value)
+		default:
+			doc[key] = value
+		}
+	}
+
+	return doc
+}
+
+func unmarshal(data []byte, v *map[string]any) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(v); err != nil || *v == nil {
+		return ErrNotObject
+	}
+
+	return nil
+}
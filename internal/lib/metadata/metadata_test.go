@@ -0,0 +1,74 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const schema = `{
+	"type": "object",
+	"properties": {
+		"plan": {"enum": ["free", "pro"]},
+		"limits": {"type": "object", "properties": {"uploads": {"type": "integer"}}}
+	},
+	"additionalProperties": false
+}`
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	v, err := New(map[string]string{"billing": schema, "catalog": ""})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		namespace string
+		data      string
+		err       error
+	}{
+		{name: "valid", namespace: "billing", data: `{"plan": "pro", "limits": {"uploads": 10}}`},
+		{name: "without schema", namespace: "catalog", data: `{"anything": [1, 2]}`},
+		{name: "schema mismatch", namespace: "billing", data: `{"plan": "enterprise"}`, err: ErrInvalid},
+		{name: "additional property", namespace: "billing", data: `{"extra": true}`, err: ErrInvalid},
+		{name: "not object", namespace: "catalog", data: `[1, 2]`, err: ErrNotObject},
+		{name: "malformed", namespace: "catalog", data: `{"plan"`, err: ErrNotObject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.namespace, []byte(tt.data))
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNew_InvalidSchema(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(map[string]string{"billing": `{"type": 1}`})
+	require.Error(t, err)
+}
+
+func TestMergePatch(t *testing.T) {
+	t.Parallel()
+
+	res, err := MergePatch(
+		[]byte(`{"plan": "free", "limits": {"uploads": 10, "tracks": 5}, "trial": true}`),
+		[]byte(`{"plan": "pro", "limits": {"tracks": null, "uploads": 20}, "trial": null, "since": 1700000000000}`),
+	)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"plan": "pro", "limits": {"uploads": 20}, "since": 1700000000000}`, string(res))
+
+	res, err = MergePatch(nil, []byte(`{"plan": "pro", "removed": null}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"plan": "pro"}`, string(res))
+
+	_, err = MergePatch([]byte(`{}`), []byte(`"plan"`))
+	assert.ErrorIs(t, err, ErrNotObject)
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor_CountsCodes(t *testing.T) {
+	t.Parallel()
+
+	const method = "/user.UserService/TestCountsCodes"
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+
+	for range 2 {
+		_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, status.Error(codes.InvalidArgument, "invalid")
+		})
+		require.Error(t, err)
+	}
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(rpcHandled.WithLabelValues(method, codes.OK.String())))
+	assert.Equal(t, 2.0, testutil.ToFloat64(rpcHandled.WithLabelValues(method, codes.InvalidArgument.String())))
+	assert.GreaterOrEqual(t, testutil.CollectAndCount(rpcDuration), 1)
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_server_handled_total",
+		Help:      "Requests of HTTP routes without RPC completed by route and status code.",
+	}, []string{"method", "code"})
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_server_handling_seconds",
+		Help:      "Latency of HTTP routes without RPC by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// ObserveHTTP counts request of HTTP route by status code and observes its latency, method is
+// stable name of route which policies use.
+func ObserveHTTP(method string, start time.Time, code int) {
+	httpHandled.WithLabelValues(method, strconv.Itoa(code)).Inc()
+	httpDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveHTTP_CountsCodes(t *testing.T) {
+	t.Parallel()
+
+	const method = "/http/TestCountsCodes"
+
+	ObserveHTTP(method, time.Now(), http.StatusOK)
+	for range 2 {
+		ObserveHTTP(method, time.Now(), http.StatusForbidden)
+	}
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpHandled.WithLabelValues(method, "200")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(httpHandled.WithLabelValues(method, "403")))
+	assert.GreaterOrEqual(t, testutil.CollectAndCount(httpDuration), 1)
+}
@@ -0,0 +1,68 @@
+// Package metrics defines prometheus metrics of the service, they are registered
+// in default registry and served at /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "beatflow_auth"
+
+// Business counters.
+var (
+	Logins = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Successful logins, including signups.",
+	})
+	Signups = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "signups_total",
+		Help:      "Users created on login.",
+	})
+	Refreshes = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "refreshes_total",
+		Help:      "Successful refreshes of tokens.",
+	})
+	RefreshFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "refresh_failures_total",
+		Help:      "Failed refreshes of tokens by reason.",
+	}, []string{"reason"})
+	AdminChanges = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "admin_changes_total",
+		Help:      "Applied and requested changes of admins by action.",
+	}, []string{"action"})
+)
+
+// UserCacheRequests counts lookups of user cache by result, hit or miss.
+var UserCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "user_cache_requests_total",
+	Help:      "Lookups of user cache by lookup and result.",
+}, []string{"lookup", "result"})
+
+// AuditEventsDropped counts audit events lost because buffer was full or write failed.
+var AuditEventsDropped = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "audit_events_dropped_total",
+	Help:      "Audit events which were not written.",
+})
+
+// Refresh failure reasons.
+const (
+	ReasonInvalidToken = "invalid_token"
+	ReasonUserNotFound = "user_not_found"
+	ReasonInternal     = "internal"
+)
+
+// Admin change actions.
+const (
+	ActionAdd     = "add"
+	ActionDelete  = "delete"
+	ActionInit    = "init"
+	ActionRequest = "request"
+)
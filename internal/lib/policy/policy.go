@@ -0,0 +1,233 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// HTTPMethodPrefix starts names of HTTP routes without RPC, for example "/http/DeleteUser".
+// Request of such route is object of its path parameters.
+const HTTPMethodPrefix = "/http/"
+
+type Rule struct {
+	Name       string
+	Methods    []string
+	Effect     string
+	Expression string
+}
+
+// Input is the data rule expressions are evaluated against.
+type Input struct {
+	Method  string
+	Request proto.Message
+	Claims  map[string]any
+	User    map[string]any
+}
+
+type RuleResult struct {
+	Rule    string
+	Effect  string
+	Matched bool
+	Err     error
+}
+
+type Decision struct {
+	Allowed bool
+	// Rule that decided the call, empty if no rule applied
+	Rule    string
+	Results []RuleResult
+}
+
+type compiledRule struct {
+	Rule
+	program cel.Program
+}
+
+type Engine struct {
+	rules map[string][]compiledRule
+}
+
+// New compiles rules, expressions have access to method, request, claims and user variables.
+func New(rules []Rule) (*Engine, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("method", cel.StringType),
+		cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("claims", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("user", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := &Engine{rules: make(map[string][]compiledRule)}
+	for _, rule := range rules {
+		if rule.Effect != EffectAllow && rule.Effect != EffectDeny {
+			return nil, fmt.Errorf("policy %s: invalid effect %q", rule.Name, rule.Effect)
+		}
+
+		ast, iss := env.Compile(rule.Expression)
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("policy %s: %w", rule.Name, iss.Err())
+		}
+
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("policy %s: expression must return bool", rule.Name)
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s: %w", rule.Name, err)
+		}
+
+		for _, method := range rule.Methods {
+			engine.rules[method] = append(engine.rules[method], compiledRule{Rule: rule, program: program})
+		}
+	}
+
+	return engine, nil
+}
+
+// Evaluate applies rules of the method. Any matched deny rule denies the call,
+// otherwise if method has allow rules at least one of them must match.
+// Method without rules is allowed.
+func (e *Engine) Evaluate(input Input) (*Decision, error) {
+	rules := e.rules[input.Method]
+	if len(rules) == 0 {
+		return &Decision{Allowed: true}, nil
+	}
+
+	request, err := toMap(input.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]any{
+		"method":  input.Method,
+		"request": request,
+		"claims":  orEmpty(input.Claims),
+		"user":    orEmpty(input.User),
+	}
+
+	decision := &Decision{}
+	var hasAllow bool
+	var allowedBy string
+	var deniedBy string
+	for _, rule := range rules {
+		res := RuleResult{Rule: rule.Name, Effect: rule.Effect}
+
+		out, _, err := rule.program.Eval(vars)
+		if err != nil {
+			// Rule that cannot be evaluated never grants access
+			res.Err = err
+			res.Matched = rule.Effect == EffectDeny
+		} else {
+			res.Matched, _ = out.Value().(bool)
+		}
+		decision.Results = append(decision.Results, res)
+
+		switch rule.Effect {
+		case EffectAllow:
+			hasAllow = true
+			if res.Matched && allowedBy == "" {
+				allowedBy = rule.Name
+			}
+		case EffectDeny:
+			if res.Matched && deniedBy == "" {
+				deniedBy = rule.Name
+			}
+		}
+	}
+
+	switch {
+	case deniedBy != "":
+		decision.Rule = deniedBy
+	case !hasAllow:
+		decision.Allowed = true
+	case allowedBy != "":
+		decision.Allowed = true
+		decision.Rule = allowedBy
+	}
+
+	return decision, nil
+}
+
+// NewRequest unmarshals JSON request of gRPC method, for example "/user.UserService/GetUsers",
+// or path parameters of HTTP route, so calls can be explained without making them. gRPC method
+// must be registered.
+func NewRequest(method string, data []byte) (proto.Message, error) {
+	if strings.HasPrefix(method, HTTPMethodPrefix) {
+		msg := &structpb.Struct{}
+		if len(data) > 0 {
+			if err := protojson.Unmarshal(data, msg); err != nil {
+				return nil, fmt.Errorf("method %s: %w", method, err)
+			}
+		}
+
+		return msg, nil
+	}
+
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(method, "/"), "/", "."))
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("method %s: %w", method, err)
+	}
+
+	md, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("method %s: not a method", method)
+	}
+
+	typ, err := protoregistry.GlobalTypes.FindMessageByName(md.Input().FullName())
+	if err != nil {
+		return nil, fmt.Errorf("method %s: %w", method, err)
+	}
+
+	msg := typ.New().Interface()
+	if len(data) > 0 {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, msg); err != nil {
+			return nil, fmt.Errorf("method %s: %w", method, err)
+		}
+	}
+
+	return msg, nil
+}
+
+func toMap(msg proto.Message) (map[string]any, error) {
+	res := make(map[string]any)
+	if msg == nil {
+		return res, nil
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func orEmpty(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+
+	return m
+}
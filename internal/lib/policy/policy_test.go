@@ -0,0 +1,145 @@
+package policy
+
+import (
+	"testing"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const getAdmins = "/user.UserService/GetAdmins"
+
+func minorAdminRule() Rule {
+	return Rule{
+		Name:       "minor-admin-sees-minor",
+		Methods:    []string{getAdmins},
+		Effect:     EffectDeny,
+		Expression: `user.admin == "minor" && (!has(request.admin_scale) || request.admin_scale != "minor")`,
+	}
+}
+
+func TestEvaluate_Deny(t *testing.T) {
+	t.Parallel()
+
+	engine, err := New([]Rule{minorAdminRule()})
+	require.NoError(t, err)
+
+	major := "major"
+	tests := []struct {
+		name    string
+		req     *userv1.GetAdminsRequest
+		user    map[string]any
+		allowed bool
+	}{
+		{
+			name:    "minor admin without scale filter",
+			req:     &userv1.GetAdminsRequest{Limit: 10},
+			user:    map[string]any{"admin": "minor"},
+			allowed: false,
+		},
+		{
+			name:    "minor admin with major scale",
+			req:     &userv1.GetAdminsRequest{Limit: 10, AdminScale: &major},
+			user:    map[string]any{"admin": "minor"},
+			allowed: false,
+		},
+		{
+			name:    "major admin",
+			req:     &userv1.GetAdminsRequest{Limit: 10},
+			user:    map[string]any{"admin": "major"},
+			allowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := engine.Evaluate(Input{Method: getAdmins, Request: tt.req, User: tt.user})
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, decision.Allowed)
+			if !tt.allowed {
+				assert.Equal(t, "minor-admin-sees-minor", decision.Rule)
+			}
+		})
+	}
+}
+
+func TestEvaluate_Allow(t *testing.T) {
+	t.Parallel()
+
+	engine, err := New([]Rule{{
+		Name:       "only-major",
+		Methods:    []string{getAdmins},
+		Effect:     EffectAllow,
+		Expression: `claims.admin == "major"`,
+	}})
+	require.NoError(t, err)
+
+	decision, err := engine.Evaluate(Input{Method: getAdmins, Claims: map[string]any{"admin": "major"}})
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, "only-major", decision.Rule)
+
+	// Missing claim fails evaluation, which never allows call
+	decision, err = engine.Evaluate(Input{Method: getAdmins})
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	require.Len(t, decision.Results, 1)
+	assert.Error(t, decision.Results[0].Err)
+
+	decision, err = engine.Evaluate(Input{Method: "/user.UserService/GetUsers"})
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+	assert.Empty(t, decision.Rule)
+}
+
+func TestNew_FailInvalidRule(t *testing.T) {
+	t.Parallel()
+
+	_, err := New([]Rule{{Name: "effect", Effect: "maybe", Expression: "true"}})
+	assert.Error(t, err)
+
+	_, err = New([]Rule{{Name: "syntax", Effect: EffectAllow, Expression: "user.admin =="}})
+	assert.Error(t, err)
+
+	_, err = New([]Rule{{Name: "type", Effect: EffectAllow, Expression: `"minor"`}})
+	assert.Error(t, err)
+}
+
+func TestNewRequest(t *testing.T) {
+	t.Parallel()
+
+	msg, err := NewRequest(getAdmins, []byte(`{"admin_scale": "minor"}`))
+	require.NoError(t, err)
+
+	req, ok := msg.(*userv1.GetAdminsRequest)
+	require.True(t, ok)
+	assert.Equal(t, "minor", req.GetAdminScale())
+
+	_, err = NewRequest("/user.UserService/Unknown", nil)
+	assert.Error(t, err)
+
+	_, err = NewRequest(getAdmins, []byte(`{"admin_scale": 1}`))
+	assert.Error(t, err)
+}
+
+func TestNewRequest_HTTPRoute(t *testing.T) {
+	t.Parallel()
+
+	const deleteUser = HTTPMethodPrefix + "DeleteUser"
+	engine, err := New([]Rule{{
+		Name:       "keep-user",
+		Methods:    []string{deleteUser},
+		Effect:     EffectDeny,
+		Expression: `request.user_id == "1"`,
+	}})
+	require.NoError(t, err)
+
+	msg, err := NewRequest(deleteUser, []byte(`{"user_id": "1"}`))
+	require.NoError(t, err)
+
+	decision, err := engine.Evaluate(Input{Method: deleteUser, Request: msg})
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, "keep-user", decision.Rule)
+}
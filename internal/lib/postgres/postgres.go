@@ -36,11 +36,17 @@ func New(ctx context.Context, dbURL string, log *slog.Logger, opts ...Option) (*
 		opt(pg)
 	}
 
+	poolConfig, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		log.Error("failed to parse database url", sl.Err(err))
+		return nil, err
+	}
+	poolConfig.ConnConfig.Tracer = newTracer()
+
 	var db *pgxpool.Pool
-	var err error
 
 	for pg.connAttempts > 0 {
-		db, err = pgxpool.New(ctx, dbURL)
+		db, err = pgxpool.NewWithConfig(ctx, poolConfig)
 		if err != nil {
 			continue
 		}
@@ -0,0 +1,172 @@
+package pseudonym
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrInvalid  = errors.New("pseudonym contains invalid characters")
+	ErrEmpty    = errors.New("pseudonym is empty")
+	ErrReserved = errors.New("pseudonym is reserved")
+	ErrBlocked  = errors.New("pseudonym contains blocked word")
+)
+
+// confusables maps characters to the latin letter they are visually confused with,
+// it covers cyrillic and greek homoglyphs. Only single characters are folded, letter
+// pairs such as "rn" and "m" are distinct pseudonyms in most fonts.
+var confusables = map[rune]rune{
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'ѕ': 's', 'і': 'i', 'ј': 'j', 'ԁ': 'd',
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p',
+	'τ': 't', 'υ': 'u', 'χ': 'x', 'ɡ': 'g', 'ı': 'i',
+}
+
+// substitutes maps digits and symbols commonly used in place of letters. They are folded
+// only when pseudonym is matched against reserved and blocked words, "m3tro" and "metro"
+// are distinct pseudonyms.
+var substitutes = map[rune]rune{
+	'0': 'o', '1': 'l', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b',
+	'@': 'a', '$': 's', '|': 'l', '!': 'i',
+}
+
+// separators split pseudonym into words, they are not part of skeleton.
+const separators = "_-."
+
+// Policy validates pseudonyms against reserved and blocked words.
+type Policy struct {
+	reserved map[string]bool
+	blocked  map[string]bool
+}
+
+// New creates policy, reserved words must not be taken as whole pseudonym,
+// blocked words must not be words of pseudonym. Both are compared by skeleton
+// with substitutes of letters folded.
+func New(reserved, blocked []string) *Policy {
+	p := &Policy{reserved: make(map[string]bool), blocked: make(map[string]bool)}
+	for _, word := range reserved {
+		p.reserved[fold(Skeleton(word))] = true
+	}
+
+	for _, word := range blocked {
+		if folded := fold(Skeleton(word)); folded != "" {
+			p.blocked[folded] = true
+		}
+	}
+
+	return p
+}
+
+// Normalize applies NFKC normalization, trims and collapses whitespace.
+func Normalize(pseudonym string) string {
+	return strings.Join(strings.Fields(norm.NFKC.String(pseudonym)), " ")
+}
+
+// Skeleton returns form used to compare pseudonyms: case, diacritics, separators
+// and confusable letters of other scripts are folded, so "Metro", "metro" and "Меtrо"
+// share skeleton. Digits and symbols are kept.
+func Skeleton(pseudonym string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(pseudonym) {
+		if unicode.Is(unicode.Mn, r) || unicode.IsSpace(r) || strings.ContainsRune(separators, r) {
+			continue
+		}
+
+		r = unicode.ToLower(r)
+		if c, ok := confusables[r]; ok {
+			r = c
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// fold replaces substitutes of letters in skeleton, so "4dm!n" matches reserved "admin".
+func fold(skeleton string) string {
+	return strings.Map(func(r rune) rune {
+		if c, ok := substitutes[r]; ok {
+			return c
+		}
+		return r
+	}, skeleton)
+}
+
+// words splits pseudonym on whitespace, separators and lower to upper case changes,
+// so "not_fake", "not fake" and "NotFake" consist of the same words.
+func words(pseudonym string) []string {
+	var res []string
+	var word []rune
+	for _, r := range pseudonym {
+		if unicode.IsSpace(r) || strings.ContainsRune(separators, r) ||
+			unicode.IsUpper(r) && len(word) > 0 && unicode.IsLower(word[len(word)-1]) {
+			if len(word) > 0 {
+				res = append(res, string(word))
+				word = word[:0]
+			}
+		}
+
+		if !unicode.IsSpace(r) && !strings.ContainsRune(separators, r) {
+			word = append(word, r)
+		}
+	}
+
+	if len(word) > 0 {
+		res = append(res, string(word))
+	}
+
+	return res
+}
+
+// isBlocked reports whether blocked word is a word of pseudonym or is spelled by adjacent words,
+// as in "f.a.k.e". Blocked words inside other words, as "ass" in "classic", are allowed.
+func (p *Policy) isBlocked(pseudonym string) bool {
+	var skeletons []string
+	for _, word := range words(pseudonym) {
+		skeletons = append(skeletons, fold(Skeleton(word)))
+	}
+
+	for i := range skeletons {
+		var joined string
+		for _, skeleton := range skeletons[i:] {
+			joined += skeleton
+			if p.blocked[joined] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Validate returns normalized pseudonym and its skeleton.
+func (p *Policy) Validate(pseudonym string) (normalized, skeleton string, err error) {
+	normalized = Normalize(pseudonym)
+	if normalized == "" {
+		return "", "", ErrEmpty
+	}
+
+	for _, r := range normalized {
+		if !unicode.IsPrint(r) {
+			return "", "", ErrInvalid
+		}
+	}
+
+	skeleton = Skeleton(normalized)
+	if skeleton == "" {
+		return "", "", ErrInvalid
+	}
+
+	if p.reserved[fold(skeleton)] {
+		return "", "", ErrReserved
+	}
+
+	if p.isBlocked(normalized) {
+		return "", "", ErrBlocked
+	}
+
+	return normalized, skeleton, nil
+}
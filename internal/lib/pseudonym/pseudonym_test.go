@@ -0,0 +1,116 @@
+package pseudonym
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkeleton(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		pseudonym string
+	}{
+		{name: "case", pseudonym: "METRO"},
+		{name: "cyrillic homoglyphs", pseudonym: "Меtrо"},
+		{name: "diacritics", pseudonym: "Métro"},
+		{name: "separators", pseudonym: "me_tro"},
+		{name: "fullwidth", pseudonym: "ｍｅｔｒｏ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, Skeleton("metro"), Skeleton(tt.pseudonym))
+		})
+	}
+
+	assert.NotEqual(t, Skeleton("metro"), Skeleton("metra"))
+}
+
+func TestSkeleton_SubstitutesNotFolded(t *testing.T) {
+	t.Parallel()
+
+	assert.NotEqual(t, Skeleton("metro"), Skeleton("m3tr0"))
+	assert.NotEqual(t, Skeleton("alice"), Skeleton("@l1ce"))
+	assert.Equal(t, Skeleton("m3tr0"), Skeleton("M3TR0"))
+}
+
+func TestSkeleton_LetterPairsNotFolded(t *testing.T) {
+	t.Parallel()
+
+	assert.NotEqual(t, Skeleton("dara"), Skeleton("clara"))
+	assert.NotEqual(t, Skeleton("modern"), Skeleton("modem"))
+	assert.NotEqual(t, Skeleton("vvolf"), Skeleton("wolf"))
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Metro Boomin", Normalize("  Metro \t Boomin "))
+	assert.Equal(t, "Metro", Normalize("Ｍｅｔｒｏ"))
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	p := New([]string{"admin", "Beatflow"}, []string{"fake"})
+
+	normalized, skeleton, err := p.Validate(" Metro  Boomin ")
+	require.NoError(t, err)
+	assert.Equal(t, "Metro Boomin", normalized)
+	assert.Equal(t, Skeleton("metroboomin"), skeleton)
+
+	tests := []struct {
+		name      string
+		pseudonym string
+		err       error
+	}{
+		{name: "empty", pseudonym: "   ", err: ErrEmpty},
+		{name: "control character", pseudonym: "metro\u0007", err: ErrInvalid},
+		{name: "only separators", pseudonym: "__", err: ErrInvalid},
+		{name: "reserved", pseudonym: "ADMIN", err: ErrReserved},
+		{name: "reserved confusable", pseudonym: "bеаtf1ow", err: ErrReserved},
+		{name: "blocked", pseudonym: "not_FAKE_metro", err: ErrBlocked},
+		{name: "blocked word of camel case", pseudonym: "NotFakeMetro", err: ErrBlocked},
+		{name: "blocked confusable", pseudonym: "not fаkе", err: ErrBlocked},
+		{name: "blocked spelled by letters", pseudonym: "f.a.k.e", err: ErrBlocked},
+		{name: "reserved with symbols", pseudonym: "4DM!N", err: ErrReserved},
+		{name: "blocked with symbols", pseudonym: "not f@k3", err: ErrBlocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := p.Validate(tt.pseudonym)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestValidate_BlockedWordBoundaries(t *testing.T) {
+	t.Parallel()
+
+	p := New(nil, []string{"ass", "dara"})
+
+	tests := []struct {
+		name      string
+		pseudonym string
+	}{
+		{name: "inside word", pseudonym: "classic"},
+		{name: "inside word at the end", pseudonym: "Grass"},
+		{name: "letter pair", pseudonym: "clara"},
+		{name: "letter pair inside name", pseudonym: "Clara Jones"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := p.Validate(tt.pseudonym)
+			assert.NoError(t, err)
+		})
+	}
+
+	_, _, err := p.Validate("Dara Jones")
+	assert.ErrorIs(t, err, ErrBlocked)
+}
@@ -0,0 +1,103 @@
+package redact
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Mask replaces values of redacted string fields.
+const Mask = "[REDACTED]"
+
+// secrets are always redacted, tokens in logs are as good as leaked.
+var secrets = []string{"access_token", "refresh_token", "token", "init_data"}
+
+// Redactor masks sensitive fields of messages before they are logged.
+type Redactor struct {
+	fields map[string]bool
+}
+
+// New creates redactor of token fields, fields annotated with debug_redact and given fields,
+// fields are matched by name, for example "first_name", or by full name, for example
+// "user.User.first_name".
+func New(fields []string) *Redactor {
+	r := &Redactor{fields: make(map[string]bool, len(secrets)+len(fields))}
+	for _, name := range secrets {
+		r.fields[name] = true
+	}
+	for _, name := range fields {
+		r.fields[name] = true
+	}
+
+	return r
+}
+
+// Redact returns copy of message with sensitive fields masked, message itself is not changed.
+func (r *Redactor) Redact(m proto.Message) proto.Message {
+	if m == nil {
+		return nil
+	}
+
+	res := proto.Clone(m)
+	r.redact(res.ProtoReflect())
+
+	return res
+}
+
+func (r *Redactor) redact(m protoreflect.Message) {
+	fields := m.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+
+		v := m.Get(fd)
+		if fd.IsList() || fd.IsMap() || fd.Message() != nil {
+			v = m.Mutable(fd)
+		}
+		if r.sensitive(fd) {
+			mask(m, fd, v)
+			continue
+		}
+
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for j := range list.Len() {
+				r.redact(list.Get(j).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				r.redact(v.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			r.redact(v.Message())
+		}
+	}
+}
+
+func (r *Redactor) sensitive(fd protoreflect.FieldDescriptor) bool {
+	if r.fields[string(fd.Name())] || r.fields[string(fd.FullName())] {
+		return true
+	}
+
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	return ok && opts.GetDebugRedact()
+}
+
+// mask replaces strings with Mask, so it is seen that field was set, and clears other values.
+func mask(m protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	switch {
+	case fd.IsList() && fd.Kind() == protoreflect.StringKind:
+		list := v.List()
+		for i := range list.Len() {
+			list.Set(i, protoreflect.ValueOfString(Mask))
+		}
+	case !fd.IsList() && !fd.IsMap() && fd.Kind() == protoreflect.StringKind:
+		m.Set(fd, protoreflect.ValueOfString(Mask))
+	default:
+		m.Clear(fd)
+	}
+}
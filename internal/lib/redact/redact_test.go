@@ -0,0 +1,52 @@
+package redact
+
+import (
+	"testing"
+
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact_Tokens(t *testing.T) {
+	t.Parallel()
+
+	res := &userv1.LoginResponse{AccessToken: "access", RefreshToken: "refresh"}
+
+	redacted, ok := New(nil).Redact(res).(*userv1.LoginResponse)
+	require.True(t, ok)
+	assert.Equal(t, Mask, redacted.GetAccessToken())
+	assert.Equal(t, Mask, redacted.GetRefreshToken())
+
+	// message sent to client keeps tokens
+	assert.Equal(t, "access", res.GetAccessToken())
+}
+
+func TestRedact_NestedFields(t *testing.T) {
+	t.Parallel()
+
+	res := &userv1.GetUsersResponse{
+		Pagination: &userv1.Pagination{RecordsPerPage: 10},
+		Users: []*userv1.User{
+			{UserId: "1", Username: "qwerty", FirstName: "Aleksandr"},
+			{UserId: "2", Username: "asdfgh"},
+		},
+	}
+
+	redacted, ok := New([]string{"username", "user.User.first_name"}).Redact(res).(*userv1.GetUsersResponse)
+	require.True(t, ok)
+	require.Len(t, redacted.GetUsers(), 2)
+	assert.Equal(t, "1", redacted.GetUsers()[0].GetUserId())
+	assert.Equal(t, Mask, redacted.GetUsers()[0].GetUsername())
+	assert.Equal(t, Mask, redacted.GetUsers()[0].GetFirstName())
+	assert.Equal(t, Mask, redacted.GetUsers()[1].GetUsername())
+	// unset fields stay unset
+	assert.Empty(t, redacted.GetUsers()[1].GetFirstName())
+	assert.Equal(t, uint64(10), redacted.GetPagination().GetRecordsPerPage())
+}
+
+func TestRedact_Nil(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, New(nil).Redact(nil))
+}
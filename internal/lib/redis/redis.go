@@ -9,6 +9,7 @@ import (
 	"time"
 
 	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -78,6 +79,11 @@ func New(ctx context.Context, connString string, log *slog.Logger, opts ...Optio
 		return nil, err
 	}
 
+	if err := redisotel.InstrumentTracing(rdb.Client); err != nil {
+		log.Error("failed to instrument redis tracing", sl.Err(err))
+		return nil, err
+	}
+
 	return rdb, nil
 }
 
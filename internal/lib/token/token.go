@@ -0,0 +1,60 @@
+// Package token validates access tokens issued by the service. gRPC interceptors and HTTP routes
+// outside of gateway share it, so both accept tokens by the same rules.
+package token
+
+import (
+	"fmt"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+type Claims struct {
+	ID    string
+	Admin *string
+	// Tenant and TenantRole are present only in organization scoped tokens
+	Tenant     *string
+	TenantRole *string
+	Raw        map[string]any
+}
+
+// Validate checks signature and expiry of token and returns its claims, errors wrap model.ErrUnauthorized.
+func Validate(token, secret string) (*Claims, error) {
+	data, err := jwt.Parse(token, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "unexpected signing method")
+		}
+
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", model.ErrUnauthorized, err)
+	}
+
+	claims, ok := data.Claims.(jwt.MapClaims)
+	if !ok || !data.Valid {
+		return nil, model.ErrUnauthorized
+	}
+
+	id, ok := claims["id"].(string)
+	if !ok || uuid.Validate(id) != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "invalid id")
+	}
+
+	res := &Claims{ID: id, Raw: claims}
+	if admin, ok := claims["admin"].(string); ok {
+		res.Admin = &admin
+	}
+
+	tenant, tenantOk := claims["tenant"].(string)
+	tenantRole, tenantRoleOk := claims["tenant_role"].(string)
+	if tenantOk != tenantRoleOk || tenantOk && uuid.Validate(tenant) != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrUnauthorized, "invalid tenant")
+	} else if tenantOk {
+		res.Tenant = &tenant
+		res.TenantRole = &tenantRole
+	}
+
+	return res, nil
+}
@@ -0,0 +1,88 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "secret"
+
+func sign(t *testing.T, claims jwt.MapClaims, key string) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key))
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestValidate_Success(t *testing.T) {
+	t.Parallel()
+
+	id, tenant := uuid.NewString(), uuid.NewString()
+	token := sign(t, jwt.MapClaims{
+		"id":          id,
+		"admin":       "minor",
+		"tenant":      tenant,
+		"tenant_role": "owner",
+		"exp":         time.Now().Add(time.Minute).Unix(),
+	}, secret)
+
+	claims, err := Validate(token, secret)
+	require.NoError(t, err)
+	assert.Equal(t, id, claims.ID)
+	require.NotNil(t, claims.Admin)
+	assert.Equal(t, "minor", *claims.Admin)
+	require.NotNil(t, claims.Tenant)
+	assert.Equal(t, tenant, *claims.Tenant)
+	require.NotNil(t, claims.TenantRole)
+	assert.Equal(t, "owner", *claims.TenantRole)
+	assert.Equal(t, id, claims.Raw["id"])
+}
+
+func TestValidate_Fail(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Now().Add(time.Minute).Unix()
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{
+			name:  "wrong secret",
+			token: sign(t, jwt.MapClaims{"id": uuid.NewString(), "exp": exp}, "other"),
+		},
+		{
+			name:  "expired",
+			token: sign(t, jwt.MapClaims{"id": uuid.NewString(), "exp": time.Now().Add(-time.Minute).Unix()}, secret),
+		},
+		{
+			name:  "invalid id",
+			token: sign(t, jwt.MapClaims{"id": "qwerty", "exp": exp}, secret),
+		},
+		{
+			name:  "tenant without role",
+			token: sign(t, jwt.MapClaims{"id": uuid.NewString(), "tenant": uuid.NewString(), "exp": exp}, secret),
+		},
+		{
+			name:  "invalid tenant",
+			token: sign(t, jwt.MapClaims{"id": uuid.NewString(), "tenant": "qwerty", "tenant_role": "owner", "exp": exp}, secret),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Validate(tt.token, secret)
+			assert.ErrorIs(t, err, model.ErrUnauthorized)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/metrics"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// requestAdminChange saves pending admin change, which must be approved by another major admin.
+func (s *UserService) requestAdminChange(ctx context.Context, action generated.AdminRequestAction, targetID uuid.UUID, scale generated.AdminScale, actorID uuid.UUID) error {
+	request, err := s.userModifier.SaveAdminRequest(ctx, generated.SaveAdminRequestParams{
+		Action:       action,
+		TargetUserID: targetID,
+		Scale:        generated.NullAdminScale{AdminScale: scale, Valid: true},
+		RequestedBy:  actorID,
+		ExpiresAt: pgtype.Timestamp{
+			Time:  time.Now().Add(time.Minute * time.Duration(s.authConfig.AdminRequestTTL)),
+			Valid: true,
+		},
+	})
+	if err != nil {
+		s.logger(ctx).Error("failed to save admin request", sl.Err(err))
+		return err
+	}
+
+	s.logger(ctx).Debug("admin request created", slog.String("request_id", request.ID.String()), slog.String("action", string(action)))
+	metrics.AdminChanges.WithLabelValues(metrics.ActionRequest).Inc()
+
+	return fmt.Errorf("%w: request %s", model.ErrAdminChangePending, request.ID)
+}
+
+func (s *UserService) GetAdminRequests(ctx context.Context, params generated.GetAdminRequestsParams) (requests []generated.AdminRequest, total *uint64, err error) {
+	return s.userProvider.GetAdminRequests(ctx, params)
+}
+
+func (s *UserService) checkAdminRequestDecider(ctx context.Context, id, deciderID uuid.UUID, scale generated.AdminScale) (*generated.AdminRequest, error) {
+	if scale != generated.AdminScaleMajor {
+		s.logger(ctx).Debug("scale of admin is not major")
+		return nil, model.ErrAdminNotMajor
+	}
+
+	request, err := s.userProvider.GetAdminRequestByID(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error("failed to get admin request", sl.Err(err))
+		return nil, err
+	}
+
+	if request.RequestedBy == deciderID {
+		s.logger(ctx).Debug("admin tried to decide own request")
+		return nil, model.ErrSelfApproval
+	}
+
+	return request, nil
+}
+
+// ApproveAdminRequest applies request of another admin. State of target is checked by store in
+// the same transaction, it fails with ErrAdminAlreadyExists or ErrCannotDeleteMajorAdmin.
+func (s *UserService) ApproveAdminRequest(ctx context.Context, id, approverID uuid.UUID, scale generated.AdminScale) (approved *generated.AdminRequest, err error) {
+	var request *generated.AdminRequest
+	defer func() {
+		s.auditAdminRequest(ctx, model.AuditActionApproveAdminRequest, err, approverID, id, request)
+	}()
+
+	request, err = s.checkAdminRequestDecider(ctx, id, approverID, scale)
+	if err != nil {
+		return nil, err
+	}
+
+	approved, err = s.userModifier.ApproveAdminRequest(ctx, id, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch approved.Action {
+	case generated.AdminRequestActionAddAdmin:
+		metrics.AdminChanges.WithLabelValues(metrics.ActionAdd).Inc()
+	case generated.AdminRequestActionDeleteAdmin:
+		metrics.AdminChanges.WithLabelValues(metrics.ActionDelete).Inc()
+	}
+
+	return approved, nil
+}
+
+func (s *UserService) RejectAdminRequest(ctx context.Context, id, approverID uuid.UUID, scale generated.AdminScale) (rejected *generated.AdminRequest, err error) {
+	var request *generated.AdminRequest
+	defer func() {
+		s.auditAdminRequest(ctx, model.AuditActionRejectAdminRequest, err, approverID, id, request)
+	}()
+
+	request, err = s.checkAdminRequestDecider(ctx, id, approverID, scale)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userModifier.RejectAdminRequest(ctx, id, approverID)
+}
+
+// auditAdminRequest records decision on admin request, request is nil if it was not found.
+func (s *UserService) auditAdminRequest(ctx context.Context, action string, err error, deciderID, id uuid.UUID, request *generated.AdminRequest) {
+	metadata := map[string]string{"request_id": id.String()}
+	var targetID *uuid.UUID
+	if request != nil {
+		targetID = &request.TargetUserID
+		metadata["request_action"] = string(request.Action)
+	}
+
+	s.audit(ctx, action, err, &deciderID, targetID, metadata)
+}
+
+// ExpireAdminRequests expires pending requests which were not decided in time.
+func (s *UserService) ExpireAdminRequests(ctx context.Context) (int, error) {
+	count, err := s.userModifier.ExpireAdminRequests(ctx)
+	if err != nil {
+		s.logger(ctx).Error("failed to expire admin requests", sl.Err(err))
+		return 0, err
+	}
+
+	return int(count), nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddAdmin_PendingApproval(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	s.userService.authConfig.AdminApproval = true
+	ctx := context.Background()
+
+	username := "qwerty"
+	userID := uuid.New()
+	actorID := uuid.New()
+
+	s.userProvider.On("GetUserAdminByUsername", mock.Anything, username).
+		Return(&generated.GetUserAdminByUsernameRow{ID: userID}, nil).Once()
+
+	s.userModifier.On("SaveAdminRequest", mock.Anything, mock.MatchedBy(func(params generated.SaveAdminRequestParams) bool {
+		return params.Action == generated.AdminRequestActionAddAdmin &&
+			params.TargetUserID == userID &&
+			params.RequestedBy == actorID &&
+			params.Scale.AdminScale == generated.AdminScaleMinor
+	})).Return(&generated.AdminRequest{ID: uuid.New()}, nil).Once()
+
+	admin, err := s.userService.AddAdmin(ctx, actorID, username, generated.AdminScaleMajor)
+	assert.ErrorIs(t, err, model.ErrAdminChangePending)
+	assert.Nil(t, admin)
+}
+
+func TestDeleteAdmin_PendingApproval(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	s.userService.authConfig.AdminApproval = true
+	ctx := context.Background()
+
+	id := uuid.New()
+	actorID := uuid.New()
+
+	s.userProvider.On("GetUserAdminByID", mock.Anything, id).
+		Return(&generated.GetUserAdminByIDRow{
+			ID: id,
+			Scale: generated.NullAdminScale{
+				Valid:      true,
+				AdminScale: generated.AdminScaleMinor,
+			}}, nil).Once()
+
+	s.userModifier.On("SaveAdminRequest", mock.Anything, mock.MatchedBy(func(params generated.SaveAdminRequestParams) bool {
+		return params.Action == generated.AdminRequestActionDeleteAdmin &&
+			params.TargetUserID == id &&
+			params.RequestedBy == actorID
+	})).Return(&generated.AdminRequest{ID: uuid.New()}, nil).Once()
+
+	err := s.userService.DeleteAdmin(ctx, actorID, id, generated.AdminScaleMajor)
+	assert.ErrorIs(t, err, model.ErrAdminChangePending)
+}
+
+func TestApproveAdminRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	targetID := uuid.New()
+	approverID := uuid.New()
+
+	request := &generated.AdminRequest{
+		ID:           id,
+		Action:       generated.AdminRequestActionAddAdmin,
+		TargetUserID: targetID,
+		RequestedBy:  uuid.New(),
+		Status:       generated.AdminRequestStatusPending,
+	}
+
+	s.userProvider.On("GetAdminRequestByID", mock.Anything, id).Return(request, nil).Once()
+	s.userModifier.On("ApproveAdminRequest", mock.Anything, id, approverID).
+		Return(&generated.AdminRequest{ID: id, Status: generated.AdminRequestStatusApproved}, nil).Once()
+
+	res, err := s.userService.ApproveAdminRequest(ctx, id, approverID, generated.AdminScaleMajor)
+	require.NoError(t, err)
+	assert.Equal(t, generated.AdminRequestStatusApproved, res.Status)
+}
+
+func TestApproveAdminRequest_FailTargetMajorAdmin(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	approverID := uuid.New()
+
+	request := &generated.AdminRequest{
+		ID:           id,
+		Action:       generated.AdminRequestActionDeleteAdmin,
+		TargetUserID: uuid.New(),
+		RequestedBy:  uuid.New(),
+		Status:       generated.AdminRequestStatusPending,
+	}
+
+	// target became major admin after request was made, store checks it in approval transaction
+	s.userProvider.On("GetAdminRequestByID", mock.Anything, id).Return(request, nil).Once()
+	s.userModifier.On("ApproveAdminRequest", mock.Anything, id, approverID).
+		Return(nil, model.ErrCannotDeleteMajorAdmin).Once()
+
+	_, err := s.userService.ApproveAdminRequest(ctx, id, approverID, generated.AdminScaleMajor)
+	assert.ErrorIs(t, err, model.ErrCannotDeleteMajorAdmin)
+}
+
+func TestApproveAdminRequest_FailSelfApproval(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	approverID := uuid.New()
+
+	s.userProvider.On("GetAdminRequestByID", mock.Anything, id).
+		Return(&generated.AdminRequest{ID: id, RequestedBy: approverID}, nil).Once()
+
+	_, err := s.userService.ApproveAdminRequest(ctx, id, approverID, generated.AdminScaleMajor)
+	assert.ErrorIs(t, err, model.ErrSelfApproval)
+}
+
+func TestApproveAdminRequest_FailAdminNotMajor(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	_, err := s.userService.ApproveAdminRequest(ctx, uuid.New(), uuid.New(), generated.AdminScaleMinor)
+	assert.ErrorIs(t, err, model.ErrAdminNotMajor)
+}
+
+func TestRejectAdminRequest_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	approverID := uuid.New()
+
+	s.userProvider.On("GetAdminRequestByID", mock.Anything, id).
+		Return(&generated.AdminRequest{ID: id, RequestedBy: uuid.New()}, nil).Once()
+	s.userModifier.On("RejectAdminRequest", mock.Anything, id, approverID).
+		Return(&generated.AdminRequest{ID: id, Status: generated.AdminRequestStatusRejected}, nil).Once()
+
+	res, err := s.userService.RejectAdminRequest(ctx, id, approverID, generated.AdminScaleMajor)
+	require.NoError(t, err)
+	assert.Equal(t, generated.AdminRequestStatusRejected, res.Status)
+}
+
+func TestExpireAdminRequests_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	s.userModifier.On("ExpireAdminRequests", mock.Anything).Return(int64(2), nil).Once()
+
+	count, err := s.userService.ExpireAdminRequests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+// audit records outcome of action made by caller of request, err of failed action is kept
+// in metadata. Recording does not block.
+func (s *UserService) audit(ctx context.Context, action string, err error, actorID, targetID *uuid.UUID, metadata map[string]string) {
+	outcome := model.AuditOutcomeSuccess
+	switch {
+	case errors.Is(err, model.ErrAdminChangePending):
+		outcome = model.AuditOutcomePending
+	case err != nil:
+		outcome = model.AuditOutcomeFailure
+		if metadata == nil {
+			metadata = make(map[string]string, 1)
+		}
+		metadata["error"] = err.Error()
+	}
+
+	s.auditRecorder.Record(ctx, model.AuditEvent{
+		Action:     action,
+		Outcome:    outcome,
+		ActorID:    actorID,
+		TargetID:   targetID,
+		Client:     model.ClientFromContext(ctx),
+		Metadata:   metadata,
+		OccurredAt: time.Now(),
+	})
+}
+
+// knownID is nil for zero id, so actions of unknown users have no actor.
+func knownID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+
+	return &id
+}
+
+// GetAuditEvents returns page of audit events from the newest one.
+func (s *UserService) GetAuditEvents(ctx context.Context, params model.GetAuditEventsParams) (events []generated.AuditEvent, total *uint64, err error) {
+	return s.auditProvider.GetAuditEvents(ctx, params)
+}
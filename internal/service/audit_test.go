@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func recordedEvents(t *testing.T, s dependencies) []model.AuditEvent {
+	t.Helper()
+
+	var events []model.AuditEvent
+	for _, call := range s.auditRecorder.Calls {
+		event, ok := call.Arguments.Get(1).(model.AuditEvent)
+		require.True(t, ok)
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func TestAudit_AddAdminSuccess(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	client := model.Client{IP: "127.0.0.1", UserAgent: "test"}
+	ctx := model.ContextWithClient(context.Background(), client)
+
+	username := "qwerty"
+	actorID, userID := uuid.New(), uuid.New()
+
+	s.userProvider.On("GetUserAdminByUsername", mock.Anything, username).
+		Return(&generated.GetUserAdminByUsernameRow{ID: userID}, nil).Once()
+	s.userModifier.On("SaveAdmin", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := s.userService.AddAdmin(ctx, actorID, username, generated.AdminScaleMajor)
+	require.NoError(t, err)
+
+	events := recordedEvents(t, s)
+	require.Len(t, events, 1)
+	assert.Equal(t, model.AuditActionAddAdmin, events[0].Action)
+	assert.Equal(t, model.AuditOutcomeSuccess, events[0].Outcome)
+	assert.Equal(t, &actorID, events[0].ActorID)
+	assert.Equal(t, &userID, events[0].TargetID)
+	assert.Equal(t, client, events[0].Client)
+	assert.Equal(t, username, events[0].Metadata["username"])
+}
+
+func TestAudit_AddAdminFailure(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	_, err := s.userService.AddAdmin(ctx, uuid.New(), "qwerty", generated.AdminScaleMinor)
+	require.ErrorIs(t, err, model.ErrAdminNotMajor)
+
+	events := recordedEvents(t, s)
+	require.Len(t, events, 1)
+	assert.Equal(t, model.AuditOutcomeFailure, events[0].Outcome)
+	assert.Nil(t, events[0].TargetID)
+	assert.Equal(t, model.ErrAdminNotMajor.Error(), events[0].Metadata["error"])
+}
+
+func TestAudit_AdminChangePending(t *testing.T) {
+	t.Parallel()
+
+	s := createServiceWithConfig(t, model.AuthConfig{AdminApproval: true})
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.userProvider.On("GetUserAdminByID", mock.Anything, id).
+		Return(&generated.GetUserAdminByIDRow{ID: id, Scale: generated.NullAdminScale{AdminScale: generated.AdminScaleMinor, Valid: true}}, nil).Once()
+	s.userModifier.On("SaveAdminRequest", mock.Anything, mock.Anything).
+		Return(&generated.AdminRequest{ID: uuid.New()}, nil).Once()
+
+	err := s.userService.DeleteAdmin(ctx, uuid.New(), id, generated.AdminScaleMajor)
+	require.ErrorIs(t, err, model.ErrAdminChangePending)
+
+	events := recordedEvents(t, s)
+	require.Len(t, events, 1)
+	assert.Equal(t, model.AuditActionDeleteAdmin, events[0].Action)
+	assert.Equal(t, model.AuditOutcomePending, events[0].Outcome)
+	assert.Equal(t, &id, events[0].TargetID)
+}
+
+func TestAudit_ApproveAdminRequest(t *testing.T) {
+	t.Parallel()
+
+	s := createServiceWithConfig(t, model.AuthConfig{AdminApproval: true})
+	ctx := context.Background()
+
+	id, targetID, approverID := uuid.New(), uuid.New(), uuid.New()
+
+	s.userProvider.On("GetAdminRequestByID", mock.Anything, id).Return(&generated.AdminRequest{
+		ID:           id,
+		Action:       generated.AdminRequestActionAddAdmin,
+		TargetUserID: targetID,
+		RequestedBy:  uuid.New(),
+		Status:       generated.AdminRequestStatusPending,
+	}, nil).Once()
+	s.userModifier.On("ApproveAdminRequest", mock.Anything, id, approverID).
+		Return(&generated.AdminRequest{ID: id, Action: generated.AdminRequestActionAddAdmin, Status: generated.AdminRequestStatusApproved}, nil).Once()
+
+	_, err := s.userService.ApproveAdminRequest(ctx, id, approverID, generated.AdminScaleMajor)
+	require.NoError(t, err)
+
+	events := recordedEvents(t, s)
+	require.Len(t, events, 1)
+	assert.Equal(t, model.AuditActionApproveAdminRequest, events[0].Action)
+	assert.Equal(t, model.AuditOutcomeSuccess, events[0].Outcome)
+	assert.Equal(t, &approverID, events[0].ActorID)
+	assert.Equal(t, &targetID, events[0].TargetID)
+	assert.Equal(t, id.String(), events[0].Metadata["request_id"])
+	assert.Equal(t, string(generated.AdminRequestActionAddAdmin), events[0].Metadata["request_action"])
+}
+
+func TestAudit_RejectAdminRequestFailure(t *testing.T) {
+	t.Parallel()
+
+	s := createServiceWithConfig(t, model.AuthConfig{AdminApproval: true})
+	ctx := context.Background()
+
+	id, approverID := uuid.New(), uuid.New()
+
+	s.userProvider.On("GetAdminRequestByID", mock.Anything, id).Return(nil, model.ErrAdminRequestNotFound).Once()
+
+	_, err := s.userService.RejectAdminRequest(ctx, id, approverID, generated.AdminScaleMajor)
+	require.ErrorIs(t, err, model.ErrAdminRequestNotFound)
+
+	events := recordedEvents(t, s)
+	require.Len(t, events, 1)
+	assert.Equal(t, model.AuditActionRejectAdminRequest, events[0].Action)
+	assert.Equal(t, model.AuditOutcomeFailure, events[0].Outcome)
+	assert.Nil(t, events[0].TargetID)
+	assert.Equal(t, id.String(), events[0].Metadata["request_id"])
+}
+
+func TestAudit_DeleteUserPending(t *testing.T) {
+	t.Parallel()
+
+	s := createServiceWithConfig(t, model.AuthConfig{AdminApproval: true})
+	ctx := context.Background()
+
+	actorID, id := uuid.New(), uuid.New()
+
+	s.userProvider.On("GetUserAdminByID", mock.Anything, id).
+		Return(&generated.GetUserAdminByIDRow{ID: id, Scale: generated.NullAdminScale{AdminScale: generated.AdminScaleMinor, Valid: true}}, nil).Once()
+	s.userModifier.On("SaveAdminRequest", mock.Anything, mock.Anything).
+		Return(&generated.AdminRequest{ID: uuid.New()}, nil).Once()
+
+	err := s.userService.DeleteUser(ctx, actorID, id, generated.NullAdminScale{AdminScale: generated.AdminScaleMajor, Valid: true})
+	require.ErrorIs(t, err, model.ErrAdminChangePending)
+
+	events := recordedEvents(t, s)
+	require.Len(t, events, 1)
+	assert.Equal(t, model.AuditActionDeleteUser, events[0].Action)
+	assert.Equal(t, model.AuditOutcomePending, events[0].Outcome)
+	assert.Equal(t, &actorID, events[0].ActorID)
+	assert.Equal(t, &id, events[0].TargetID)
+}
+
+func TestAudit_SetPseudonym(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	actorID, id := uuid.New(), uuid.New()
+
+	s.userProvider.On("IsPseudonymTaken", mock.Anything, "metro", &id, mock.Anything).Return(false, nil).Once()
+	s.userModifier.On("UpdateUser", mock.Anything, mock.Anything, actorID, mock.Anything).
+		Return(&generated.User{ID: id, Pseudonym: "Metro"}, nil).Once()
+
+	_, err := s.userService.SetUserPseudonym(ctx, actorID, id, "Metro", generated.NullAdminScale{AdminScale: generated.AdminScaleMinor, Valid: true})
+	require.NoError(t, err)
+
+	events := recordedEvents(t, s)
+	require.Len(t, events, 1)
+	assert.Equal(t, model.AuditActionSetPseudonym, events[0].Action)
+	assert.Equal(t, model.AuditOutcomeSuccess, events[0].Outcome)
+	assert.Equal(t, &id, events[0].TargetID)
+	assert.Equal(t, "Metro", events[0].Metadata["pseudonym"])
+}
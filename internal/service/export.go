@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+// exportTimeout limits building of asynchronous export, it is not bound to request context.
+const exportTimeout = time.Minute
+
+// ExportUserData returns everything stored about user. Users can export own data, admins can export any user.
+func (s *UserService) ExportUserData(ctx context.Context, actorID, id uuid.UUID, scale generated.NullAdminScale) (*model.UserData, error) {
+	if actorID != id && !scale.Valid {
+		s.logger(ctx).Debug("only admin can export data of other users")
+		return nil, model.ErrUnauthorized
+	}
+
+	return s.exportUserData(ctx, id)
+}
+
+func (s *UserService) exportUserData(ctx context.Context, id uuid.UUID) (*model.UserData, error) {
+	data, err := s.userProvider.GetUserData(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error("failed to get user data", sl.Err(err))
+		return nil, err
+	}
+
+	sessions, err := s.refreshTokenProvider.GetSessions(ctx, id.String())
+	if err != nil {
+		s.logger(ctx).Error("failed to get sessions", sl.Err(err))
+		return nil, err
+	}
+
+	data.Sessions = sessions
+	data.ExportedAt = time.Now().UTC()
+
+	return data, nil
+}
+
+// RequestUserDataExport schedules building of export in background and returns token to download it with.
+func (s *UserService) RequestUserDataExport(ctx context.Context, actorID, id uuid.UUID, scale generated.NullAdminScale) (*string, error) {
+	if actorID != id && !scale.Valid {
+		s.logger(ctx).Debug("only admin can export data of other users")
+		return nil, model.ErrUnauthorized
+	}
+
+	token := uuid.NewString()
+	expiry := time.Minute * time.Duration(s.authConfig.ExportTTL)
+	export := model.Export{Status: model.ExportStatusPending, RequestedBy: actorID.String()}
+	if err := s.exportModifier.SaveExport(ctx, token, export, expiry); err != nil {
+		s.logger(ctx).Error("failed to save export", sl.Err(err))
+		return nil, err
+	}
+
+	err := s.exportScheduler.Schedule(ctx, func(ctx context.Context) {
+		s.buildExport(ctx, token, export, id, expiry)
+	})
+	if err != nil {
+		s.logger(ctx).Warn("failed to schedule export", sl.Err(err))
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (s *UserService) buildExport(ctx context.Context, token string, export model.Export, id uuid.UUID, expiry time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	export.Status = model.ExportStatusFailed
+
+	data, err := s.exportUserData(ctx, id)
+	if err == nil {
+		export.Data, err = json.Marshal(data)
+		if err == nil {
+			export.Status = model.ExportStatusReady
+		}
+	}
+
+	if err != nil {
+		s.logger(ctx).Error("failed to build export", sl.Err(err), slog.String("user_id", id.String()))
+	}
+
+	if err := s.exportModifier.SaveExport(ctx, token, export, expiry); err != nil {
+		s.logger(ctx).Error("failed to save export", sl.Err(err))
+	}
+}
+
+// GetUserDataExport returns serialized export, it is available only to the one who requested it.
+func (s *UserService) GetUserDataExport(ctx context.Context, actorID uuid.UUID, token string) ([]byte, error) {
+	export, err := s.exportProvider.GetExport(ctx, token)
+	if err != nil {
+		s.logger(ctx).Error("failed to get export", sl.Err(err))
+		return nil, err
+	}
+
+	if export.RequestedBy != actorID.String() {
+		s.logger(ctx).Debug("export requested by another user")
+		return nil, model.ErrExportNotFound
+	}
+
+	switch export.Status {
+	case model.ExportStatusPending:
+		return nil, model.ErrExportPending
+	case model.ExportStatusFailed:
+		return nil, model.ErrExportFailed
+	}
+
+	return export.Data, nil
+}
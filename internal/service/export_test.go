@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportUserData_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	sessions := []model.Session{{ExpiresAt: time.Now().Add(time.Hour)}}
+
+	s.userProvider.On("GetUserData", mock.Anything, id).
+		Return(&model.UserData{User: model.ExportUser{ID: id.String()}}, nil).Once()
+	s.refreshTokenProvider.On("GetSessions", mock.Anything, id.String()).
+		Return(sessions, nil).Once()
+
+	data, err := s.userService.ExportUserData(ctx, id, id, generated.NullAdminScale{})
+	require.NoError(t, err)
+	assert.Equal(t, id.String(), data.User.ID)
+	assert.Equal(t, sessions, data.Sessions)
+	assert.False(t, data.ExportedAt.IsZero())
+}
+
+func TestExportUserData_FailNotAdmin(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	_, err := s.userService.ExportUserData(ctx, uuid.New(), uuid.New(), generated.NullAdminScale{})
+	assert.ErrorIs(t, err, model.ErrUnauthorized)
+}
+
+func TestRequestUserDataExport_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	id := uuid.New()
+	admin := generated.NullAdminScale{AdminScale: generated.AdminScaleMinor, Valid: true}
+
+	s.exportModifier.On("SaveExport", mock.Anything, mock.Anything, model.Export{
+		Status:      model.ExportStatusPending,
+		RequestedBy: actorID.String(),
+	}, mock.Anything).Return(nil).Once()
+
+	s.userProvider.On("GetUserData", mock.Anything, id).
+		Return(&model.UserData{User: model.ExportUser{ID: id.String()}}, nil).Once()
+	s.refreshTokenProvider.On("GetSessions", mock.Anything, id.String()).
+		Return([]model.Session{}, nil).Once()
+
+	var built model.Export
+	s.exportModifier.On("SaveExport", mock.Anything, mock.Anything, mock.MatchedBy(func(export model.Export) bool {
+		return export.Status == model.ExportStatusReady
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		built = args.Get(2).(model.Export)
+	}).Return(nil).Once()
+
+	// scheduled export is built right away
+	s.exportScheduler.On("Schedule", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(func(context.Context))(context.Background())
+	}).Return(nil).Once()
+
+	token, err := s.userService.RequestUserDataExport(ctx, actorID, id, admin)
+	require.NoError(t, err)
+	assert.NoError(t, uuid.Validate(*token))
+
+	var data model.UserData
+	require.NoError(t, json.Unmarshal(built.Data, &data))
+	assert.Equal(t, id.String(), data.User.ID)
+}
+
+func TestRequestUserDataExport_FailUnavailable(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.exportModifier.On("SaveExport", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	s.exportScheduler.On("Schedule", mock.Anything, mock.Anything).Return(model.ErrExportUnavailable).Once()
+
+	_, err := s.userService.RequestUserDataExport(ctx, id, id, generated.NullAdminScale{})
+	assert.ErrorIs(t, err, model.ErrExportUnavailable)
+}
+
+func TestGetUserDataExport(t *testing.T) {
+	t.Parallel()
+
+	actorID := uuid.New()
+
+	tests := []struct {
+		name   string
+		export *model.Export
+		data   []byte
+		err    error
+	}{
+		{
+			name:   "ready",
+			export: &model.Export{Status: model.ExportStatusReady, RequestedBy: actorID.String(), Data: []byte("{}")},
+			data:   []byte("{}"),
+		},
+		{
+			name:   "pending",
+			export: &model.Export{Status: model.ExportStatusPending, RequestedBy: actorID.String()},
+			err:    model.ErrExportPending,
+		},
+		{
+			name:   "requested by another user",
+			export: &model.Export{Status: model.ExportStatusReady, RequestedBy: uuid.NewString()},
+			err:    model.ErrExportNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := createService(t)
+
+			s.exportProvider.On("GetExport", mock.Anything, "token").Return(tt.export, nil).Once()
+
+			data, err := s.userService.GetUserDataExport(context.Background(), actorID, "token")
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.data, data)
+		})
+	}
+}
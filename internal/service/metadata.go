@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/metadata"
+	"github.com/google/uuid"
+)
+
+func mustMetadataValidator(namespaces []model.MetadataNamespace) *metadata.Validator {
+	schemas := make(map[string]string, len(namespaces))
+	for _, namespace := range namespaces {
+		schemas[namespace.Name] = namespace.Schema
+	}
+
+	validator, err := metadata.New(schemas)
+	if err != nil {
+		panic("invalid metadata schema: " + err.Error())
+	}
+
+	return validator
+}
+
+// AuthenticateServiceClient returns id of service client the key belongs to.
+func (s *UserService) AuthenticateServiceClient(key string) (string, error) {
+	for _, client := range s.authConfig.ServiceClients {
+		if key != "" && subtle.ConstantTimeCompare([]byte(client.Key), []byte(key)) == 1 {
+			return client.ID, nil
+		}
+	}
+
+	return "", model.ErrServiceClientUnknown
+}
+
+func (s *UserService) GetUserMetadata(ctx context.Context, clientID string, userID uuid.UUID, namespace string) (*model.Metadata, error) {
+	if err := s.checkMetadataOwner(clientID, namespace); err != nil {
+		return nil, err
+	}
+
+	res, err := s.userProvider.GetUserMetadata(ctx, userID, namespace)
+	if err != nil {
+		if !errors.Is(err, model.ErrMetadataNotFound) {
+			s.logger(ctx).Error("failed to get user metadata", sl.Err(err))
+		}
+		return nil, err
+	}
+
+	return model.ToMetadata(*res), nil
+}
+
+// SetUserMetadata replaces namespace data of user.
+func (s *UserService) SetUserMetadata(ctx context.Context, clientID string, userID uuid.UUID, namespace string, data []byte) (*model.Metadata, error) {
+	return s.updateUserMetadata(ctx, clientID, userID, namespace, func([]byte) ([]byte, error) {
+		return data, nil
+	})
+}
+
+// PatchUserMetadata applies JSON Merge Patch to namespace data of user.
+func (s *UserService) PatchUserMetadata(ctx context.Context, clientID string, userID uuid.UUID, namespace string, patch []byte) (*model.Metadata, error) {
+	return s.updateUserMetadata(ctx, clientID, userID, namespace, func(current []byte) ([]byte, error) {
+		data, err := metadata.MergePatch(current, patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", model.ErrMetadataInvalid, err)
+		}
+
+		return data, nil
+	})
+}
+
+func (s *UserService) updateUserMetadata(ctx context.Context, clientID string, userID uuid.UUID, namespace string, update func([]byte) ([]byte, error)) (*model.Metadata, error) {
+	if err := s.checkMetadataOwner(clientID, namespace); err != nil {
+		return nil, err
+	}
+
+	res, err := s.userModifier.UpdateUserMetadata(ctx, userID, namespace, func(current []byte) ([]byte, error) {
+		data, err := update(current)
+		if err != nil {
+			return nil, err
+		}
+
+		return data, s.validateMetadata(namespace, data)
+	})
+	if err != nil {
+		if !errors.Is(err, model.ErrUserNotFound) && !errors.Is(err, model.ErrMetadataInvalid) && !errors.Is(err, model.ErrMetadataTooLarge) {
+			s.logger(ctx).Error("failed to update user metadata", sl.Err(err))
+		}
+		return nil, err
+	}
+
+	s.logger(ctx).Debug("user metadata updated", slog.String("user_id", userID.String()), slog.String("namespace", namespace))
+
+	return model.ToMetadata(*res), nil
+}
+
+func (s *UserService) checkMetadataOwner(clientID, namespace string) error {
+	for _, ns := range s.authConfig.MetadataNamespaces {
+		if ns.Name != namespace {
+			continue
+		}
+
+		if ns.Owner != clientID {
+			return model.ErrMetadataForbidden
+		}
+
+		return nil
+	}
+
+	return model.ErrMetadataNamespaceNotFound
+}
+
+func (s *UserService) validateMetadata(namespace string, data []byte) error {
+	if s.authConfig.MetadataMaxSize > 0 && len(data) > s.authConfig.MetadataMaxSize {
+		return model.ErrMetadataTooLarge
+	}
+
+	if err := s.metadataValidator.Validate(namespace, data); err != nil {
+		return fmt.Errorf("%w: %w", model.ErrMetadataInvalid, err)
+	}
+
+	return nil
+}
+
+// metadataClaims returns data of namespaces embedded in access token by namespace name.
+func (s *UserService) metadataClaims(ctx context.Context, userID uuid.UUID) (map[string]json.RawMessage, error) {
+	var namespaces []string
+	for _, ns := range s.authConfig.MetadataNamespaces {
+		if ns.Claims {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+
+	if len(namespaces) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.userProvider.GetUserMetadataByNamespaces(ctx, userID, namespaces)
+	if err != nil {
+		s.logger(ctx).Error("failed to get metadata claims", sl.Err(err))
+		return nil, err
+	}
+
+	res := make(map[string]json.RawMessage, len(rows))
+	for _, m := range rows {
+		res[m.Namespace] = m.Data
+	}
+
+	return res, nil
+}
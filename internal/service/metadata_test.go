@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createMetadataService(t *testing.T) dependencies {
+	t.Helper()
+
+	return createServiceWithConfig(t, model.AuthConfig{
+		Secret:          "secret",
+		AccessTokenTTL:  20,
+		RefreshTokenTTL: 4200,
+		ServiceClients: []model.ServiceClient{
+			{ID: "catalog", Key: "catalog-key"},
+			{ID: "billing", Key: "billing-key"},
+		},
+		MetadataNamespaces: []model.MetadataNamespace{
+			{
+				Name:   "catalog",
+				Owner:  "catalog",
+				Schema: `{"type": "object", "properties": {"verified": {"type": "boolean"}}}`,
+				Claims: true,
+			},
+			{Name: "billing", Owner: "billing"},
+		},
+		MetadataMaxSize: 64,
+	})
+}
+
+// applyMetadataUpdate emulates store applying update to current namespace data.
+func applyMetadataUpdate(current []byte) func(context.Context, uuid.UUID, string, func([]byte) ([]byte, error)) (*generated.UsersMetadatum, error) {
+	return func(_ context.Context, userID uuid.UUID, namespace string, update func([]byte) ([]byte, error)) (*generated.UsersMetadatum, error) {
+		data, err := update(current)
+		if err != nil {
+			return nil, err
+		}
+
+		return &generated.UsersMetadatum{UserID: userID, Namespace: namespace, Data: data}, nil
+	}
+}
+
+func TestAuthenticateServiceClient(t *testing.T) {
+	t.Parallel()
+
+	s := createMetadataService(t)
+
+	clientID, err := s.userService.AuthenticateServiceClient("billing-key")
+	require.NoError(t, err)
+	assert.Equal(t, "billing", clientID)
+
+	_, err = s.userService.AuthenticateServiceClient("unknown")
+	require.ErrorIs(t, err, model.ErrServiceClientUnknown)
+
+	_, err = s.userService.AuthenticateServiceClient("")
+	require.ErrorIs(t, err, model.ErrServiceClientUnknown)
+}
+
+func TestGetUserMetadata(t *testing.T) {
+	t.Parallel()
+
+	s := createMetadataService(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	s.userProvider.On("GetUserMetadata", mock.Anything, userID, "catalog").
+		Return(&generated.UsersMetadatum{Namespace: "catalog", Data: []byte(`{"verified": true}`)}, nil).Once()
+
+	res, err := s.userService.GetUserMetadata(ctx, "catalog", userID, "catalog")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"verified": true}`, string(res.Data))
+
+	_, err = s.userService.GetUserMetadata(ctx, "billing", userID, "catalog")
+	require.ErrorIs(t, err, model.ErrMetadataForbidden)
+
+	_, err = s.userService.GetUserMetadata(ctx, "catalog", userID, "unknown")
+	require.ErrorIs(t, err, model.ErrMetadataNamespaceNotFound)
+}
+
+func TestSetUserMetadata(t *testing.T) {
+	t.Parallel()
+
+	s := createMetadataService(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{name: "valid", data: `{"verified": true}`},
+		{name: "schema mismatch", data: `{"verified": "yes"}`, err: model.ErrMetadataInvalid},
+		{name: "not object", data: `true`, err: model.ErrMetadataInvalid},
+		{name: "too large", data: `{"verified": true, "description": "more than sixty four bytes of data"}`, err: model.ErrMetadataTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.userModifier.On("UpdateUserMetadata", mock.Anything, userID, "catalog", mock.Anything).
+				Return(applyMetadataUpdate([]byte(`{"verified": false}`))).Once()
+
+			res, err := s.userService.SetUserMetadata(ctx, "catalog", userID, "catalog", []byte(tt.data))
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.data, string(res.Data))
+		})
+	}
+}
+
+func TestPatchUserMetadata(t *testing.T) {
+	t.Parallel()
+
+	s := createMetadataService(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	s.userModifier.On("UpdateUserMetadata", mock.Anything, userID, "billing", mock.Anything).
+		Return(applyMetadataUpdate([]byte(`{"plan": "free", "trial": true}`))).Once()
+
+	res, err := s.userService.PatchUserMetadata(ctx, "billing", userID, "billing", []byte(`{"plan": "pro", "trial": null}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"plan": "pro"}`, string(res.Data))
+
+	s.userModifier.On("UpdateUserMetadata", mock.Anything, userID, "billing", mock.Anything).
+		Return(applyMetadataUpdate(nil)).Once()
+
+	_, err = s.userService.PatchUserMetadata(ctx, "billing", userID, "billing", []byte(`[1]`))
+	require.ErrorIs(t, err, model.ErrMetadataInvalid)
+
+	_, err = s.userService.PatchUserMetadata(ctx, "catalog", userID, "billing", []byte(`{}`))
+	require.ErrorIs(t, err, model.ErrMetadataForbidden)
+}
+
+func TestRefreshToken_MetadataClaims(t *testing.T) {
+	t.Parallel()
+
+	s := createMetadataService(t)
+	ctx := context.Background()
+
+	token := uuid.NewString()
+	userID := uuid.New()
+	id := userID.String()
+
+	s.refreshTokenProvider.On("GetRefreshToken", mock.Anything, token).Return(&id, nil).Once()
+	s.userProvider.On("GetUserAdminByID", mock.Anything, userID).
+		Return(&generated.GetUserAdminByIDRow{ID: userID}, nil).Once()
+	s.userProvider.On("GetUserMetadataByNamespaces", mock.Anything, userID, []string{"catalog"}).
+		Return([]generated.UsersMetadatum{{Namespace: "catalog", Data: []byte(`{"verified":true}`)}}, nil).Once()
+	s.refreshTokenModifier.On("ReplaceRefreshToken", mock.Anything, token, mock.Anything, id, mock.Anything).
+		Return(nil).Once()
+
+	accessToken, _, err := s.userService.RefreshToken(ctx, token)
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(*accessToken, claims, func(token *jwt.Token) (any, error) {
+		return []byte(s.userService.authConfig.Secret), nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"catalog": map[string]any{"verified": true}}, claims["metadata"])
+}
@@ -33,6 +33,24 @@ func (_m *RefreshTokenModifier) ReplaceRefreshToken(ctx context.Context, oldID s
 	return r0
 }
 
+// RevokeRefreshTokens provides a mock function with given fields: ctx, userID
+func (_m *RefreshTokenModifier) RevokeRefreshTokens(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeRefreshTokens")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetRefreshToken provides a mock function with given fields: ctx, userID, tokenID, expiry
 func (_m *RefreshTokenModifier) SetRefreshToken(ctx context.Context, userID string, tokenID string, expiry time.Duration) error {
 	ret := _m.Called(ctx, userID, tokenID, expiry)
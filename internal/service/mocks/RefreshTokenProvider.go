@@ -6,6 +6,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
 )
 
 // RefreshTokenProvider is an autogenerated mock type for the RefreshTokenProvider type
@@ -43,6 +45,36 @@ func (_m *RefreshTokenProvider) GetRefreshToken(ctx context.Context, tokenID str
 	return r0, r1
 }
 
+// GetSessions provides a mock function with given fields: ctx, userID
+func (_m *RefreshTokenProvider) GetSessions(ctx context.Context, userID string) ([]model.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessions")
+	}
+
+	var r0 []model.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewRefreshTokenProvider creates a new instance of RefreshTokenProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRefreshTokenProvider(t interface {
@@ -8,6 +8,10 @@ import (
 	generated "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
 	mock "github.com/stretchr/testify/mock"
 
+	model "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -16,6 +20,66 @@ type UserModifier struct {
 	mock.Mock
 }
 
+// AnonymizeDeletedUsers provides a mock function with given fields: ctx, deletedBefore
+func (_m *UserModifier) AnonymizeDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnonymizeDeletedUsers")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]uuid.UUID, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []uuid.UUID); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApproveAdminRequest provides a mock function with given fields: ctx, id, approverID
+func (_m *UserModifier) ApproveAdminRequest(ctx context.Context, id uuid.UUID, approverID uuid.UUID) (*generated.AdminRequest, error) {
+	ret := _m.Called(ctx, id, approverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveAdminRequest")
+	}
+
+	var r0 *generated.AdminRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*generated.AdminRequest, error)); ok {
+		return rf(ctx, id, approverID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *generated.AdminRequest); ok {
+		r0 = rf(ctx, id, approverID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.AdminRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, id, approverID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteAdmin provides a mock function with given fields: ctx, userID
 func (_m *UserModifier) DeleteAdmin(ctx context.Context, userID uuid.UUID) error {
 	ret := _m.Called(ctx, userID)
@@ -34,6 +98,128 @@ func (_m *UserModifier) DeleteAdmin(ctx context.Context, userID uuid.UUID) error
 	return r0
 }
 
+// DeleteUser provides a mock function with given fields: ctx, id, deletedBy
+func (_m *UserModifier) DeleteUser(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	ret := _m.Called(ctx, id, deletedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, id, deletedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpireAdminRequests provides a mock function with given fields: ctx
+func (_m *UserModifier) ExpireAdminRequests(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireAdminRequests")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishOutboxEvents provides a mock function with given fields: ctx, limit, publish
+func (_m *UserModifier) PublishOutboxEvents(ctx context.Context, limit int32, publish func(model.Event) error) (int, error) {
+	ret := _m.Called(ctx, limit, publish)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishOutboxEvents")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, func(model.Event) error) (int, error)); ok {
+		return rf(ctx, limit, publish)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, func(model.Event) error) int); ok {
+		r0 = rf(ctx, limit, publish)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, func(model.Event) error) error); ok {
+		r1 = rf(ctx, limit, publish)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectAdminRequest provides a mock function with given fields: ctx, id, approverID
+func (_m *UserModifier) RejectAdminRequest(ctx context.Context, id uuid.UUID, approverID uuid.UUID) (*generated.AdminRequest, error) {
+	ret := _m.Called(ctx, id, approverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectAdminRequest")
+	}
+
+	var r0 *generated.AdminRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*generated.AdminRequest, error)); ok {
+		return rf(ctx, id, approverID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *generated.AdminRequest); ok {
+		r0 = rf(ctx, id, approverID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.AdminRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, id, approverID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreUser provides a mock function with given fields: ctx, id, username
+func (_m *UserModifier) RestoreUser(ctx context.Context, id uuid.UUID, username string) error {
+	ret := _m.Called(ctx, id, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, id, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveAdmin provides a mock function with given fields: ctx, params
 func (_m *UserModifier) SaveAdmin(ctx context.Context, params generated.SaveAdminParams) error {
 	ret := _m.Called(ctx, params)
@@ -52,6 +238,36 @@ func (_m *UserModifier) SaveAdmin(ctx context.Context, params generated.SaveAdmi
 	return r0
 }
 
+// SaveAdminRequest provides a mock function with given fields: ctx, params
+func (_m *UserModifier) SaveAdminRequest(ctx context.Context, params generated.SaveAdminRequestParams) (*generated.AdminRequest, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAdminRequest")
+	}
+
+	var r0 *generated.AdminRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveAdminRequestParams) (*generated.AdminRequest, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SaveAdminRequestParams) *generated.AdminRequest); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.AdminRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.SaveAdminRequestParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveUser provides a mock function with given fields: ctx, user
 func (_m *UserModifier) SaveUser(ctx context.Context, user generated.SaveUserParams) (*uuid.UUID, error) {
 	ret := _m.Called(ctx, user)
@@ -82,9 +298,37 @@ func (_m *UserModifier) SaveUser(ctx context.Context, user generated.SaveUserPar
 	return r0, r1
 }
 
-// UpdateUser provides a mock function with given fields: ctx, user
-func (_m *UserModifier) UpdateUser(ctx context.Context, user generated.UpdateUserParams) (*generated.User, error) {
-	ret := _m.Called(ctx, user)
+// SyncTelegramProfile provides a mock function with given fields: ctx, params
+func (_m *UserModifier) SyncTelegramProfile(ctx context.Context, params generated.SyncTelegramProfileParams) (bool, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncTelegramProfile")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SyncTelegramProfileParams) (bool, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.SyncTelegramProfileParams) bool); ok {
+		r0 = rf(ctx, params)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.SyncTelegramProfileParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user, changedBy, cooldown
+func (_m *UserModifier) UpdateUser(ctx context.Context, user generated.UpdateUserParams, changedBy uuid.UUID, cooldown time.Duration) (*generated.User, error) {
+	ret := _m.Called(ctx, user, changedBy, cooldown)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUser")
@@ -92,19 +336,49 @@ func (_m *UserModifier) UpdateUser(ctx context.Context, user generated.UpdateUse
 
 	var r0 *generated.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, generated.UpdateUserParams) (*generated.User, error)); ok {
-		return rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, generated.UpdateUserParams, uuid.UUID, time.Duration) (*generated.User, error)); ok {
+		return rf(ctx, user, changedBy, cooldown)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, generated.UpdateUserParams) *generated.User); ok {
-		r0 = rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, generated.UpdateUserParams, uuid.UUID, time.Duration) *generated.User); ok {
+		r0 = rf(ctx, user, changedBy, cooldown)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*generated.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, generated.UpdateUserParams) error); ok {
-		r1 = rf(ctx, user)
+	if rf, ok := ret.Get(1).(func(context.Context, generated.UpdateUserParams, uuid.UUID, time.Duration) error); ok {
+		r1 = rf(ctx, user, changedBy, cooldown)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserMetadata provides a mock function with given fields: ctx, userID, namespace, update
+func (_m *UserModifier) UpdateUserMetadata(ctx context.Context, userID uuid.UUID, namespace string, update func(current []byte) ([]byte, error)) (*generated.UsersMetadatum, error) {
+	ret := _m.Called(ctx, userID, namespace, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserMetadata")
+	}
+
+	var r0 *generated.UsersMetadatum
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, func(current []byte) ([]byte, error)) (*generated.UsersMetadatum, error)); ok {
+		return rf(ctx, userID, namespace, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, func(current []byte) ([]byte, error)) *generated.UsersMetadatum); ok {
+		r0 = rf(ctx, userID, namespace, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.UsersMetadatum)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, func(current []byte) ([]byte, error)) error); ok {
+		r1 = rf(ctx, userID, namespace, update)
 	} else {
 		r1 = ret.Error(1)
 	}
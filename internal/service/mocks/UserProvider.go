@@ -10,6 +10,8 @@ import (
 
 	model "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
 
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -18,29 +20,116 @@ type UserProvider struct {
 	mock.Mock
 }
 
+// ExportUsers provides a mock function with given fields: ctx, params, fn
+func (_m *UserProvider) ExportUsers(ctx context.Context, params model.GetUsersParams, fn func(generated.User) error) error {
+	ret := _m.Called(ctx, params, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetUsersParams, func(generated.User) error) error); ok {
+		r0 = rf(ctx, params, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAdminRequestByID provides a mock function with given fields: ctx, id
+func (_m *UserProvider) GetAdminRequestByID(ctx context.Context, id uuid.UUID) (*generated.AdminRequest, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdminRequestByID")
+	}
+
+	var r0 *generated.AdminRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*generated.AdminRequest, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *generated.AdminRequest); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.AdminRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdminRequests provides a mock function with given fields: ctx, params
+func (_m *UserProvider) GetAdminRequests(ctx context.Context, params generated.GetAdminRequestsParams) ([]generated.AdminRequest, *uint64, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdminRequests")
+	}
+
+	var r0 []generated.AdminRequest
+	var r1 *uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, generated.GetAdminRequestsParams) ([]generated.AdminRequest, *uint64, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, generated.GetAdminRequestsParams) []generated.AdminRequest); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.AdminRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, generated.GetAdminRequestsParams) *uint64); ok {
+		r1 = rf(ctx, params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*uint64)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, generated.GetAdminRequestsParams) error); ok {
+		r2 = rf(ctx, params)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetAdmins provides a mock function with given fields: ctx, params
-func (_m *UserProvider) GetAdmins(ctx context.Context, params generated.GetAdminsParams) ([]generated.GetAdminsRow, *uint64, error) {
+func (_m *UserProvider) GetAdmins(ctx context.Context, params model.GetAdminsParams) ([]model.Admin, *uint64, error) {
 	ret := _m.Called(ctx, params)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAdmins")
 	}
 
-	var r0 []generated.GetAdminsRow
+	var r0 []model.Admin
 	var r1 *uint64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, generated.GetAdminsParams) ([]generated.GetAdminsRow, *uint64, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetAdminsParams) ([]model.Admin, *uint64, error)); ok {
 		return rf(ctx, params)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, generated.GetAdminsParams) []generated.GetAdminsRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.GetAdminsParams) []model.Admin); ok {
 		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]generated.GetAdminsRow)
+			r0 = ret.Get(0).([]model.Admin)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, generated.GetAdminsParams) *uint64); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, model.GetAdminsParams) *uint64); ok {
 		r1 = rf(ctx, params)
 	} else {
 		if ret.Get(1) != nil {
@@ -48,7 +137,7 @@ func (_m *UserProvider) GetAdmins(ctx context.Context, params generated.GetAdmin
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, generated.GetAdminsParams) error); ok {
+	if rf, ok := ret.Get(2).(func(context.Context, model.GetAdminsParams) error); ok {
 		r2 = rf(ctx, params)
 	} else {
 		r2 = ret.Error(2)
@@ -57,6 +146,66 @@ func (_m *UserProvider) GetAdmins(ctx context.Context, params generated.GetAdmin
 	return r0, r1, r2
 }
 
+// GetLegacyUserAdminByUsername provides a mock function with given fields: ctx, username
+func (_m *UserProvider) GetLegacyUserAdminByUsername(ctx context.Context, username string) (*generated.GetUserAdminByUsernameRow, error) {
+	ret := _m.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLegacyUserAdminByUsername")
+	}
+
+	var r0 *generated.GetUserAdminByUsernameRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*generated.GetUserAdminByUsernameRow, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *generated.GetUserAdminByUsernameRow); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.GetUserAdminByUsernameRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRestorableUser provides a mock function with given fields: ctx, telegramID, username, deletedAfter
+func (_m *UserProvider) GetRestorableUser(ctx context.Context, telegramID *int64, username string, deletedAfter time.Time) (*generated.GetRestorableUserRow, error) {
+	ret := _m.Called(ctx, telegramID, username, deletedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRestorableUser")
+	}
+
+	var r0 *generated.GetRestorableUserRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, string, time.Time) (*generated.GetRestorableUserRow, error)); ok {
+		return rf(ctx, telegramID, username, deletedAfter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, string, time.Time) *generated.GetRestorableUserRow); ok {
+		r0 = rf(ctx, telegramID, username, deletedAfter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.GetRestorableUserRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, string, time.Time) error); ok {
+		r1 = rf(ctx, telegramID, username, deletedAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserAdminByID provides a mock function with given fields: ctx, id
 func (_m *UserProvider) GetUserAdminByID(ctx context.Context, id uuid.UUID) (*generated.GetUserAdminByIDRow, error) {
 	ret := _m.Called(ctx, id)
@@ -87,6 +236,36 @@ func (_m *UserProvider) GetUserAdminByID(ctx context.Context, id uuid.UUID) (*ge
 	return r0, r1
 }
 
+// GetUserAdminByTelegramID provides a mock function with given fields: ctx, telegramID
+func (_m *UserProvider) GetUserAdminByTelegramID(ctx context.Context, telegramID int64) (*generated.GetUserAdminByTelegramIDRow, error) {
+	ret := _m.Called(ctx, telegramID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserAdminByTelegramID")
+	}
+
+	var r0 *generated.GetUserAdminByTelegramIDRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*generated.GetUserAdminByTelegramIDRow, error)); ok {
+		return rf(ctx, telegramID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *generated.GetUserAdminByTelegramIDRow); ok {
+		r0 = rf(ctx, telegramID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.GetUserAdminByTelegramIDRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, telegramID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserAdminByUsername provides a mock function with given fields: ctx, username
 func (_m *UserProvider) GetUserAdminByUsername(ctx context.Context, username string) (*generated.GetUserAdminByUsernameRow, error) {
 	ret := _m.Called(ctx, username)
@@ -147,6 +326,96 @@ func (_m *UserProvider) GetUserByID(ctx context.Context, id uuid.UUID) (*generat
 	return r0, r1
 }
 
+// GetUserData provides a mock function with given fields: ctx, id
+func (_m *UserProvider) GetUserData(ctx context.Context, id uuid.UUID) (*model.UserData, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserData")
+	}
+
+	var r0 *model.UserData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*model.UserData, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *model.UserData); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserMetadata provides a mock function with given fields: ctx, userID, namespace
+func (_m *UserProvider) GetUserMetadata(ctx context.Context, userID uuid.UUID, namespace string) (*generated.UsersMetadatum, error) {
+	ret := _m.Called(ctx, userID, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserMetadata")
+	}
+
+	var r0 *generated.UsersMetadatum
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (*generated.UsersMetadatum, error)); ok {
+		return rf(ctx, userID, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) *generated.UsersMetadatum); ok {
+		r0 = rf(ctx, userID, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*generated.UsersMetadatum)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, userID, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserMetadataByNamespaces provides a mock function with given fields: ctx, userID, namespaces
+func (_m *UserProvider) GetUserMetadataByNamespaces(ctx context.Context, userID uuid.UUID, namespaces []string) ([]generated.UsersMetadatum, error) {
+	ret := _m.Called(ctx, userID, namespaces)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserMetadataByNamespaces")
+	}
+
+	var r0 []generated.UsersMetadatum
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) ([]generated.UsersMetadatum, error)); ok {
+		return rf(ctx, userID, namespaces)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []string) []generated.UsersMetadatum); ok {
+		r0 = rf(ctx, userID, namespaces)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.UsersMetadatum)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, []string) error); ok {
+		r1 = rf(ctx, userID, namespaces)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsers provides a mock function with given fields: ctx, params
 func (_m *UserProvider) GetUsers(ctx context.Context, params model.GetUsersParams) ([]generated.User, *uint64, error) {
 	ret := _m.Called(ctx, params)
@@ -186,6 +455,64 @@ func (_m *UserProvider) GetUsers(ctx context.Context, params model.GetUsersParam
 	return r0, r1, r2
 }
 
+// GetUsersByIDs provides a mock function with given fields: ctx, ids
+func (_m *UserProvider) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]generated.User, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersByIDs")
+	}
+
+	var r0 []generated.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]generated.User, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []generated.User); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]generated.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsPseudonymTaken provides a mock function with given fields: ctx, skeleton, userID, releasedAfter
+func (_m *UserProvider) IsPseudonymTaken(ctx context.Context, skeleton string, userID *uuid.UUID, releasedAfter time.Time) (bool, error) {
+	ret := _m.Called(ctx, skeleton, userID, releasedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsPseudonymTaken")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *uuid.UUID, time.Time) (bool, error)); ok {
+		return rf(ctx, skeleton, userID, releasedAfter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *uuid.UUID, time.Time) bool); ok {
+		r0 = rf(ctx, skeleton, userID, releasedAfter)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, skeleton, userID, releasedAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserProvider creates a new instance of UserProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserProvider(t interface {
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+)
+
+func (s *UserService) CreateOrganization(ctx context.Context, ownerID uuid.UUID, name string) (*generated.Organization, error) {
+	organization, err := s.organizationModifier.CreateOrganization(ctx, name, ownerID)
+	if err != nil {
+		s.logger(ctx).Error("failed to create organization", sl.Err(err))
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (s *UserService) GetUserOrganizations(ctx context.Context, userID uuid.UUID) ([]generated.GetUserOrganizationsRow, error) {
+	return s.organizationProvider.GetUserOrganizations(ctx, userID)
+}
+
+func (s *UserService) InviteOrganizationMember(ctx context.Context, actorID, organizationID uuid.UUID, username string, role generated.OrganizationRole) error {
+	actor, err := s.organizationProvider.GetOrganizationMember(ctx, organizationID, actorID)
+	if err != nil {
+		s.logger(ctx).Error("failed to get organization member", sl.Err(err))
+		return err
+	}
+
+	// Owner can invite admins and members, admin can invite only members
+	switch {
+	case role == generated.OrganizationRoleOwner:
+		return model.ErrOrganizationRoleForbidden
+	case actor.Role == generated.OrganizationRoleOwner:
+	case actor.Role == generated.OrganizationRoleAdmin && role == generated.OrganizationRoleMember:
+	default:
+		s.logger(ctx).Debug("organization role does not allow invite", slog.String("role", string(actor.Role)))
+		return model.ErrOrganizationRoleForbidden
+	}
+
+	user, err := s.userProvider.GetUserAdminByUsername(ctx, username)
+	if err != nil {
+		s.logger(ctx).Error("failed to get user", sl.Err(err))
+		return err
+	}
+
+	_, err = s.organizationProvider.GetOrganizationMember(ctx, organizationID, user.ID)
+	if err == nil {
+		return model.ErrOrganizationMemberExists
+	} else if !errors.Is(err, model.ErrOrganizationMemberNotFound) {
+		s.logger(ctx).Error("failed to get organization member", sl.Err(err))
+		return err
+	}
+
+	err = s.organizationModifier.SaveOrganizationMember(ctx, generated.SaveOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Role:           role,
+	})
+	if err != nil {
+		s.logger(ctx).Error("failed to save organization member", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *UserService) RemoveOrganizationMember(ctx context.Context, actorID, organizationID, userID uuid.UUID) error {
+	member, err := s.organizationProvider.GetOrganizationMember(ctx, organizationID, userID)
+	if err != nil {
+		s.logger(ctx).Error("failed to get organization member", sl.Err(err))
+		return err
+	}
+
+	if member.Role == generated.OrganizationRoleOwner {
+		return model.ErrCannotRemoveOwner
+	}
+
+	// Anyone can leave organization
+	if actorID != userID {
+		actor, err := s.organizationProvider.GetOrganizationMember(ctx, organizationID, actorID)
+		if err != nil {
+			s.logger(ctx).Error("failed to get organization member", sl.Err(err))
+			return err
+		}
+
+		if actor.Role != generated.OrganizationRoleOwner &&
+			(actor.Role != generated.OrganizationRoleAdmin || member.Role != generated.OrganizationRoleMember) {
+			s.logger(ctx).Debug("organization role does not allow removal", slog.String("role", string(actor.Role)))
+			return model.ErrOrganizationRoleForbidden
+		}
+	}
+
+	err = s.organizationModifier.DeleteOrganizationMember(ctx, generated.DeleteOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		s.logger(ctx).Error("failed to delete organization member", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+// OrganizationToken issues access token scoped to organization with tenant claims.
+func (s *UserService) OrganizationToken(ctx context.Context, userID, organizationID uuid.UUID) (*string, error) {
+	member, err := s.organizationProvider.GetOrganizationMember(ctx, organizationID, userID)
+	if err != nil {
+		s.logger(ctx).Error("failed to get organization member", sl.Err(err))
+		return nil, err
+	}
+
+	user, err := s.userProvider.GetUserAdminByID(ctx, userID)
+	if err != nil {
+		s.logger(ctx).Error("failed to get user", sl.Err(err))
+		return nil, err
+	}
+
+	claims, err := s.accessTokenClaims(ctx, user.ID, user.Scale, time.Duration(s.authConfig.AccessTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+	claims["tenant"] = member.OrganizationID
+	claims["tenant_role"] = member.Role
+
+	accessToken, err := s.signToken(claims)
+	if err != nil {
+		s.logger(ctx).Error("failed to generate token", sl.Err(err))
+		return nil, err
+	}
+
+	return accessToken, nil
+}
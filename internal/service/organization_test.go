@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInviteOrganizationMember_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	organizationID := uuid.New()
+	userID := uuid.New()
+	username := "qwerty"
+
+	s.organizationProvider.On("GetOrganizationMember", mock.Anything, organizationID, actorID).
+		Return(&generated.OrganizationMember{Role: generated.OrganizationRoleOwner}, nil).Once()
+	s.userProvider.On("GetUserAdminByUsername", mock.Anything, username).
+		Return(&generated.GetUserAdminByUsernameRow{ID: userID}, nil).Once()
+	s.organizationProvider.On("GetOrganizationMember", mock.Anything, organizationID, userID).
+		Return(nil, model.ErrOrganizationMemberNotFound).Once()
+	s.organizationModifier.On("SaveOrganizationMember", mock.Anything, generated.SaveOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           generated.OrganizationRoleAdmin,
+	}).Return(nil).Once()
+
+	err := s.userService.InviteOrganizationMember(ctx, actorID, organizationID, username, generated.OrganizationRoleAdmin)
+	require.NoError(t, err)
+}
+
+func TestInviteOrganizationMember_FailRoleForbidden(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	organizationID := uuid.New()
+
+	s.organizationProvider.On("GetOrganizationMember", mock.Anything, organizationID, actorID).
+		Return(&generated.OrganizationMember{Role: generated.OrganizationRoleAdmin}, nil).Once()
+
+	err := s.userService.InviteOrganizationMember(ctx, actorID, organizationID, "qwerty", generated.OrganizationRoleAdmin)
+	assert.ErrorIs(t, err, model.ErrOrganizationRoleForbidden)
+}
+
+func TestRemoveOrganizationMember_FailCannotRemoveOwner(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	organizationID := uuid.New()
+	userID := uuid.New()
+
+	s.organizationProvider.On("GetOrganizationMember", mock.Anything, organizationID, userID).
+		Return(&generated.OrganizationMember{Role: generated.OrganizationRoleOwner}, nil).Once()
+
+	err := s.userService.RemoveOrganizationMember(ctx, uuid.New(), organizationID, userID)
+	assert.ErrorIs(t, err, model.ErrCannotRemoveOwner)
+}
+
+func TestRemoveOrganizationMember_SuccessLeave(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	organizationID := uuid.New()
+	userID := uuid.New()
+
+	s.organizationProvider.On("GetOrganizationMember", mock.Anything, organizationID, userID).
+		Return(&generated.OrganizationMember{Role: generated.OrganizationRoleMember}, nil).Once()
+	s.organizationModifier.On("DeleteOrganizationMember", mock.Anything, generated.DeleteOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	}).Return(nil).Once()
+
+	err := s.userService.RemoveOrganizationMember(ctx, userID, organizationID, userID)
+	require.NoError(t, err)
+}
+
+func TestOrganizationToken_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	organizationID := uuid.New()
+	userID := uuid.New()
+
+	s.organizationProvider.On("GetOrganizationMember", mock.Anything, organizationID, userID).
+		Return(&generated.OrganizationMember{
+			OrganizationID: organizationID,
+			UserID:         userID,
+			Role:           generated.OrganizationRoleAdmin,
+		}, nil).Once()
+	s.userProvider.On("GetUserAdminByID", mock.Anything, userID).
+		Return(&generated.GetUserAdminByIDRow{ID: userID}, nil).Once()
+
+	accessToken, err := s.userService.OrganizationToken(ctx, userID, organizationID)
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(*accessToken, claims, func(token *jwt.Token) (any, error) {
+		return []byte(s.userService.authConfig.Secret), nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, userID.String(), claims["id"])
+	assert.Equal(t, organizationID.String(), claims["tenant"])
+	assert.Equal(t, string(generated.OrganizationRoleAdmin), claims["tenant_role"])
+}
+
+func TestOrganizationToken_FailNotMember(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	s.organizationProvider.On("GetOrganizationMember", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, model.ErrOrganizationMemberNotFound).Once()
+
+	_, err := s.userService.OrganizationToken(ctx, uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, model.ErrOrganizationMemberNotFound)
+}
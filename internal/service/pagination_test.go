@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createUsers(n int) []generated.User {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	users := make([]generated.User, 0, n)
+	for i := range n {
+		users = append(users, generated.User{
+			ID:        uuid.New(),
+			CreatedAt: pgtype.Timestamp{Time: start.Add(time.Duration(i) * time.Second), Valid: true},
+		})
+	}
+
+	return users
+}
+
+func TestGetUsers_CursorPagination(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+	users := createUsers(5)
+
+	// first page, one extra user tells that next page exists
+	params := model.GetUsersParams{Limit: 2, PageParams: model.PageParams{UseCursor: true, SkipTotal: true}}
+	expected := params
+	expected.Sort = model.Sort{{Field: "created_at", Order: model.OrderAsc}, {Field: "id", Order: model.OrderAsc}}
+	s.userProvider.On("GetUsers", mock.Anything, expected).Return(users[:3], nil, nil).Once()
+
+	res, page, err := s.userService.GetUsers(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, users[:2], res)
+	assert.Nil(t, page.Total)
+	assert.Nil(t, page.PrevCursor)
+	require.NotNil(t, page.NextCursor)
+
+	next, err := model.DecodeCursor(*page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Sort, next.Sort)
+	assert.Equal(t, []string{"2024-01-01T00:00:01Z", users[1].ID.String()}, next.Values)
+	assert.False(t, next.Backward)
+
+	// last page has previous page only
+	params.Cursor = next
+	expected.Cursor = next
+	s.userProvider.On("GetUsers", mock.Anything, expected).Return(users[2:4], nil, nil).Once()
+
+	res, page, err = s.userService.GetUsers(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, users[2:4], res)
+	assert.Nil(t, page.NextCursor)
+	require.NotNil(t, page.PrevCursor)
+
+	prev, err := model.DecodeCursor(*page.PrevCursor)
+	require.NoError(t, err)
+	assert.Equal(t, users[2].ID.String(), prev.Values[1])
+	assert.True(t, prev.Backward)
+
+	// backward page is read in reverse order
+	params.Cursor = prev
+	expected.Cursor = prev
+	s.userProvider.On("GetUsers", mock.Anything, expected).Return([]generated.User{users[1], users[0]}, nil, nil).Once()
+
+	res, page, err = s.userService.GetUsers(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, users[:2], res)
+	assert.Nil(t, page.PrevCursor)
+	require.NotNil(t, page.NextCursor)
+}
+
+func TestGetUsers_CursorPaginationFail(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	query := "metr"
+	cursor := &model.Cursor{
+		Sort:   model.Sort{{Field: "username", Order: model.OrderAsc}, {Field: "id", Order: model.OrderAsc}},
+		Values: []string{"bob", uuid.NewString()},
+	}
+
+	tests := []struct {
+		name   string
+		params model.GetUsersParams
+		err    error
+	}{
+		{
+			name:   "search",
+			params: model.GetUsersParams{Query: &query, Limit: 10, PageParams: model.PageParams{UseCursor: true}},
+			err:    model.ErrCursorNotSupported,
+		},
+		{
+			name: "unknown field",
+			params: model.GetUsersParams{
+				Sort:       model.Sort{{Field: "is_premium", Order: model.OrderAsc}},
+				Limit:      10,
+				PageParams: model.PageParams{UseCursor: true},
+			},
+			err: model.ErrOrderByInvalidField,
+		},
+		{
+			name: "cursor of another order",
+			params: model.GetUsersParams{
+				Sort:       model.Sort{{Field: "username", Order: model.OrderDesc}},
+				Limit:      10,
+				PageParams: model.PageParams{UseCursor: true, Cursor: cursor},
+			},
+			err: model.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := s.userService.GetUsers(ctx, tt.params)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestGetAdmins_CursorPagination(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	admins := []model.Admin{
+		{ID: uuid.New(), CreatedAt: time.Now().Add(-time.Hour)},
+		{ID: uuid.New(), CreatedAt: time.Now()},
+	}
+	total := uint64(2)
+
+	params := model.GetAdminsParams{Limit: 1, PageParams: model.PageParams{UseCursor: true}}
+	expected := params
+	expected.Sort = model.Sort{{Field: "created_at", Order: model.OrderAsc}, {Field: "id", Order: model.OrderAsc}}
+	s.userProvider.On("GetAdmins", mock.Anything, expected).Return(admins, &total, nil).Once()
+
+	res, page, err := s.userService.GetAdmins(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, admins[:1], res)
+	assert.Equal(t, total, *page.Total)
+	require.NotNil(t, page.NextCursor)
+
+	next, err := model.DecodeCursor(*page.NextCursor)
+	require.NoError(t, err)
+	values, err := next.SortValues()
+	require.NoError(t, err)
+	assert.True(t, admins[0].CreatedAt.Equal(values[0].(time.Time)))
+	assert.Equal(t, admins[0].ID, values[1])
+
+	_, _, err = s.userService.GetAdmins(ctx, model.GetAdminsParams{
+		Limit: 1,
+		PageParams: model.PageParams{UseCursor: true, Cursor: &model.Cursor{
+			Sort:   model.Sort{{Field: "username", Order: model.OrderAsc}, {Field: "id", Order: model.OrderAsc}},
+			Values: []string{"bob", uuid.NewString()},
+		}},
+	})
+	require.ErrorIs(t, err, model.ErrInvalidCursor)
+}
+
+func TestGetUsers_SortAddsTieBreaker(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	params := model.GetUsersParams{
+		Sort:  model.Sort{{Field: "last_name", Order: "DESC"}, {Field: "first_name", Order: model.OrderAsc}},
+		Limit: 10,
+	}
+	expected := params
+	expected.Sort = model.Sort{
+		{Field: "last_name", Order: model.OrderDesc},
+		{Field: "first_name", Order: model.OrderAsc},
+		{Field: "id", Order: model.OrderAsc},
+	}
+	total := uint64(0)
+	s.userProvider.On("GetUsers", mock.Anything, expected).Return(nil, &total, nil).Once()
+
+	_, _, err := s.userService.GetUsers(ctx, params)
+	require.NoError(t, err)
+}
+
+func TestGetAdmins_Sort(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	sort, err := model.ParseSort("scale desc, username", model.AdminSortFields)
+	require.NoError(t, err)
+
+	params := model.GetAdminsParams{Sort: sort, Limit: 10}
+	expected := params
+	expected.Sort = model.Sort{
+		{Field: "scale", Order: model.OrderDesc},
+		{Field: "username", Order: model.OrderAsc},
+		{Field: "id", Order: model.OrderAsc},
+	}
+	total := uint64(0)
+	s.userProvider.On("GetAdmins", mock.Anything, expected).Return(nil, &total, nil).Once()
+
+	_, _, err = s.userService.GetAdmins(ctx, params)
+	require.NoError(t, err)
+}
+
+func TestParseSort_Fail(t *testing.T) {
+	t.Parallel()
+
+	for _, spec := range []string{
+		"created_at up",
+		"created_at, created_at desc",
+		"is_premium",
+		"username asc desc",
+		"username,",
+		"id, username, pseudonym, first_name, last_name",
+	} {
+		t.Run(spec, func(t *testing.T) {
+			_, err := model.ParseSort(spec, model.UserSortFields)
+			require.ErrorIs(t, err, model.ErrOrderByInvalidField)
+		})
+	}
+}
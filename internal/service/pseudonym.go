@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/pseudonym"
+	"github.com/google/uuid"
+)
+
+// validatePseudonym checks pseudonym policy and that no other user has or recently had pseudonym
+// with the same skeleton. It returns normalized pseudonym and its skeleton.
+func (s *UserService) validatePseudonym(ctx context.Context, value string, userID *uuid.UUID) (normalized, skeleton string, err error) {
+	normalized, skeleton, err = s.pseudonymPolicy.Validate(value)
+	if err != nil {
+		s.logger(ctx).Debug("pseudonym not allowed", sl.Err(err))
+		if errors.Is(err, pseudonym.ErrEmpty) {
+			return "", "", model.ErrEmptyPseudonym
+		}
+		return "", "", fmt.Errorf("%w: %w", model.ErrPseudonymNotAllowed, err)
+	}
+
+	taken, err := s.userProvider.IsPseudonymTaken(ctx, skeleton, userID, s.formerPseudonymsAfter())
+	if err != nil {
+		s.logger(ctx).Error("failed to check pseudonym", sl.Err(err))
+		return "", "", err
+	}
+
+	if taken {
+		s.logger(ctx).Debug("pseudonym already taken")
+		return "", "", model.ErrPseudonymTaken
+	}
+
+	return normalized, skeleton, nil
+}
+
+// CheckPseudonymAvailability returns normalized pseudonym if it can be taken,
+// userID is optional and excludes caller's own pseudonym from the check.
+func (s *UserService) CheckPseudonymAvailability(ctx context.Context, userID *uuid.UUID, value string) (*string, error) {
+	normalized, _, err := s.validatePseudonym(ctx, value, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &normalized, nil
+}
+
+// formerPseudonymsAfter returns time after which released pseudonyms still belong to their previous owner.
+func (s *UserService) formerPseudonymsAfter() time.Time {
+	return time.Now().Add(-time.Minute * time.Duration(s.authConfig.FormerPseudonymTTL))
+}
+
+func (s *UserService) isPseudonymChanged(ctx context.Context, id uuid.UUID, value string) (bool, error) {
+	user, err := s.userProvider.GetUserByID(ctx, id)
+	if err != nil {
+		s.logger(ctx).Error("failed to get user", sl.Err(err))
+		return false, err
+	}
+
+	return pseudonym.Normalize(value) != user.Pseudonym, nil
+}
+
+// SetUserPseudonym lets admin change pseudonym of any user regardless of cooldown,
+// e.g. to resolve impersonation. Change is recorded in history as made by admin.
+func (s *UserService) SetUserPseudonym(ctx context.Context, actorID, id uuid.UUID, value string, scale generated.NullAdminScale) (user *generated.User, err error) {
+	defer func() {
+		s.audit(ctx, model.AuditActionSetPseudonym, err, &actorID, &id, map[string]string{"pseudonym": value})
+	}()
+
+	if !scale.Valid {
+		s.logger(ctx).Debug("only admin can override pseudonym")
+		return nil, model.ErrUnauthorized
+	}
+
+	normalized, skeleton, err := s.validatePseudonym(ctx, value, &id)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err = s.userModifier.UpdateUser(ctx, generated.UpdateUserParams{
+		ID:                id,
+		Pseudonym:         &normalized,
+		PseudonymSkeleton: &skeleton,
+	}, actorID, 0)
+	if err != nil {
+		s.logger(ctx).Error("failed to update user", sl.Err(err))
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/pseudonym"
+	userv1 "github.com/MAXXXIMUS-tropical-milkshake/beatflow-protos/gen/go/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateUser_NormalizesPseudonym(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	value := "  Metro   Boomin "
+	normalized := "Metro Boomin"
+	skeleton := "metroboomin"
+
+	s.userProvider.On("GetUserByID", mock.Anything, id).Return(&generated.User{ID: id, Pseudonym: "Metro"}, nil).Once()
+	s.userProvider.On("IsPseudonymTaken", mock.Anything, skeleton, &id, mock.Anything).Return(false, nil).Once()
+	s.userModifier.On("UpdateUser", mock.Anything, generated.UpdateUserParams{
+		ID:                id,
+		Pseudonym:         &normalized,
+		PseudonymSkeleton: &skeleton,
+	}, id, time.Duration(0)).Return(&generated.User{ID: id, Pseudonym: normalized}, nil).Once()
+
+	user, err := s.userService.UpdateUser(ctx, generated.UpdateUserParams{ID: id, Pseudonym: &value})
+	require.NoError(t, err)
+	assert.Equal(t, normalized, user.Pseudonym)
+}
+
+func TestUpdateUser_FailPseudonymCooldown(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	s.userService.authConfig.PseudonymCooldown = 60
+	ctx := context.Background()
+
+	id := uuid.New()
+	value := "Metro Boomin"
+
+	s.userProvider.On("GetUserByID", mock.Anything, id).Return(&generated.User{ID: id, Pseudonym: "Metro"}, nil).Once()
+	s.userProvider.On("IsPseudonymTaken", mock.Anything, "metroboomin", &id, mock.Anything).Return(false, nil).Once()
+	// cooldown is checked by store under lock of user row
+	s.userModifier.On("UpdateUser", mock.Anything, mock.Anything, id, time.Hour).Return(nil, model.ErrPseudonymCooldown).Once()
+
+	_, err := s.userService.UpdateUser(ctx, generated.UpdateUserParams{ID: id, Pseudonym: &value})
+	assert.ErrorIs(t, err, model.ErrPseudonymCooldown)
+}
+
+func TestUpdateUser_SamePseudonymSkipsCooldown(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	value := " Metro "
+	firstName := "Leland"
+
+	s.userProvider.On("GetUserByID", mock.Anything, id).Return(&generated.User{ID: id, Pseudonym: "Metro"}, nil).Once()
+	s.userModifier.On("UpdateUser", mock.Anything, generated.UpdateUserParams{ID: id, FirstName: &firstName}, id, mock.Anything).
+		Return(&generated.User{ID: id}, nil).Once()
+
+	_, err := s.userService.UpdateUser(ctx, generated.UpdateUserParams{ID: id, Pseudonym: &value, FirstName: &firstName})
+	require.NoError(t, err)
+}
+
+func TestUpdateUser_MaskClearsField(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	firstName := "Leland"
+	req := &userv1.UpdateUserRequest{FirstName: &firstName, Pseudonym: &firstName}
+
+	mask, err := model.ParseFieldMask("first_name, last_name", req)
+	require.NoError(t, err)
+
+	params := model.ToDomainUpdateUserParams(id, req)
+	require.NoError(t, model.ApplyUpdateMask(params, req, mask))
+
+	var got generated.UpdateUserParams
+	s.userModifier.On("UpdateUser", mock.Anything, mock.Anything, id, time.Duration(0)).Run(func(args mock.Arguments) {
+		got = args.Get(1).(generated.UpdateUserParams)
+	}).Return(&generated.User{ID: id, FirstName: firstName}, nil).Once()
+
+	_, err = s.userService.UpdateUser(ctx, *params)
+	require.NoError(t, err)
+
+	// masked last name is cleared and pseudonym outside of mask is left as is
+	empty := ""
+	assert.Equal(t, generated.UpdateUserParams{ID: id, FirstName: &firstName, LastName: &empty}, got)
+	s.userProvider.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func TestSetUserPseudonym_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	id := uuid.New()
+	admin := generated.NullAdminScale{AdminScale: generated.AdminScaleMinor, Valid: true}
+
+	s.userProvider.On("IsPseudonymTaken", mock.Anything, "metro", &id, mock.Anything).Return(false, nil).Once()
+	s.userModifier.On("UpdateUser", mock.Anything, mock.MatchedBy(func(params generated.UpdateUserParams) bool {
+		return params.ID == id && *params.Pseudonym == "Metro"
+	}), actorID, time.Duration(0)).Return(&generated.User{ID: id, Pseudonym: "Metro"}, nil).Once()
+
+	_, err := s.userService.SetUserPseudonym(ctx, actorID, id, "Metro", admin)
+	require.NoError(t, err)
+
+	_, err = s.userService.SetUserPseudonym(ctx, actorID, id, "Metro", generated.NullAdminScale{})
+	assert.ErrorIs(t, err, model.ErrUnauthorized)
+}
+
+func TestGetUsers_ResolvesFormerPseudonym(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+	former := "Metro"
+	params := model.GetUsersParams{Pseudonym: &former, Limit: 10}
+
+	total := uint64(1)
+	s.userProvider.On("GetUsers", mock.Anything, mock.MatchedBy(func(params model.GetUsersParams) bool {
+		return params.FormerPseudonym != nil && params.FormerPseudonym.Skeleton == "metro" &&
+			time.Until(params.FormerPseudonym.ChangedAfter) < 0
+	})).Return([]generated.User{{ID: id, Pseudonym: "Metro Boomin"}}, &total, nil).Once()
+
+	users, page, err := s.userService.GetUsers(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, id, users[0].ID)
+	assert.Equal(t, uint64(1), *page.Total)
+}
+
+func TestCheckPseudonymAvailability(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		pseudonym string
+		taken     *bool
+		err       error
+	}{
+		{name: "available", pseudonym: "metro", taken: new(bool)},
+		{name: "reserved", pseudonym: "Аdmin", err: pseudonym.ErrReserved},
+		{name: "empty", pseudonym: " ", err: model.ErrEmptyPseudonym},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := createService(t)
+			s.userService.pseudonymPolicy = pseudonym.New([]string{"admin"}, nil)
+
+			if tt.taken != nil {
+				s.userProvider.On("IsPseudonymTaken", mock.Anything, mock.Anything, (*uuid.UUID)(nil), mock.Anything).
+					Return(*tt.taken, nil).Once()
+			}
+
+			_, err := s.userService.CheckPseudonymAvailability(context.Background(), nil, tt.pseudonym)
+			assert.ErrorIs(t, err, tt.err)
+			if tt.err != nil && tt.err != model.ErrEmptyPseudonym {
+				assert.ErrorIs(t, err, model.ErrPseudonymNotAllowed)
+			}
+		})
+	}
+}
@@ -9,36 +9,105 @@ import (
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
 	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/metadata"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/metrics"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/pseudonym"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
 //go:generate mockery --name UserModifier
 type UserModifier interface {
-	UpdateUser(ctx context.Context, user generated.UpdateUserParams) (*generated.User, error)
+	UpdateUser(ctx context.Context, user generated.UpdateUserParams, changedBy uuid.UUID, cooldown time.Duration) (*generated.User, error)
 	SaveUser(ctx context.Context, user generated.SaveUserParams) (*uuid.UUID, error)
 	SaveAdmin(ctx context.Context, params generated.SaveAdminParams) error
 	DeleteAdmin(ctx context.Context, userID uuid.UUID) error
+	SaveAdminRequest(ctx context.Context, params generated.SaveAdminRequestParams) (*generated.AdminRequest, error)
+	ApproveAdminRequest(ctx context.Context, id, approverID uuid.UUID) (*generated.AdminRequest, error)
+	RejectAdminRequest(ctx context.Context, id, approverID uuid.UUID) (*generated.AdminRequest, error)
+	ExpireAdminRequests(ctx context.Context) (int64, error)
+	DeleteUser(ctx context.Context, id, deletedBy uuid.UUID) error
+	RestoreUser(ctx context.Context, id uuid.UUID, username string) error
+	SyncTelegramProfile(ctx context.Context, params generated.SyncTelegramProfileParams) (bool, error)
+	UpdateUserMetadata(ctx context.Context, userID uuid.UUID, namespace string, update func(current []byte) ([]byte, error)) (*generated.UsersMetadatum, error)
+	AnonymizeDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error)
+	PublishOutboxEvents(ctx context.Context, limit int32, publish func(model.Event) error) (int, error)
 }
 
 //go:generate mockery --name UserProvider
 type UserProvider interface {
 	GetUsers(ctx context.Context, params model.GetUsersParams) (users []generated.User, total *uint64, err error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*generated.User, error)
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]generated.User, error)
+	ExportUsers(ctx context.Context, params model.GetUsersParams, fn func(generated.User) error) error
 	GetUserAdminByUsername(ctx context.Context, username string) (*generated.GetUserAdminByUsernameRow, error)
+	GetLegacyUserAdminByUsername(ctx context.Context, username string) (*generated.GetUserAdminByUsernameRow, error)
 	GetUserAdminByID(ctx context.Context, id uuid.UUID) (*generated.GetUserAdminByIDRow, error)
-	GetAdmins(ctx context.Context, params generated.GetAdminsParams) (admins []generated.GetAdminsRow, total *uint64, err error)
+	GetUserAdminByTelegramID(ctx context.Context, telegramID int64) (*generated.GetUserAdminByTelegramIDRow, error)
+	GetAdmins(ctx context.Context, params model.GetAdminsParams) (admins []model.Admin, total *uint64, err error)
+	GetAdminRequestByID(ctx context.Context, id uuid.UUID) (*generated.AdminRequest, error)
+	GetAdminRequests(ctx context.Context, params generated.GetAdminRequestsParams) (requests []generated.AdminRequest, total *uint64, err error)
+	GetRestorableUser(ctx context.Context, telegramID *int64, username string, deletedAfter time.Time) (*generated.GetRestorableUserRow, error)
+	GetUserData(ctx context.Context, id uuid.UUID) (*model.UserData, error)
+	IsPseudonymTaken(ctx context.Context, skeleton string, userID *uuid.UUID, releasedAfter time.Time) (bool, error)
+	GetUserMetadata(ctx context.Context, userID uuid.UUID, namespace string) (*generated.UsersMetadatum, error)
+	GetUserMetadataByNamespaces(ctx context.Context, userID uuid.UUID, namespaces []string) ([]generated.UsersMetadatum, error)
 }
 
 //go:generate mockery --name RefreshTokenProvider
 type RefreshTokenProvider interface {
 	GetRefreshToken(ctx context.Context, tokenID string) (*string, error)
+	GetSessions(ctx context.Context, userID string) ([]model.Session, error)
 }
 
 //go:generate mockery --name RefreshTokenModifier
 type RefreshTokenModifier interface {
 	SetRefreshToken(ctx context.Context, userID string, tokenID string, expiry time.Duration) error
 	ReplaceRefreshToken(ctx context.Context, oldID, newID, userID string, expiry time.Duration) error
+	RevokeRefreshTokens(ctx context.Context, userID string) error
+}
+
+//go:generate mockery --name OrganizationModifier
+type OrganizationModifier interface {
+	CreateOrganization(ctx context.Context, name string, ownerID uuid.UUID) (*generated.Organization, error)
+	SaveOrganizationMember(ctx context.Context, params generated.SaveOrganizationMemberParams) error
+	DeleteOrganizationMember(ctx context.Context, params generated.DeleteOrganizationMemberParams) error
+}
+
+//go:generate mockery --name OrganizationProvider
+type OrganizationProvider interface {
+	GetOrganizationMember(ctx context.Context, organizationID, userID uuid.UUID) (*generated.OrganizationMember, error)
+	GetUserOrganizations(ctx context.Context, userID uuid.UUID) ([]generated.GetUserOrganizationsRow, error)
+}
+
+//go:generate mockery --name EventPublisher
+type EventPublisher interface {
+	Publish(ctx context.Context, event model.Event) error
+}
+
+//go:generate mockery --name ExportModifier
+type ExportModifier interface {
+	SaveExport(ctx context.Context, token string, export model.Export, expiry time.Duration) error
+}
+
+//go:generate mockery --name ExportProvider
+type ExportProvider interface {
+	GetExport(ctx context.Context, token string) (*model.Export, error)
+}
+
+//go:generate mockery --name ExportScheduler
+type ExportScheduler interface {
+	Schedule(ctx context.Context, build func(ctx context.Context)) error
+}
+
+//go:generate mockery --name AuditRecorder
+type AuditRecorder interface {
+	Record(ctx context.Context, event model.AuditEvent)
+}
+
+//go:generate mockery --name AuditProvider
+type AuditProvider interface {
+	GetAuditEvents(ctx context.Context, params model.GetAuditEventsParams) (events []generated.AuditEvent, total *uint64, err error)
 }
 
 type UserService struct {
@@ -46,7 +115,17 @@ type UserService struct {
 	userProvider         UserProvider
 	refreshTokenProvider RefreshTokenProvider
 	refreshTokenModifier RefreshTokenModifier
+	organizationModifier OrganizationModifier
+	organizationProvider OrganizationProvider
+	eventPublisher       EventPublisher
+	exportModifier       ExportModifier
+	exportProvider       ExportProvider
+	exportScheduler      ExportScheduler
+	auditRecorder        AuditRecorder
+	auditProvider        AuditProvider
 	authConfig           model.AuthConfig
+	pseudonymPolicy      *pseudonym.Policy
+	metadataValidator    *metadata.Validator
 	log                  *slog.Logger
 }
 
@@ -55,6 +134,14 @@ func New(
 	userProvider UserProvider,
 	refreshTokenProvider RefreshTokenProvider,
 	refreshTokenModifier RefreshTokenModifier,
+	organizationModifier OrganizationModifier,
+	organizationProvider OrganizationProvider,
+	eventPublisher EventPublisher,
+	exportModifier ExportModifier,
+	exportProvider ExportProvider,
+	exportScheduler ExportScheduler,
+	auditRecorder AuditRecorder,
+	auditProvider AuditProvider,
 	authConfig model.AuthConfig,
 	log *slog.Logger,
 ) *UserService {
@@ -63,13 +150,48 @@ func New(
 		userProvider:         userProvider,
 		refreshTokenProvider: refreshTokenProvider,
 		refreshTokenModifier: refreshTokenModifier,
+		organizationModifier: organizationModifier,
+		organizationProvider: organizationProvider,
+		eventPublisher:       eventPublisher,
+		exportModifier:       exportModifier,
+		exportProvider:       exportProvider,
+		exportScheduler:      exportScheduler,
+		auditRecorder:        auditRecorder,
+		auditProvider:        auditProvider,
 		authConfig:           authConfig,
+		pseudonymPolicy:      pseudonym.New(authConfig.ReservedPseudonyms, authConfig.BlockedPseudonyms),
+		metadataValidator:    mustMetadataValidator(authConfig.MetadataNamespaces),
 		log:                  log,
 	}
 }
 
+// logger returns logger of request, so records carry its request id, user id and method.
+func (s *UserService) logger(ctx context.Context) *slog.Logger {
+	return sl.FromContext(ctx, s.log)
+}
+
+// UpdateUser updates user, pseudonym can be changed once per cooldown.
 func (s *UserService) UpdateUser(ctx context.Context, updateUser generated.UpdateUserParams) (*generated.User, error) {
-	user, err := s.userModifier.UpdateUser(ctx, updateUser)
+	if updateUser.Pseudonym != nil {
+		changed, err := s.isPseudonymChanged(ctx, updateUser.ID, *updateUser.Pseudonym)
+		if err != nil {
+			return nil, err
+		}
+
+		if changed {
+			normalized, skeleton, err := s.validatePseudonym(ctx, *updateUser.Pseudonym, &updateUser.ID)
+			if err != nil {
+				return nil, err
+			}
+			updateUser.Pseudonym = &normalized
+			updateUser.PseudonymSkeleton = &skeleton
+		} else {
+			updateUser.Pseudonym = nil
+		}
+	}
+
+	cooldown := time.Minute * time.Duration(s.authConfig.PseudonymCooldown)
+	user, err := s.userModifier.UpdateUser(ctx, updateUser, updateUser.ID, cooldown)
 	if err != nil {
 		return nil, err
 	}
@@ -77,11 +199,114 @@ func (s *UserService) UpdateUser(ctx context.Context, updateUser generated.Updat
 	return user, nil
 }
 
-func (s *UserService) GetUsers(ctx context.Context, params model.GetUsersParams) (users []generated.User, total *uint64, err error) {
-	return s.userProvider.GetUsers(ctx, params)
+// GetUsers returns users, lookup by pseudonym also matches user who recently released it.
+// With cursor pagination users are ordered by creation time unless sort is given.
+func (s *UserService) GetUsers(ctx context.Context, params model.GetUsersParams) (users []generated.User, page *model.Page, err error) {
+	if params.Query != nil {
+		if params.UseCursor {
+			return nil, nil, model.ErrCursorNotSupported
+		}
+		params.SimilarityThreshold = s.authConfig.SearchSimilarityThreshold
+	}
+
+	var defaultSort model.Sort
+	if params.UseCursor {
+		defaultSort = model.Sort{{Field: "created_at", Order: model.OrderAsc}}
+	}
+
+	params.Sort, err = checkSort(params.Sort, defaultSort, model.UserSortFields, params.Cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if params.Pseudonym != nil {
+		params.FormerPseudonym = &model.FormerPseudonym{
+			Skeleton:     pseudonym.Skeleton(*params.Pseudonym),
+			ChangedAfter: s.formerPseudonymsAfter(),
+		}
+	}
+
+	users, total, err := s.userProvider.GetUsers(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users, page = model.NewPage(users, total, params.Limit, params.PageParams, func(user generated.User, backward bool) model.Cursor {
+		return model.UserCursor(user, params.Sort, backward)
+	})
+
+	return users, page, nil
+}
+
+// ExportUsers calls fn for every user matching params from one snapshot, search and pagination
+// of params are ignored. Users are ordered by id unless order is given.
+func (s *UserService) ExportUsers(ctx context.Context, params model.GetUsersParams, fn func(generated.User) error) error {
+	params.Query = nil
+	params.PageParams = model.PageParams{}
+
+	var err error
+	params.Sort, err = checkSort(params.Sort, model.Sort{{Field: "id", Order: model.OrderAsc}}, model.UserSortFields, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userProvider.ExportUsers(ctx, params, fn); err != nil {
+		s.logger(ctx).Error("failed to export users", sl.Err(err))
+		return err
+	}
+
+	return nil
 }
 
-func (s *UserService) generateToken(id uuid.UUID, scale generated.NullAdminScale, expiry time.Duration) (*string, error) {
+// checkSort validates sort against allowed fields and appends id tie-breaker, empty sort is
+// replaced with defaultSort if there is one. Cursor must be issued for the same sort.
+func checkSort(sort, defaultSort model.Sort, allowed map[string]bool, cursor *model.Cursor) (model.Sort, error) {
+	if len(sort) == 0 {
+		if len(defaultSort) == 0 {
+			return nil, nil
+		}
+		sort = defaultSort
+	}
+
+	sort, err := sort.Normalize(allowed)
+	if err != nil {
+		return nil, err
+	}
+	sort = sort.WithTieBreaker()
+
+	if cursor != nil && !cursor.Sort.Equal(sort) {
+		return nil, model.ErrInvalidCursor
+	}
+
+	return sort, nil
+}
+
+func (s *UserService) generateToken(ctx context.Context, id uuid.UUID, scale generated.NullAdminScale, expiry time.Duration) (*string, error) {
+	claims, err := s.accessTokenClaims(ctx, id, scale, expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signToken(claims)
+}
+
+// accessTokenClaims adds metadata of namespaces embedded in token to base claims.
+func (s *UserService) accessTokenClaims(ctx context.Context, id uuid.UUID, scale generated.NullAdminScale, expiry time.Duration) (jwt.MapClaims, error) {
+	claims := tokenClaims(id, scale, expiry)
+
+	data, err := s.metadataClaims(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		claims["metadata"] = data
+	}
+
+	return claims, nil
+}
+
+func tokenClaims(id uuid.UUID, scale generated.NullAdminScale, expiry time.Duration) jwt.MapClaims {
 	claims := jwt.MapClaims{
 		"id":  id,
 		"exp": time.Now().Add(time.Minute * expiry).Unix(),
@@ -91,6 +316,10 @@ func (s *UserService) generateToken(id uuid.UUID, scale generated.NullAdminScale
 		claims["admin"] = scale.AdminScale
 	}
 
+	return claims
+}
+
+func (s *UserService) signToken(claims jwt.MapClaims) (*string, error) {
 	data := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	token, err := data.SignedString([]byte(s.authConfig.Secret))
@@ -103,93 +332,397 @@ func (s *UserService) generateToken(id uuid.UUID, scale generated.NullAdminScale
 }
 
 func (s *UserService) Login(ctx context.Context, saveUser generated.SaveUserParams) (accessToken, refreshToken *string, err error) {
-	user, err := s.userProvider.GetUserAdminByUsername(ctx, saveUser.Username)
+	var userID uuid.UUID
+	defer func() {
+		s.audit(ctx, model.AuditActionLogin, err, knownID(userID), knownID(userID), map[string]string{"username": saveUser.Username})
+	}()
+
+	if saveUser.IsBot {
+		s.logger(ctx).Debug("bot tried to login", slog.String("username", saveUser.Username))
+		return nil, nil, model.ErrBotNotAllowed
+	}
+
+	user, err := s.getLoginUser(ctx, saveUser)
 	if err != nil && !errors.Is(err, model.ErrUserNotFound) {
-		s.log.Error("failed to get user", sl.Err(err))
+		s.logger(ctx).Error("failed to get user", sl.Err(err))
 		return nil, nil, err
 	}
 
-	var userID uuid.UUID
 	var admin generated.NullAdminScale
 	if errors.Is(err, model.ErrUserNotFound) {
-		if saveUser.Pseudonym == "" {
-			s.log.Debug("pseudonym of new user must be non empty")
-			return nil, nil, model.ErrEmptyPseudonym
+		id, err := s.restoreUser(ctx, saveUser)
+		if err != nil && !errors.Is(err, model.ErrUserNotFound) {
+			return nil, nil, err
 		}
 
-		id, err := s.userModifier.SaveUser(ctx, saveUser)
-		if err != nil {
-			s.log.Error("failed to save user", sl.Err(err))
-			return nil, nil, err
+		if id != nil {
+			userID = *id
+			s.syncProfile(ctx, userID, saveUser)
+		} else {
+			saveUser.Pseudonym, saveUser.PseudonymSkeleton, err = s.validatePseudonym(ctx, saveUser.Pseudonym, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			id, err := s.userModifier.SaveUser(ctx, saveUser)
+			if err != nil {
+				s.logger(ctx).Error("failed to save user", sl.Err(err))
+				return nil, nil, err
+			}
+			userID = *id
+			metrics.Signups.Inc()
+			s.audit(ctx, model.AuditActionSignup, nil, id, id, map[string]string{"username": saveUser.Username})
 		}
-		userID = *id
 	} else {
 		userID = user.ID
+		s.syncProfile(ctx, userID, saveUser)
 		admin = generated.NullAdminScale{
 			AdminScale: user.Scale.AdminScale,
 			Valid:      true,
 		}
 	}
 
-	accessToken, err = s.generateToken(userID, admin, time.Duration(s.authConfig.AccessTokenTTL))
+	accessToken, err = s.generateToken(ctx, userID, admin, time.Duration(s.authConfig.AccessTokenTTL))
 	if err != nil {
-		s.log.Error("failed to generate token", sl.Err(err))
+		s.logger(ctx).Error("failed to generate token", sl.Err(err))
 		return nil, nil, err
 	}
 
 	refreshToken = new(string)
 	*refreshToken = uuid.NewString()
 	if err := s.refreshTokenModifier.SetRefreshToken(ctx, userID.String(), *refreshToken, time.Minute*time.Duration(s.authConfig.RefreshTokenTTL)); err != nil {
-		s.log.Error("failed to set refresh token", sl.Err(err))
+		s.logger(ctx).Error("failed to set refresh token", sl.Err(err))
 		return nil, nil, err
 	}
+	metrics.Logins.Inc()
 
 	return accessToken, refreshToken, nil
 }
 
 func (s *UserService) RefreshToken(ctx context.Context, token string) (accessToken, refreshToken *string, err error) {
+	var userIDParsed uuid.UUID
+	defer func() {
+		if err != nil {
+			metrics.RefreshFailures.WithLabelValues(refreshFailureReason(err)).Inc()
+		} else {
+			metrics.Refreshes.Inc()
+		}
+		s.audit(ctx, model.AuditActionRefresh, err, knownID(userIDParsed), knownID(userIDParsed), nil)
+	}()
+
 	userID, err := s.refreshTokenProvider.GetRefreshToken(ctx, token)
 	if err != nil {
-		s.log.Error("failed to get refresh token", sl.Err(err))
+		s.logger(ctx).Error("failed to get refresh token", sl.Err(err))
 		return nil, nil, err
 	}
 
-	userIDParsed, err := uuid.Parse(*userID)
+	userIDParsed, err = uuid.Parse(*userID)
 	if err != nil {
-		s.log.Error("got invalid user id from refresh token", sl.Err(err), slog.String("user_id", *userID))
+		s.logger(ctx).Error("got invalid user id from refresh token", sl.Err(err), slog.String("user_id", *userID))
 		return nil, nil, err
 	}
 
 	user, err := s.userProvider.GetUserAdminByID(ctx, userIDParsed)
 	if err != nil {
-		s.log.Error("failed to get user", sl.Err(err))
+		s.logger(ctx).Error("failed to get user", sl.Err(err))
 		return nil, nil, err
 	}
 
-	accessToken, err = s.generateToken(user.ID, user.Scale, time.Duration(s.authConfig.AccessTokenTTL))
+	accessToken, err = s.generateToken(ctx, user.ID, user.Scale, time.Duration(s.authConfig.AccessTokenTTL))
 	if err != nil {
-		s.log.Error("failed to generate token", sl.Err(err))
+		s.logger(ctx).Error("failed to generate token", sl.Err(err))
 		return nil, nil, err
 	}
 
 	refreshToken = new(string)
 	*refreshToken = uuid.NewString()
 	if err = s.refreshTokenModifier.ReplaceRefreshToken(ctx, token, *refreshToken, *userID, time.Minute*time.Duration(s.authConfig.RefreshTokenTTL)); err != nil {
-		s.log.Error("failed to replace refresh token", sl.Err(err))
+		s.logger(ctx).Error("failed to replace refresh token", sl.Err(err))
 		return nil, nil, err
 	}
 
 	return accessToken, refreshToken, nil
 }
 
+// refreshFailureReason labels failed refresh in metrics.
+func refreshFailureReason(err error) string {
+	switch {
+	case errors.Is(err, model.ErrRefreshTokenNotValid):
+		return metrics.ReasonInvalidToken
+	case errors.Is(err, model.ErrUserNotFound):
+		return metrics.ReasonUserNotFound
+	default:
+		return metrics.ReasonInternal
+	}
+}
+
 func (s *UserService) GetUser(ctx context.Context, id uuid.UUID) (*generated.User, error) {
 	return s.userProvider.GetUserByID(ctx, id)
 }
 
+// GetTelegramProfile returns Telegram profile of user, users read own profile and admins read any profile.
+func (s *UserService) GetTelegramProfile(ctx context.Context, actorID, id uuid.UUID, scale generated.NullAdminScale) (*model.TelegramProfile, error) {
+	if actorID != id && !scale.Valid {
+		s.logger(ctx).Debug("only admin can read telegram profile of other users")
+		return nil, model.ErrUnauthorized
+	}
+
+	user, err := s.userProvider.GetUserByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, model.ErrUserNotFound) {
+			s.logger(ctx).Error("failed to get user", sl.Err(err))
+		}
+		return nil, err
+	}
+
+	return model.ToTelegramProfile(*user), nil
+}
+
+// GetUsersByIDs returns found users in order of requested ids and ids of users that do not
+// exist or are deleted, repeated ids are returned once.
+func (s *UserService) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) (users []generated.User, missing []uuid.UUID, err error) {
+	unique := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	if len(unique) > s.authConfig.BatchMaxIDs {
+		s.logger(ctx).Debug("too many ids requested", slog.Int("count", len(unique)))
+		return nil, nil, model.ErrTooManyIDs
+	}
+	if len(unique) == 0 {
+		return nil, nil, nil
+	}
+
+	found, err := s.userProvider.GetUsersByIDs(ctx, unique)
+	if err != nil {
+		s.logger(ctx).Error("failed to get users by ids", sl.Err(err))
+		return nil, nil, err
+	}
+
+	byID := make(map[uuid.UUID]generated.User, len(found))
+	for _, user := range found {
+		byID[user.ID] = user
+	}
+
+	users = make([]generated.User, 0, len(found))
+	for _, id := range unique {
+		if user, ok := byID[id]; ok {
+			users = append(users, user)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	return users, missing, nil
+}
+
+// DeleteUser soft deletes user and revokes all sessions. Users can delete themselves,
+// admins can delete regular users and only major admin can delete minor admin. Deletion drops
+// admin role of user, so with admin approval deleting minor admin first requests removal of
+// the role, and the last major admin can not delete themselves.
+// Users restore their account by logging in within grace period, accounts deleted by admin
+// are not restored that way.
+func (s *UserService) DeleteUser(ctx context.Context, actorID, id uuid.UUID, scale generated.NullAdminScale) (err error) {
+	defer func() {
+		s.audit(ctx, model.AuditActionDeleteUser, err, &actorID, &id, nil)
+	}()
+
+	if actorID == id {
+		if scale.Valid && scale.AdminScale == generated.AdminScaleMajor {
+			if err := s.checkNotLastMajorAdmin(ctx); err != nil {
+				return err
+			}
+		}
+	} else {
+		if !scale.Valid {
+			s.logger(ctx).Debug("only admin can delete other users")
+			return model.ErrUnauthorized
+		}
+
+		user, err := s.userProvider.GetUserAdminByID(ctx, id)
+		if err != nil {
+			s.logger(ctx).Error("failed to get user", sl.Err(err))
+			return err
+		}
+
+		if user.Scale.Valid {
+			if user.Scale.AdminScale == generated.AdminScaleMajor {
+				s.logger(ctx).Debug("cannot delete major admin")
+				return model.ErrCannotDeleteMajorAdmin
+			} else if scale.AdminScale != generated.AdminScaleMajor {
+				s.logger(ctx).Debug("scale of admin is not major")
+				return model.ErrAdminNotMajor
+			}
+
+			if s.authConfig.AdminApproval {
+				return s.requestAdminChange(ctx, generated.AdminRequestActionDeleteAdmin, id, user.Scale.AdminScale, actorID)
+			}
+		}
+	}
+
+	if err := s.userModifier.DeleteUser(ctx, id, actorID); err != nil {
+		s.logger(ctx).Error("failed to delete user", sl.Err(err))
+		return err
+	}
+
+	if err := s.refreshTokenModifier.RevokeRefreshTokens(ctx, id.String()); err != nil {
+		s.logger(ctx).Error("failed to revoke refresh tokens", sl.Err(err))
+		return err
+	}
+
+	return nil
+}
+
+// checkNotLastMajorAdmin fails if there is only one major admin left.
+func (s *UserService) checkNotLastMajorAdmin(ctx context.Context) error {
+	admins, _, err := s.userProvider.GetAdmins(ctx, model.GetAdminsParams{
+		AdminScale: generated.NullAdminScale{AdminScale: generated.AdminScaleMajor, Valid: true},
+		Limit:      2,
+		PageParams: model.PageParams{SkipTotal: true},
+	})
+	if err != nil {
+		s.logger(ctx).Error("failed to get admins", sl.Err(err))
+		return err
+	}
+
+	if len(admins) < 2 {
+		s.logger(ctx).Debug("cannot delete the last major admin")
+		return model.ErrLastMajorAdmin
+	}
+
+	return nil
+}
+
+// getLoginUser looks the user up by Telegram id, which never changes, and falls back to username
+// for accounts created before Telegram ids were stored. Accounts with another Telegram id are never
+// matched by username, their username is stale and is released when the user is saved or synced.
+// Telegram usernames are optional, empty username is shared by users without one and matches nobody.
+func (s *UserService) getLoginUser(ctx context.Context, saveUser generated.SaveUserParams) (*generated.GetUserAdminByUsernameRow, error) {
+	if saveUser.TelegramID == nil && saveUser.Username == "" {
+		return nil, model.ErrUserNotFound
+	}
+	if saveUser.TelegramID == nil {
+		return s.userProvider.GetUserAdminByUsername(ctx, saveUser.Username)
+	}
+
+	user, err := s.userProvider.GetUserAdminByTelegramID(ctx, *saveUser.TelegramID)
+	if err == nil {
+		return (*generated.GetUserAdminByUsernameRow)(user), nil
+	}
+	if !errors.Is(err, model.ErrUserNotFound) {
+		return nil, err
+	}
+
+	if saveUser.Username == "" {
+		return nil, model.ErrUserNotFound
+	}
+
+	return s.userProvider.GetLegacyUserAdminByUsername(ctx, saveUser.Username)
+}
+
+// syncProfile brings the stored profile in line with Telegram init data. Names edited by the user
+// are kept and username conflicts are resolved by the store in favour of the user logging in,
+// other failures are only logged so that login does not depend on the sync.
+func (s *UserService) syncProfile(ctx context.Context, userID uuid.UUID, saveUser generated.SaveUserParams) {
+	changed, err := s.userModifier.SyncTelegramProfile(ctx, generated.SyncTelegramProfileParams{
+		Username:        saveUser.Username,
+		FirstName:       saveUser.FirstName,
+		LastName:        saveUser.LastName,
+		TelegramID:      saveUser.TelegramID,
+		LanguageCode:    saveUser.LanguageCode,
+		IsPremium:       saveUser.IsPremium,
+		PhotoUrl:        saveUser.PhotoUrl,
+		AllowsWriteToPm: saveUser.AllowsWriteToPm,
+		ID:              userID,
+	})
+	if err != nil {
+		s.logger(ctx).Error("failed to sync telegram profile", sl.Err(err), slog.String("user_id", userID.String()))
+		return
+	}
+
+	if changed {
+		s.logger(ctx).Debug("telegram profile synced", slog.String("user_id", userID.String()))
+	}
+}
+
+// restoreUser restores account deleted within grace period.
+func (s *UserService) restoreUser(ctx context.Context, saveUser generated.SaveUserParams) (*uuid.UUID, error) {
+	deletedAfter := time.Now().Add(-time.Minute * time.Duration(s.authConfig.DeletionGracePeriod))
+	user, err := s.userProvider.GetRestorableUser(ctx, saveUser.TelegramID, saveUser.Username, deletedAfter)
+	if err != nil {
+		if !errors.Is(err, model.ErrUserNotFound) {
+			s.logger(ctx).Error("failed to get deleted user", sl.Err(err))
+		}
+		return nil, err
+	}
+
+	if user.DeletedBy.Valid && uuid.UUID(user.DeletedBy.Bytes) != user.ID {
+		s.logger(ctx).Debug("user was deleted by admin", slog.String("user_id", user.ID.String()))
+		return nil, model.ErrUserDeletedByAdmin
+	}
+
+	if err := s.userModifier.RestoreUser(ctx, user.ID, saveUser.Username); err != nil {
+		if errors.Is(err, model.ErrUsernameTaken) || errors.Is(err, model.ErrPseudonymTaken) {
+			s.logger(ctx).Debug("failed to restore user", sl.Err(err), slog.String("user_id", user.ID.String()))
+		} else {
+			s.logger(ctx).Error("failed to restore user", sl.Err(err))
+		}
+		return nil, err
+	}
+
+	s.logger(ctx).Debug("user restored", slog.String("user_id", user.ID.String()))
+
+	return &user.ID, nil
+}
+
+// AnonymizeDeletedUsers irreversibly erases personal data of users deleted before grace period,
+// user id is kept so references in other services stay valid. Events about anonymized users are
+// saved to outbox with the data change and published afterwards, events which failed to publish
+// are retried on the next call.
+func (s *UserService) AnonymizeDeletedUsers(ctx context.Context) (int, error) {
+	deletedBefore := time.Now().Add(-time.Minute * time.Duration(s.authConfig.DeletionGracePeriod))
+	ids, err := s.userModifier.AnonymizeDeletedUsers(ctx, deletedBefore)
+	if err != nil {
+		s.logger(ctx).Error("failed to anonymize users", sl.Err(err))
+		return 0, err
+	}
+
+	if err := s.publishOutboxEvents(ctx); err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
+
+// outboxBatchSize is max number of outbox events published in one transaction.
+const outboxBatchSize = 100
+
+// publishOutboxEvents publishes outbox events in batches until outbox is empty.
+func (s *UserService) publishOutboxEvents(ctx context.Context) error {
+	for {
+		published, err := s.userModifier.PublishOutboxEvents(ctx, outboxBatchSize, func(event model.Event) error {
+			return s.eventPublisher.Publish(ctx, event)
+		})
+		if err != nil {
+			s.logger(ctx).Error("failed to publish outbox events", sl.Err(err), slog.Int("published", published))
+			return err
+		}
+
+		if published < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
 func (s *UserService) addAdmin(ctx context.Context, username string, saveScale generated.AdminScale) (*model.Admin, error) {
 	user, err := s.userProvider.GetUserAdminByUsername(ctx, username)
 	if err != nil {
-		s.log.Error("failed to get user", sl.Err(err))
+		s.logger(ctx).Error("failed to get user", sl.Err(err))
 		return nil, err
 	}
 
@@ -203,7 +736,7 @@ func (s *UserService) addAdmin(ctx context.Context, username string, saveScale g
 		Scale:  saveScale,
 	})
 	if err != nil {
-		s.log.Error("failed to save admin", sl.Err(err))
+		s.logger(ctx).Error("failed to save admin", sl.Err(err))
 		return nil, err
 	}
 
@@ -215,39 +748,111 @@ func (s *UserService) addAdmin(ctx context.Context, username string, saveScale g
 	}, nil
 }
 
-func (s *UserService) AddAdmin(ctx context.Context, username string, scale generated.AdminScale) (*model.Admin, error) {
+func (s *UserService) AddAdmin(ctx context.Context, actorID uuid.UUID, username string, scale generated.AdminScale) (admin *model.Admin, err error) {
+	defer func() {
+		var targetID *uuid.UUID
+		if admin != nil {
+			targetID = &admin.ID
+		}
+		s.audit(ctx, model.AuditActionAddAdmin, err, &actorID, targetID, map[string]string{"username": username})
+	}()
+
 	if scale != generated.AdminScaleMajor {
-		s.log.Debug("scale of admin is not major")
+		s.logger(ctx).Debug("scale of admin is not major")
 		return nil, model.ErrAdminNotMajor
 	}
 
-	return s.addAdmin(ctx, username, generated.AdminScaleMinor)
+	if s.authConfig.AdminApproval {
+		user, err := s.userProvider.GetUserAdminByUsername(ctx, username)
+		if err != nil {
+			s.logger(ctx).Error("failed to get user", sl.Err(err))
+			return nil, err
+		}
+
+		if user.Scale.Valid {
+			return nil, model.ErrAdminAlreadyExists
+		}
+
+		return nil, s.requestAdminChange(ctx, generated.AdminRequestActionAddAdmin, user.ID, generated.AdminScaleMinor, actorID)
+	}
+
+	admin, err = s.addAdmin(ctx, username, generated.AdminScaleMinor)
+	if err != nil {
+		return nil, err
+	}
+	metrics.AdminChanges.WithLabelValues(metrics.ActionAdd).Inc()
+
+	return admin, nil
 }
 
-func (s *UserService) DeleteAdmin(ctx context.Context, id uuid.UUID, scale generated.AdminScale) error {
+func (s *UserService) DeleteAdmin(ctx context.Context, actorID, id uuid.UUID, scale generated.AdminScale) (err error) {
+	defer func() {
+		s.audit(ctx, model.AuditActionDeleteAdmin, err, &actorID, &id, nil)
+	}()
+
 	if scale != generated.AdminScaleMajor {
-		s.log.Debug("scale of admin is not major")
+		s.logger(ctx).Debug("scale of admin is not major")
 		return model.ErrAdminNotMajor
 	}
 
 	admin, err := s.userProvider.GetUserAdminByID(ctx, id)
 	if err != nil {
-		s.log.Error("failed to get user", sl.Err(err))
+		s.logger(ctx).Error("failed to get user", sl.Err(err))
 		return err
 	}
 
 	if admin.Scale.Valid && admin.Scale.AdminScale == generated.AdminScaleMajor {
-		s.log.Debug("cannot delete major admin")
+		s.logger(ctx).Debug("cannot delete major admin")
 		return model.ErrCannotDeleteMajorAdmin
 	}
 
-	return s.userModifier.DeleteAdmin(ctx, id)
+	if s.authConfig.AdminApproval {
+		if !admin.Scale.Valid {
+			return nil
+		}
+
+		return s.requestAdminChange(ctx, generated.AdminRequestActionDeleteAdmin, id, admin.Scale.AdminScale, actorID)
+	}
+
+	if err := s.userModifier.DeleteAdmin(ctx, id); err != nil {
+		return err
+	}
+	metrics.AdminChanges.WithLabelValues(metrics.ActionDelete).Inc()
+
+	return nil
 }
 
-func (s *UserService) InitAdmin(ctx context.Context, username string) (*model.Admin, error) {
-	return s.addAdmin(ctx, username, generated.AdminScaleMajor)
+func (s *UserService) InitAdmin(ctx context.Context, username string) (admin *model.Admin, err error) {
+	defer func() {
+		var targetID *uuid.UUID
+		if admin != nil {
+			targetID = &admin.ID
+		}
+		s.audit(ctx, model.AuditActionInitAdmin, err, nil, targetID, map[string]string{"username": username})
+	}()
+
+	admin, err = s.addAdmin(ctx, username, generated.AdminScaleMajor)
+	if err != nil {
+		return nil, err
+	}
+	metrics.AdminChanges.WithLabelValues(metrics.ActionInit).Inc()
+
+	return admin, nil
 }
 
-func (s *UserService) GetAdmins(ctx context.Context, params generated.GetAdminsParams) (admins []generated.GetAdminsRow, total *uint64, err error) {
-	return s.userProvider.GetAdmins(ctx, params)
+func (s *UserService) GetAdmins(ctx context.Context, params model.GetAdminsParams) (admins []model.Admin, page *model.Page, err error) {
+	params.Sort, err = checkSort(params.Sort, model.Sort{{Field: "created_at", Order: model.OrderAsc}}, model.AdminSortFields, params.Cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	admins, total, err := s.userProvider.GetAdmins(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	admins, page = model.NewPage(admins, total, params.Limit, params.PageParams, func(admin model.Admin, backward bool) model.Cursor {
+		return model.AdminCursor(admin, params.Sort, backward)
+	})
+	return admins, page, nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/service/mocks"
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -23,27 +24,59 @@ type dependencies struct {
 	userModifier         *mocks.UserModifier
 	refreshTokenModifier *mocks.RefreshTokenModifier
 	refreshTokenProvider *mocks.RefreshTokenProvider
+	organizationModifier *mocks.OrganizationModifier
+	organizationProvider *mocks.OrganizationProvider
+	eventPublisher       *mocks.EventPublisher
+	exportModifier       *mocks.ExportModifier
+	exportProvider       *mocks.ExportProvider
+	exportScheduler      *mocks.ExportScheduler
+	auditRecorder        *mocks.AuditRecorder
+	auditProvider        *mocks.AuditProvider
 }
 
 func createService(t *testing.T) dependencies {
 	t.Helper()
 
+	return createServiceWithConfig(t, model.AuthConfig{
+		Secret:          "secret",
+		AccessTokenTTL:  20,
+		RefreshTokenTTL: 4200,
+	})
+}
+
+func createServiceWithConfig(t *testing.T, authConfig model.AuthConfig) dependencies {
+	t.Helper()
+
 	userProvider := mocks.NewUserProvider(t)
 	userModifier := mocks.NewUserModifier(t)
 	refreshTokenModifier := mocks.NewRefreshTokenModifier(t)
 	refreshTokenProvider := mocks.NewRefreshTokenProvider(t)
-	authConfig := model.AuthConfig{
-		Secret:          "secret",
-		AccessTokenTTL:  20,
-		RefreshTokenTTL: 4200,
-	}
+	organizationModifier := mocks.NewOrganizationModifier(t)
+	organizationProvider := mocks.NewOrganizationProvider(t)
+	eventPublisher := mocks.NewEventPublisher(t)
+	exportModifier := mocks.NewExportModifier(t)
+	exportProvider := mocks.NewExportProvider(t)
+	exportScheduler := mocks.NewExportScheduler(t)
+	auditRecorder := mocks.NewAuditRecorder(t)
+	auditProvider := mocks.NewAuditProvider(t)
+
+	// audit events are checked only by audit tests
+	auditRecorder.On("Record", mock.Anything, mock.Anything).Maybe()
 
 	return dependencies{
-		userService:          New(userModifier, userProvider, refreshTokenProvider, refreshTokenModifier, authConfig, slogdiscard.NewDiscardLogger()),
+		userService:          New(userModifier, userProvider, refreshTokenProvider, refreshTokenModifier, organizationModifier, organizationProvider, eventPublisher, exportModifier, exportProvider, exportScheduler, auditRecorder, auditProvider, authConfig, slogdiscard.NewDiscardLogger()),
 		userProvider:         userProvider,
 		userModifier:         userModifier,
 		refreshTokenModifier: refreshTokenModifier,
 		refreshTokenProvider: refreshTokenProvider,
+		organizationModifier: organizationModifier,
+		organizationProvider: organizationProvider,
+		eventPublisher:       eventPublisher,
+		exportModifier:       exportModifier,
+		exportProvider:       exportProvider,
+		exportScheduler:      exportScheduler,
+		auditRecorder:        auditRecorder,
+		auditProvider:        auditProvider,
 	}
 }
 
@@ -105,6 +138,10 @@ func TestLogin_SuccessUserExists(t *testing.T) {
 			},
 		}, nil).Once()
 
+	s.userModifier.On("SyncTelegramProfile", mock.Anything, mock.MatchedBy(func(params generated.SyncTelegramProfileParams) bool {
+		return params.ID == id && params.FirstName == user.FirstName && params.LastName == user.LastName
+	})).Return(false, nil).Once()
+
 	var rt string
 	s.refreshTokenModifier.On("SetRefreshToken", mock.Anything, id.String(), mock.MatchedBy(func(refreshToken string) bool {
 		rt = refreshToken
@@ -142,8 +179,15 @@ func TestLogin_SuccessUserNotExists(t *testing.T) {
 
 	s.userProvider.On("GetUserAdminByUsername", mock.Anything, user.Username).
 		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetRestorableUser", mock.Anything, user.TelegramID, user.Username, mock.Anything).
+		Return(nil, model.ErrUserNotFound).Once()
 
-	s.userModifier.On("SaveUser", mock.Anything, user).
+	s.userProvider.On("IsPseudonymTaken", mock.Anything, "qwerty", (*uuid.UUID)(nil), mock.Anything).
+		Return(false, nil).Once()
+
+	saveUser := user
+	saveUser.PseudonymSkeleton = "qwerty"
+	s.userModifier.On("SaveUser", mock.Anything, saveUser).
 		Return(&id, nil).Once()
 
 	s.refreshTokenModifier.On("SetRefreshToken", mock.Anything, id.String(), mock.MatchedBy(func(refreshToken string) bool {
@@ -158,6 +202,226 @@ func TestLogin_SuccessUserNotExists(t *testing.T) {
 	assert.Nil(t, decodedAccessToken.admin)
 }
 
+func TestLogin_SuccessUserRestored(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	s.userService.authConfig.DeletionGracePeriod = 60
+	ctx := context.Background()
+
+	user := generated.SaveUserParams{Username: "qwerty"}
+	id := uuid.New()
+
+	s.userProvider.On("GetUserAdminByUsername", mock.Anything, user.Username).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetRestorableUser", mock.Anything, user.TelegramID, user.Username, mock.MatchedBy(func(deletedAfter time.Time) bool {
+		return time.Until(deletedAfter) < -59*time.Minute
+	})).Return(&generated.GetRestorableUserRow{ID: id, DeletedBy: pgtype.UUID{Bytes: id, Valid: true}}, nil).Once()
+	s.userModifier.On("RestoreUser", mock.Anything, id, user.Username).Return(nil).Once()
+	s.userModifier.On("SyncTelegramProfile", mock.Anything, mock.Anything).Return(true, nil).Once()
+
+	s.refreshTokenModifier.On("SetRefreshToken", mock.Anything, id.String(), mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	accessToken, _, err := s.userService.Login(ctx, user)
+	require.NoError(t, err)
+
+	decodedAccessToken := decodeToken(t, s.userService.authConfig.Secret, *accessToken)
+	assert.Equal(t, id.String(), decodedAccessToken.id)
+	assert.Nil(t, decodedAccessToken.admin)
+}
+
+func TestLogin_FailDeletedByAdmin(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	telegramID := int64(42)
+	user := generated.SaveUserParams{Username: "qwerty", TelegramID: &telegramID}
+	id := uuid.New()
+
+	s.userProvider.On("GetUserAdminByTelegramID", mock.Anything, telegramID).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetLegacyUserAdminByUsername", mock.Anything, user.Username).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetRestorableUser", mock.Anything, &telegramID, user.Username, mock.Anything).
+		Return(&generated.GetRestorableUserRow{ID: id, DeletedBy: pgtype.UUID{Bytes: uuid.New(), Valid: true}}, nil).Once()
+
+	_, _, err := s.userService.Login(ctx, user)
+	require.ErrorIs(t, err, model.ErrUserDeletedByAdmin)
+	s.userModifier.AssertNotCalled(t, "RestoreUser", mock.Anything, mock.Anything, mock.Anything)
+	s.userModifier.AssertNotCalled(t, "SaveUser", mock.Anything, mock.Anything)
+}
+
+func TestLogin_FailRestoreUsernameTaken(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	user := generated.SaveUserParams{Username: "qwerty"}
+	id := uuid.New()
+
+	s.userProvider.On("GetUserAdminByUsername", mock.Anything, user.Username).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetRestorableUser", mock.Anything, user.TelegramID, user.Username, mock.Anything).
+		Return(&generated.GetRestorableUserRow{ID: id}, nil).Once()
+	s.userModifier.On("RestoreUser", mock.Anything, id, user.Username).Return(model.ErrUsernameTaken).Once()
+
+	_, _, err := s.userService.Login(ctx, user)
+	require.ErrorIs(t, err, model.ErrUsernameTaken)
+}
+
+func TestLogin_SuccessUserFoundByTelegramID(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	telegramID := int64(42)
+	languageCode := "en"
+	user := generated.SaveUserParams{
+		Username:     "renamed",
+		Pseudonym:    "qwerty",
+		FirstName:    "Aleskandr",
+		TelegramID:   &telegramID,
+		LanguageCode: &languageCode,
+		IsPremium:    true,
+	}
+	id := uuid.New()
+
+	s.userProvider.On("GetUserAdminByTelegramID", mock.Anything, telegramID).
+		Return(&generated.GetUserAdminByTelegramIDRow{ID: id}, nil).Once()
+	s.userModifier.On("SyncTelegramProfile", mock.Anything, generated.SyncTelegramProfileParams{
+		Username:     "renamed",
+		FirstName:    "Aleskandr",
+		TelegramID:   &telegramID,
+		LanguageCode: &languageCode,
+		IsPremium:    true,
+		ID:           id,
+	}).Return(true, nil).Once()
+	s.refreshTokenModifier.On("SetRefreshToken", mock.Anything, id.String(), mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	accessToken, _, err := s.userService.Login(ctx, user)
+	require.NoError(t, err)
+
+	decodedAccessToken := decodeToken(t, s.userService.authConfig.Secret, *accessToken)
+	assert.Equal(t, id.String(), decodedAccessToken.id)
+}
+
+func TestLogin_SuccessProfileSyncFailed(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	telegramID := int64(42)
+	user := generated.SaveUserParams{Username: "qwerty", Pseudonym: "qwerty", TelegramID: &telegramID}
+	id := uuid.New()
+
+	s.userProvider.On("GetUserAdminByTelegramID", mock.Anything, telegramID).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetLegacyUserAdminByUsername", mock.Anything, user.Username).
+		Return(&generated.GetUserAdminByUsernameRow{ID: id}, nil).Once()
+	s.userModifier.On("SyncTelegramProfile", mock.Anything, mock.Anything).
+		Return(false, errors.New("failed to sync profile")).Once()
+	s.refreshTokenModifier.On("SetRefreshToken", mock.Anything, id.String(), mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	_, refreshToken, err := s.userService.Login(ctx, user)
+	require.NoError(t, err)
+	assert.NotNil(t, refreshToken)
+}
+
+func TestLogin_SuccessStaleUsernameNotMatched(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	telegramID := int64(42)
+	user := generated.SaveUserParams{Username: "qwerty", Pseudonym: "qwerty", TelegramID: &telegramID}
+	id := uuid.New()
+
+	s.userProvider.On("GetUserAdminByTelegramID", mock.Anything, telegramID).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetLegacyUserAdminByUsername", mock.Anything, user.Username).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetRestorableUser", mock.Anything, &telegramID, user.Username, mock.Anything).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("IsPseudonymTaken", mock.Anything, "qwerty", (*uuid.UUID)(nil), mock.Anything).
+		Return(false, nil).Once()
+	s.userModifier.On("SaveUser", mock.Anything, mock.Anything).Return(&id, nil).Once()
+	s.refreshTokenModifier.On("SetRefreshToken", mock.Anything, id.String(), mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	accessToken, _, err := s.userService.Login(ctx, user)
+	require.NoError(t, err)
+
+	decodedAccessToken := decodeToken(t, s.userService.authConfig.Secret, *accessToken)
+	assert.Equal(t, id.String(), decodedAccessToken.id)
+	s.userProvider.AssertNotCalled(t, "GetUserAdminByUsername", mock.Anything, mock.Anything)
+}
+
+func TestLogin_SuccessEmptyUsernameNotMatched(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	telegramID := int64(42)
+	user := generated.SaveUserParams{Pseudonym: "qwerty", TelegramID: &telegramID}
+	id := uuid.New()
+
+	s.userProvider.On("GetUserAdminByTelegramID", mock.Anything, telegramID).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetRestorableUser", mock.Anything, &telegramID, "", mock.Anything).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("IsPseudonymTaken", mock.Anything, "qwerty", (*uuid.UUID)(nil), mock.Anything).
+		Return(false, nil).Once()
+	s.userModifier.On("SaveUser", mock.Anything, mock.Anything).Return(&id, nil).Once()
+	s.refreshTokenModifier.On("SetRefreshToken", mock.Anything, id.String(), mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	accessToken, _, err := s.userService.Login(ctx, user)
+	require.NoError(t, err)
+
+	decodedAccessToken := decodeToken(t, s.userService.authConfig.Secret, *accessToken)
+	assert.Equal(t, id.String(), decodedAccessToken.id)
+	s.userProvider.AssertNotCalled(t, "GetLegacyUserAdminByUsername", mock.Anything, mock.Anything)
+}
+
+func TestLogin_FailPseudonymTaken(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	user := generated.SaveUserParams{Username: "qwerty", Pseudonym: "Меtrо"}
+
+	s.userProvider.On("GetUserAdminByUsername", mock.Anything, user.Username).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetRestorableUser", mock.Anything, user.TelegramID, user.Username, mock.Anything).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("IsPseudonymTaken", mock.Anything, "metro", (*uuid.UUID)(nil), mock.Anything).
+		Return(true, nil).Once()
+
+	_, _, err := s.userService.Login(ctx, user)
+	require.ErrorIs(t, err, model.ErrPseudonymTaken)
+}
+
+func TestLogin_FailBot(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	_, _, err := s.userService.Login(ctx, generated.SaveUserParams{Username: "qwerty_bot", Pseudonym: "qwerty", IsBot: true})
+	require.ErrorIs(t, err, model.ErrBotNotAllowed)
+}
+
 func TestLogin_FailEmptyPseudonym(t *testing.T) {
 	t.Parallel()
 
@@ -173,6 +437,8 @@ func TestLogin_FailEmptyPseudonym(t *testing.T) {
 
 	s.userProvider.On("GetUserAdminByUsername", mock.Anything, user.Username).
 		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetRestorableUser", mock.Anything, user.TelegramID, user.Username, mock.Anything).
+		Return(nil, model.ErrUserNotFound).Once()
 
 	_, _, err := s.userService.Login(ctx, user)
 	require.ErrorIs(t, err, model.ErrEmptyPseudonym)
@@ -199,6 +465,10 @@ func TestLogin_Fail(t *testing.T) {
 			beh: func() {
 				s.userProvider.On("GetUserAdminByUsername", mock.Anything, mock.Anything).
 					Return(nil, model.ErrUserNotFound).Once()
+				s.userProvider.On("GetRestorableUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, model.ErrUserNotFound).Once()
+				s.userProvider.On("IsPseudonymTaken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(false, nil).Once()
 
 				s.userModifier.On("SaveUser", mock.Anything, mock.Anything).
 					Return(nil, saveUserErr).Once()
@@ -210,6 +480,8 @@ func TestLogin_Fail(t *testing.T) {
 			beh: func() {
 				s.userProvider.On("GetUserAdminByUsername", mock.Anything, mock.Anything).
 					Return(&generated.GetUserAdminByUsernameRow{}, nil).Once()
+				s.userModifier.On("SyncTelegramProfile", mock.Anything, mock.Anything).
+					Return(false, nil).Once()
 
 				s.refreshTokenModifier.On("SetRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(setRefreshTokenErr).Once()
@@ -229,12 +501,185 @@ func TestLogin_Fail(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.beh()
 
-			_, _, err := s.userService.Login(ctx, generated.SaveUserParams{Pseudonym: "qwerty"})
+			_, _, err := s.userService.Login(ctx, generated.SaveUserParams{Username: "qwerty", Pseudonym: "qwerty"})
 			assert.ErrorIs(t, err, tt.err)
 		})
 	}
 }
 
+func TestGetUsers_SearchUsesSimilarityThreshold(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	s.userService.authConfig.SearchSimilarityThreshold = 0.4
+	ctx := context.Background()
+
+	query := "metr"
+	params := model.GetUsersParams{Query: &query, Limit: 10}
+
+	expected := params
+	expected.SimilarityThreshold = 0.4
+	total := uint64(1)
+	s.userProvider.On("GetUsers", mock.Anything, expected).
+		Return([]generated.User{{Username: "metro"}}, &total, nil).Once()
+
+	users, _, err := s.userService.GetUsers(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "metro", users[0].Username)
+}
+
+func TestGetTelegramProfile_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	telegramID := int64(42)
+	languageCode := "en"
+	photoURL := "https://t.me/i/userpic/320/qwerty.jpg"
+	user := generated.SaveUserParams{
+		Username:        "qwerty",
+		Pseudonym:       "qwerty",
+		TelegramID:      &telegramID,
+		LanguageCode:    &languageCode,
+		IsPremium:       true,
+		PhotoUrl:        &photoURL,
+		AllowsWriteToPm: true,
+	}
+	id := uuid.New()
+
+	s.userProvider.On("GetUserAdminByTelegramID", mock.Anything, telegramID).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetLegacyUserAdminByUsername", mock.Anything, user.Username).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("GetRestorableUser", mock.Anything, &telegramID, user.Username, mock.Anything).
+		Return(nil, model.ErrUserNotFound).Once()
+	s.userProvider.On("IsPseudonymTaken", mock.Anything, "qwerty", (*uuid.UUID)(nil), mock.Anything).
+		Return(false, nil).Once()
+
+	var saved generated.SaveUserParams
+	s.userModifier.On("SaveUser", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(generated.SaveUserParams)
+	}).Return(&id, nil).Once()
+	s.refreshTokenModifier.On("SetRefreshToken", mock.Anything, id.String(), mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	_, _, err := s.userService.Login(ctx, user)
+	require.NoError(t, err)
+
+	s.userProvider.On("GetUserByID", mock.Anything, id).Return(&generated.User{
+		ID:              id,
+		Username:        saved.Username,
+		TelegramID:      saved.TelegramID,
+		LanguageCode:    saved.LanguageCode,
+		IsPremium:       saved.IsPremium,
+		PhotoUrl:        saved.PhotoUrl,
+		AllowsWriteToPm: saved.AllowsWriteToPm,
+	}, nil).Once()
+
+	profile, err := s.userService.GetTelegramProfile(ctx, id, id, generated.NullAdminScale{})
+	require.NoError(t, err)
+	assert.Equal(t, &model.TelegramProfile{
+		UserID:          id,
+		TelegramID:      &telegramID,
+		LanguageCode:    &languageCode,
+		IsPremium:       true,
+		PhotoURL:        &photoURL,
+		AllowsWriteToPm: true,
+	}, profile)
+}
+
+func TestGetTelegramProfile_FailNotAdmin(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+
+	_, err := s.userService.GetTelegramProfile(context.Background(), uuid.New(), uuid.New(), generated.NullAdminScale{})
+	require.ErrorIs(t, err, model.ErrUnauthorized)
+	s.userProvider.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func TestGetUsersByIDs_KeepsRequestOrder(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	s.userService.authConfig.BatchMaxIDs = 10
+	ctx := context.Background()
+
+	first, second, missing := uuid.New(), uuid.New(), uuid.New()
+	s.userProvider.On("GetUsersByIDs", mock.Anything, []uuid.UUID{first, missing, second}).
+		Return([]generated.User{{ID: second, Username: "second"}, {ID: first, Username: "first"}}, nil).Once()
+
+	users, notFound, err := s.userService.GetUsersByIDs(ctx, []uuid.UUID{first, missing, first, second})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "first", users[0].Username)
+	assert.Equal(t, "second", users[1].Username)
+	assert.Equal(t, []uuid.UUID{missing}, notFound)
+}
+
+func TestGetUsersByIDs_FailTooManyIDs(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	s.userService.authConfig.BatchMaxIDs = 2
+	ctx := context.Background()
+
+	_, _, err := s.userService.GetUsersByIDs(ctx, []uuid.UUID{uuid.New(), uuid.New(), uuid.New()})
+	assert.ErrorIs(t, err, model.ErrTooManyIDs)
+}
+
+func TestExportUsers_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	query := "metr"
+	filter := "is_admin = true"
+	params := model.GetUsersParams{
+		Query:      &query,
+		Filter:     &filter,
+		Sort:       model.Sort{{Field: "created_at", Order: "DESC"}},
+		Limit:      10,
+		PageParams: model.PageParams{UseCursor: true},
+	}
+
+	expected := model.GetUsersParams{
+		Filter: &filter,
+		Sort:   model.Sort{{Field: "created_at", Order: model.OrderDesc}, {Field: "id", Order: model.OrderAsc}},
+		Limit:  10,
+	}
+	s.userProvider.On("ExportUsers", mock.Anything, expected, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(generated.User) error)
+			require.NoError(t, fn(generated.User{Username: "first"}))
+			require.NoError(t, fn(generated.User{Username: "second"}))
+		}).
+		Return(nil).Once()
+
+	var usernames []string
+	err := s.userService.ExportUsers(ctx, params, func(user generated.User) error {
+		usernames = append(usernames, user.Username)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, usernames)
+}
+
+func TestExportUsers_FailInvalidOrder(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	params := model.GetUsersParams{Sort: model.Sort{{Field: "pseudonym_skeleton; drop", Order: model.OrderAsc}}}
+
+	err := s.userService.ExportUsers(ctx, params, func(generated.User) error { return nil })
+	assert.ErrorIs(t, err, model.ErrOrderByInvalidField)
+}
+
 func TestRefreshToken_Success(t *testing.T) {
 	t.Parallel()
 
@@ -359,7 +804,7 @@ func TestAddAdmin_Success(t *testing.T) {
 	}).Return(nil).Once()
 
 	createdAt := time.Now()
-	admin, err := s.userService.AddAdmin(ctx, username, generated.AdminScaleMajor)
+	admin, err := s.userService.AddAdmin(ctx, uuid.New(), username, generated.AdminScaleMajor)
 	require.NoError(t, err)
 	assert.NotNil(t, admin)
 
@@ -377,7 +822,7 @@ func TestAddAdmin_FailAdminNotMajor(t *testing.T) {
 	s := createService(t)
 	ctx := context.Background()
 
-	_, err := s.userService.AddAdmin(ctx, "", generated.AdminScaleMinor)
+	_, err := s.userService.AddAdmin(ctx, uuid.New(), "", generated.AdminScaleMinor)
 	assert.ErrorIs(t, err, model.ErrAdminNotMajor)
 }
 
@@ -392,7 +837,7 @@ func TestAddAdmin_FailAdminAlreadyExists(t *testing.T) {
 	s.userProvider.On("GetUserAdminByUsername", mock.Anything, username).
 		Return(&generated.GetUserAdminByUsernameRow{Scale: generated.NullAdminScale{Valid: true}}, nil).Once()
 
-	_, err := s.userService.AddAdmin(ctx, username, generated.AdminScaleMajor)
+	_, err := s.userService.AddAdmin(ctx, uuid.New(), username, generated.AdminScaleMajor)
 	assert.ErrorIs(t, err, model.ErrAdminAlreadyExists)
 }
 
@@ -435,7 +880,7 @@ func TestAddAdmin_Fail(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.beh()
 
-			_, err := s.userService.AddAdmin(ctx, "", generated.AdminScaleMajor)
+			_, err := s.userService.AddAdmin(ctx, uuid.New(), "", generated.AdminScaleMajor)
 			assert.ErrorIs(t, err, tt.err)
 		})
 	}
@@ -458,7 +903,7 @@ func TestDeleteAdmin_Success(t *testing.T) {
 
 	s.userModifier.On("DeleteAdmin", mock.Anything, id).Return(nil).Once()
 
-	err := s.userService.DeleteAdmin(ctx, id, generated.AdminScaleMajor)
+	err := s.userService.DeleteAdmin(ctx, uuid.New(), id, generated.AdminScaleMajor)
 	require.NoError(t, err)
 }
 
@@ -468,7 +913,7 @@ func TestDeleteAdmin_FailAdminNotMajor(t *testing.T) {
 	s := createService(t)
 	ctx := context.Background()
 
-	err := s.userService.DeleteAdmin(ctx, uuid.New(), generated.AdminScaleMinor)
+	err := s.userService.DeleteAdmin(ctx, uuid.New(), uuid.New(), generated.AdminScaleMinor)
 	assert.ErrorIs(t, err, model.ErrAdminNotMajor)
 }
 
@@ -487,7 +932,7 @@ func TestDeleteAdmin_FailCannotDeleteMajorAdmin(t *testing.T) {
 				AdminScale: generated.AdminScaleMajor,
 			}}, nil).Once()
 
-	err := s.userService.DeleteAdmin(ctx, id, generated.AdminScaleMajor)
+	err := s.userService.DeleteAdmin(ctx, uuid.New(), id, generated.AdminScaleMajor)
 	assert.ErrorIs(t, err, model.ErrCannotDeleteMajorAdmin)
 }
 
@@ -530,8 +975,237 @@ func TestDeleteAdmin_Fail(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.beh()
 
-			err := s.userService.DeleteAdmin(ctx, uuid.New(), generated.AdminScaleMajor)
+			err := s.userService.DeleteAdmin(ctx, uuid.New(), uuid.New(), generated.AdminScaleMajor)
 			assert.ErrorIs(t, err, tt.err)
 		})
 	}
 }
+
+func TestDeleteUser_SuccessSelf(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.userModifier.On("DeleteUser", mock.Anything, id, id).Return(nil).Once()
+	s.refreshTokenModifier.On("RevokeRefreshTokens", mock.Anything, id.String()).Return(nil).Once()
+
+	err := s.userService.DeleteUser(ctx, id, id, generated.NullAdminScale{})
+	require.NoError(t, err)
+}
+
+func TestDeleteUser_SuccessByAdmin(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id, adminID := uuid.New(), uuid.New()
+
+	s.userProvider.On("GetUserAdminByID", mock.Anything, id).
+		Return(&generated.GetUserAdminByIDRow{ID: id}, nil).Once()
+	s.userModifier.On("DeleteUser", mock.Anything, id, adminID).Return(nil).Once()
+	s.refreshTokenModifier.On("RevokeRefreshTokens", mock.Anything, id.String()).Return(nil).Once()
+
+	err := s.userService.DeleteUser(ctx, adminID, id, generated.NullAdminScale{
+		AdminScale: generated.AdminScaleMinor,
+		Valid:      true,
+	})
+	require.NoError(t, err)
+}
+
+func TestDeleteUser_Fail(t *testing.T) {
+	t.Parallel()
+
+	minor := generated.NullAdminScale{AdminScale: generated.AdminScaleMinor, Valid: true}
+	major := generated.NullAdminScale{AdminScale: generated.AdminScaleMajor, Valid: true}
+	revokeErr := errors.New("failed to revoke refresh tokens")
+
+	tests := []struct {
+		name  string
+		err   error
+		self  bool
+		scale generated.NullAdminScale
+		beh   func(s dependencies)
+	}{
+		{
+			name: "not admin",
+			err:  model.ErrUnauthorized,
+			beh:  func(s dependencies) {},
+		},
+		{
+			name:  "major admin target",
+			err:   model.ErrCannotDeleteMajorAdmin,
+			scale: major,
+			beh: func(s dependencies) {
+				s.userProvider.On("GetUserAdminByID", mock.Anything, mock.Anything).
+					Return(&generated.GetUserAdminByIDRow{Scale: major}, nil).Once()
+			},
+		},
+		{
+			name:  "minor admin deletes minor admin",
+			err:   model.ErrAdminNotMajor,
+			scale: minor,
+			beh: func(s dependencies) {
+				s.userProvider.On("GetUserAdminByID", mock.Anything, mock.Anything).
+					Return(&generated.GetUserAdminByIDRow{Scale: minor}, nil).Once()
+			},
+		},
+		{
+			name:  "last major admin deletes self",
+			err:   model.ErrLastMajorAdmin,
+			self:  true,
+			scale: major,
+			beh: func(s dependencies) {
+				s.userProvider.On("GetAdmins", mock.Anything, mock.MatchedBy(func(params model.GetAdminsParams) bool {
+					return params.AdminScale == major
+				})).Return([]model.Admin{{Scale: generated.AdminScaleMajor}}, nil, nil).Once()
+			},
+		},
+		{
+			name: "user not found",
+			err:  model.ErrUserNotFound,
+			self: true,
+			beh: func(s dependencies) {
+				s.userModifier.On("DeleteUser", mock.Anything, mock.Anything, mock.Anything).
+					Return(model.ErrUserNotFound).Once()
+			},
+		},
+		{
+			name: "revoke refresh tokens error",
+			err:  revokeErr,
+			self: true,
+			beh: func(s dependencies) {
+				s.userModifier.On("DeleteUser", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				s.refreshTokenModifier.On("RevokeRefreshTokens", mock.Anything, mock.Anything).
+					Return(revokeErr).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := createService(t)
+			tt.beh(s)
+
+			id := uuid.New()
+			actorID := uuid.New()
+			if tt.self {
+				actorID = id
+			}
+
+			err := s.userService.DeleteUser(context.Background(), actorID, id, tt.scale)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestDeleteUser_SuccessMajorAdminSelf(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	id := uuid.New()
+
+	s.userProvider.On("GetAdmins", mock.Anything, mock.Anything).
+		Return([]model.Admin{{ID: id, Scale: generated.AdminScaleMajor}, {ID: uuid.New(), Scale: generated.AdminScaleMajor}}, nil, nil).Once()
+	s.userModifier.On("DeleteUser", mock.Anything, id, id).Return(nil).Once()
+	s.refreshTokenModifier.On("RevokeRefreshTokens", mock.Anything, id.String()).Return(nil).Once()
+
+	err := s.userService.DeleteUser(ctx, id, id, generated.NullAdminScale{AdminScale: generated.AdminScaleMajor, Valid: true})
+	require.NoError(t, err)
+}
+
+func TestDeleteUser_MinorAdminPendingApproval(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	s.userService.authConfig.AdminApproval = true
+	ctx := context.Background()
+
+	id, actorID := uuid.New(), uuid.New()
+
+	s.userProvider.On("GetUserAdminByID", mock.Anything, id).
+		Return(&generated.GetUserAdminByIDRow{
+			ID:    id,
+			Scale: generated.NullAdminScale{AdminScale: generated.AdminScaleMinor, Valid: true},
+		}, nil).Once()
+	s.userModifier.On("SaveAdminRequest", mock.Anything, mock.MatchedBy(func(params generated.SaveAdminRequestParams) bool {
+		return params.Action == generated.AdminRequestActionDeleteAdmin &&
+			params.TargetUserID == id &&
+			params.RequestedBy == actorID
+	})).Return(&generated.AdminRequest{ID: uuid.New()}, nil).Once()
+
+	// account is not deleted until removal of admin role is approved
+	err := s.userService.DeleteUser(ctx, actorID, id, generated.NullAdminScale{AdminScale: generated.AdminScaleMajor, Valid: true})
+	assert.ErrorIs(t, err, model.ErrAdminChangePending)
+	s.userModifier.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAnonymizeDeletedUsers_Success(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	s.userModifier.On("AnonymizeDeletedUsers", mock.Anything, mock.Anything).Return(ids, nil).Once()
+	s.userModifier.On("PublishOutboxEvents", mock.Anything, int32(outboxBatchSize), mock.Anything).
+		Return(func(_ context.Context, _ int32, publish func(model.Event) error) (int, error) {
+			for i, id := range ids {
+				if err := publish(model.Event{ID: uuid.New(), Type: model.EventUserAnonymized, UserID: id}); err != nil {
+					return i, err
+				}
+			}
+			return len(ids), nil
+		}).Once()
+	for _, id := range ids {
+		s.eventPublisher.On("Publish", mock.Anything, mock.MatchedBy(func(event model.Event) bool {
+			return event.Type == model.EventUserAnonymized && event.UserID == id
+		})).Return(nil).Once()
+	}
+
+	count, err := s.userService.AnonymizeDeletedUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, len(ids), count)
+}
+
+func TestAnonymizeDeletedUsers_SuccessFullOutbox(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	s.userModifier.On("AnonymizeDeletedUsers", mock.Anything, mock.Anything).Return([]uuid.UUID{}, nil).Once()
+	s.userModifier.On("PublishOutboxEvents", mock.Anything, int32(outboxBatchSize), mock.Anything).
+		Return(outboxBatchSize, nil).Once()
+	s.userModifier.On("PublishOutboxEvents", mock.Anything, int32(outboxBatchSize), mock.Anything).
+		Return(1, nil).Once()
+
+	_, err := s.userService.AnonymizeDeletedUsers(ctx)
+	require.NoError(t, err)
+	s.userModifier.AssertNumberOfCalls(t, "PublishOutboxEvents", 2)
+}
+
+func TestAnonymizeDeletedUsers_FailPublish(t *testing.T) {
+	t.Parallel()
+
+	s := createService(t)
+	ctx := context.Background()
+
+	publishErr := errors.New("failed to publish")
+
+	s.userModifier.On("AnonymizeDeletedUsers", mock.Anything, mock.Anything).Return([]uuid.UUID{uuid.New()}, nil).Once()
+	s.userModifier.On("PublishOutboxEvents", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, _ int32, publish func(model.Event) error) (int, error) {
+			return 0, publish(model.Event{ID: uuid.New(), Type: model.EventUserAnonymized})
+		}).Once()
+	s.eventPublisher.On("Publish", mock.Anything, mock.Anything).Return(publishErr).Once()
+
+	_, err := s.userService.AnonymizeDeletedUsers(ctx)
+	assert.ErrorIs(t, err, publishErr)
+}
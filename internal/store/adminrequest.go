@@ -0,0 +1,142 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *UserStore) SaveAdminRequest(ctx context.Context, params generated.SaveAdminRequestParams) (*generated.AdminRequest, error) {
+	request, err := s.Queries.SaveAdminRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+func (s *UserStore) GetAdminRequestByID(ctx context.Context, id uuid.UUID) (*generated.AdminRequest, error) {
+	request, err := s.Queries.GetAdminRequestByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAdminRequestNotFound
+		}
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+func (s *UserStore) GetAdminRequests(ctx context.Context, params generated.GetAdminRequestsParams) (requests []generated.AdminRequest, total *uint64, err error) {
+	cnt, err := s.Queries.CountAdminRequests(ctx, params.Status)
+	if err != nil {
+		s.log.Error("failed to count admin requests", sl.Err(err))
+		return nil, nil, err
+	}
+
+	requests, err = s.Queries.GetAdminRequests(ctx, params)
+	if err != nil {
+		s.log.Error("failed to query admin requests", sl.Err(err))
+		return nil, nil, err
+	}
+
+	tmp := uint64(cnt)
+	total = &tmp
+
+	return requests, total, nil
+}
+
+// ExpireAdminRequests marks pending requests past their expiry as expired and returns their count.
+func (s *UserStore) ExpireAdminRequests(ctx context.Context) (int64, error) {
+	return s.Queries.ExpireAdminRequests(ctx)
+}
+
+// ApproveAdminRequest marks pending request as approved and applies it in one transaction. Target
+// user and admin role are locked and checked in the transaction, so concurrent admin changes can
+// not make the request add existing admin or delete major admin.
+func (s *UserStore) ApproveAdminRequest(ctx context.Context, id, approverID uuid.UUID) (*generated.AdminRequest, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	request, err := qtx.DecideAdminRequest(ctx, generated.DecideAdminRequestParams{
+		Status:    generated.AdminRequestStatusApproved,
+		DecidedBy: pgtype.UUID{Bytes: approverID, Valid: true},
+		ID:        id,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAdminRequestNotFound
+		}
+		return nil, err
+	}
+
+	if _, err := qtx.LockUser(ctx, request.TargetUserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	scale, err := qtx.GetAdminScaleForUpdate(ctx, request.TargetUserID)
+	isAdmin := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	switch request.Action {
+	case generated.AdminRequestActionAddAdmin:
+		if isAdmin {
+			return nil, model.ErrAdminAlreadyExists
+		}
+
+		err = qtx.SaveAdmin(ctx, generated.SaveAdminParams{
+			UserID: request.TargetUserID,
+			Scale:  request.Scale.AdminScale,
+		})
+		if isUniqueViolation(err, adminKey) {
+			return nil, model.ErrAdminAlreadyExists
+		}
+	case generated.AdminRequestActionDeleteAdmin:
+		if scale == generated.AdminScaleMajor {
+			return nil, model.ErrCannotDeleteMajorAdmin
+		}
+
+		err = qtx.DeleteAdmin(ctx, request.TargetUserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+func (s *UserStore) RejectAdminRequest(ctx context.Context, id, approverID uuid.UUID) (*generated.AdminRequest, error) {
+	request, err := s.Queries.DecideAdminRequest(ctx, generated.DecideAdminRequestParams{
+		Status:    generated.AdminRequestStatusRejected,
+		DecidedBy: pgtype.UUID{Bytes: approverID, Valid: true},
+		ID:        id,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrAdminRequestNotFound
+		}
+		return nil, err
+	}
+
+	return &request, nil
+}
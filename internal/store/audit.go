@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/postgres"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type AuditStore struct {
+	*postgres.Postgres
+	*generated.Queries
+	log *slog.Logger
+}
+
+func NewAuditStore(pg *postgres.Postgres, log *slog.Logger) *AuditStore {
+	return &AuditStore{pg, generated.New(pg.DB), log}
+}
+
+// SaveAuditEvents copies batch of events in one round trip.
+func (s *AuditStore) SaveAuditEvents(ctx context.Context, events []model.AuditEvent) error {
+	rows := make([]generated.SaveAuditEventsParams, 0, len(events))
+	for _, event := range events {
+		metadata, err := json.Marshal(event.Metadata)
+		if err != nil {
+			return err
+		}
+		if event.Metadata == nil {
+			metadata = []byte("{}")
+		}
+
+		rows = append(rows, generated.SaveAuditEventsParams{
+			Action:    event.Action,
+			Outcome:   event.Outcome,
+			ActorID:   toPgUUID(event.ActorID),
+			TargetID:  toPgUUID(event.TargetID),
+			Ip:        nonEmpty(event.Client.IP),
+			UserAgent: nonEmpty(event.Client.UserAgent),
+			Metadata:  metadata,
+			CreatedAt: pgtype.Timestamp{Time: event.OccurredAt, Valid: true},
+		})
+	}
+
+	_, err := s.Queries.SaveAuditEvents(ctx, rows)
+	return err
+}
+
+// DeleteAuditEventsBefore removes events which are older than retention period.
+func (s *AuditStore) DeleteAuditEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	return s.Queries.DeleteAuditEventsBefore(ctx, pgtype.Timestamp{Time: before, Valid: true})
+}
+
+// GetAuditEvents returns matching events from the newest one.
+func (s *AuditStore) GetAuditEvents(ctx context.Context, params model.GetAuditEventsParams) (events []generated.AuditEvent, total *uint64, err error) {
+	where := sq.And{}
+	if params.ActorID != nil {
+		where = append(where, sq.Eq{`"actor_id"`: *params.ActorID})
+	}
+	if params.TargetID != nil {
+		where = append(where, sq.Eq{`"target_id"`: *params.TargetID})
+	}
+	if params.Action != nil {
+		where = append(where, sq.Eq{`"action"`: *params.Action})
+	}
+	if params.Outcome != nil {
+		where = append(where, sq.Eq{`"outcome"`: *params.Outcome})
+	}
+	if params.From != nil {
+		where = append(where, sq.GtOrEq{`"created_at"`: *params.From})
+	}
+	if params.To != nil {
+		where = append(where, sq.Lt{`"created_at"`: *params.To})
+	}
+
+	builder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	stmt, args, err := builder.Select("count(*)").From(`"audit_events"`).Where(where).ToSql()
+	if err != nil {
+		s.log.Error("failed to convert to sql", sl.Err(err))
+		return nil, nil, err
+	}
+
+	var cnt uint64
+	if err := s.DB.QueryRow(ctx, stmt, args...).Scan(&cnt); err != nil {
+		s.log.Error("failed to count audit events", sl.Err(err))
+		return nil, nil, err
+	}
+	total = &cnt
+
+	stmt, args, err = builder.Select("*").From(`"audit_events"`).Where(where).
+		OrderBy(`"created_at" desc`, `"id"`).
+		Limit(params.Limit).Offset(params.Offset).
+		ToSql()
+	if err != nil {
+		s.log.Error("failed to convert to sql", sl.Err(err))
+		return nil, nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	if err != nil {
+		s.log.Error("failed to query audit events", sl.Err(err))
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	events, err = pgx.CollectRows(rows, pgx.RowToStructByName[generated.AuditEvent])
+	if err != nil {
+		s.log.Error("failed to scan audit events", sl.Err(err))
+		return nil, nil, err
+	}
+
+	return events, total, nil
+}
+
+func toPgUUID(id *uuid.UUID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{}
+	}
+
+	return pgtype.UUID{Bytes: *id, Valid: true}
+}
+
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
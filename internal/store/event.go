@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/redis"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// usersStream is a redis stream other services consume user events from.
+const usersStream = "events:users"
+
+type EventStore struct {
+	*redis.Redis
+}
+
+func NewEventStore(r *redis.Redis) *EventStore {
+	return &EventStore{r}
+}
+
+func (s *EventStore) Publish(ctx context.Context, event model.Event) error {
+	err := s.Redis.XAdd(ctx, &rdb.XAddArgs{
+		Stream: usersStream,
+		Values: map[string]any{
+			"event_id":    event.ID.String(),
+			"type":        event.Type,
+			"user_id":     event.UserID.String(),
+			"occurred_at": event.OccurredAt.UTC().Format(time.RFC3339),
+		},
+	}).Err()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
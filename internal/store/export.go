@@ -0,0 +1,152 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/redis"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// GetUserData reads everything stored about user from one snapshot.
+func (s *UserStore) GetUserData(ctx context.Context, id uuid.UUID) (*model.UserData, error) {
+	tx, err := s.DB.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	user, err := qtx.GetUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	var admin *generated.UsersAdmin
+	usersAdmin, err := qtx.GetAdminByUserID(ctx, id)
+	if err == nil {
+		admin = &usersAdmin
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	requests, err := qtx.GetUserAdminRequests(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations, err := qtx.GetUserOrganizations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	pseudonyms, err := qtx.GetPseudonymHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := qtx.GetAllUserMetadata(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	auditEvents, err := qtx.GetUserAuditEvents(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return model.ToUserData(user, admin, requests, organizations, pseudonyms, metadata, auditEvents), nil
+}
+
+func (s *RefreshTokenStore) GetSessions(ctx context.Context, userID string) ([]model.Session, error) {
+	tokenIDs, err := s.Redis.SMembers(ctx, userTokensKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	cmds, err := s.Redis.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for _, tokenID := range tokenIDs {
+			pipe.TTL(ctx, tokenID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sessions := []model.Session{}
+	for _, cmd := range cmds {
+		// Negative ttl means token has already expired
+		ttl := cmd.(*rdb.DurationCmd).Val()
+		if ttl <= 0 {
+			continue
+		}
+		sessions = append(sessions, model.Session{ExpiresAt: now.Add(ttl)})
+	}
+
+	return sessions, nil
+}
+
+type ExportStore struct {
+	*redis.Redis
+}
+
+func NewExportStore(r *redis.Redis) *ExportStore {
+	return &ExportStore{r}
+}
+
+func exportKey(token string) string {
+	return "exports:" + token
+}
+
+func (s *ExportStore) SaveExport(ctx context.Context, token string, export model.Export, expiry time.Duration) error {
+	_, err := s.Redis.TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+		pipe.HSet(ctx, exportKey(token), map[string]any{
+			"status":       export.Status,
+			"requested_by": export.RequestedBy,
+			"data":         export.Data,
+		})
+		pipe.Expire(ctx, exportKey(token), expiry)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *ExportStore) GetExport(ctx context.Context, token string) (*model.Export, error) {
+	values, err := s.Redis.HGetAll(ctx, exportKey(token)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 0 {
+		return nil, model.ErrExportNotFound
+	}
+
+	return &model.Export{
+		Status:      values["status"],
+		RequestedBy: values["requested_by"],
+		Data:        []byte(values["data"]),
+	}, nil
+}
@@ -0,0 +1,203 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/filter"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type filterKind int
+
+const (
+	filterText filterKind = iota
+	filterUUID
+	filterTime
+	filterBool
+	filterAdminScale
+)
+
+// filterField describes field allowed in users filter, column is quoted users column
+// or subquery condition for fields not stored in users.
+type filterField struct {
+	column string
+	kind   filterKind
+	ops    []filter.Op
+}
+
+var (
+	equalityOps   = []filter.Op{filter.OpEq, filter.OpNe, filter.OpIn}
+	comparisonOps = []filter.Op{filter.OpEq, filter.OpNe, filter.OpLt, filter.OpLe, filter.OpGt, filter.OpGe}
+	boolOps       = []filter.Op{filter.OpEq, filter.OpNe}
+)
+
+// isAdmin and adminScale are conditions on users_admins row of filtered user.
+const (
+	isAdmin    = `exists (select 1 from "users_admins" ua where ua."user_id" = "users"."id")`
+	adminScale = `exists (select 1 from "users_admins" ua where ua."user_id" = "users"."id" and ua."scale"::text = any(?))`
+)
+
+// userFilterFields whitelists fields users may be filtered by.
+var userFilterFields = map[string]filterField{
+	"id":            {column: `"id"`, kind: filterUUID, ops: equalityOps},
+	"username":      {column: `"username"`, kind: filterText, ops: equalityOps},
+	"pseudonym":     {column: `"pseudonym"`, kind: filterText, ops: equalityOps},
+	"first_name":    {column: `"first_name"`, kind: filterText, ops: equalityOps},
+	"last_name":     {column: `"last_name"`, kind: filterText, ops: equalityOps},
+	"language_code": {column: `"language_code"`, kind: filterText, ops: equalityOps},
+	"is_premium":    {column: `"is_premium"`, kind: filterBool, ops: boolOps},
+	"is_deleted":    {column: `"is_deleted"`, kind: filterBool, ops: boolOps},
+	"created_at":    {column: `"created_at"`, kind: filterTime, ops: comparisonOps},
+	"updated_at":    {column: `"updated_at"`, kind: filterTime, ops: comparisonOps},
+	"deleted_at":    {column: `"deleted_at"`, kind: filterTime, ops: comparisonOps},
+	"is_admin":      {column: isAdmin, kind: filterBool, ops: boolOps},
+	"admin_scale":   {column: adminScale, kind: filterAdminScale, ops: equalityOps},
+}
+
+// deletedFilterField may be filtered by only when deleted users are included, otherwise
+// "is_deleted = true" would be a way around listing active users only.
+const deletedFilterField = "is_deleted"
+
+// userFilter parses filter and converts it to users query predicate.
+func userFilter(s string, includeDeleted bool) (sq.Sqlizer, error) {
+	expr, err := filter.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", model.ErrInvalidFilter, err)
+	}
+	if expr == nil {
+		return nil, nil
+	}
+
+	return filterPredicate(expr, includeDeleted)
+}
+
+func filterPredicate(expr filter.Expr, includeDeleted bool) (sq.Sqlizer, error) {
+	switch expr := expr.(type) {
+	case filter.And:
+		and := make(sq.And, 0, len(expr))
+		for _, e := range expr {
+			pred, err := filterPredicate(e, includeDeleted)
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, pred)
+		}
+		return and, nil
+	case filter.Or:
+		or := make(sq.Or, 0, len(expr))
+		for _, e := range expr {
+			pred, err := filterPredicate(e, includeDeleted)
+			if err != nil {
+				return nil, err
+			}
+			or = append(or, pred)
+		}
+		return or, nil
+	case filter.Not:
+		pred, err := filterPredicate(expr.Expr, includeDeleted)
+		if err != nil {
+			return nil, err
+		}
+		// null compared columns make negation null too, coalesce keeps NOT symmetric
+		return sq.Expr("not coalesce(?, false)", pred), nil
+	case filter.Restriction:
+		if expr.Field == deletedFilterField && !includeDeleted {
+			return nil, fmt.Errorf("%w: %q requires deleted users to be included", model.ErrInvalidFilter, expr.Field)
+		}
+		return restrictionPredicate(expr)
+	default:
+		return nil, fmt.Errorf("%w: unsupported expression", model.ErrInvalidFilter)
+	}
+}
+
+func restrictionPredicate(r filter.Restriction) (sq.Sqlizer, error) {
+	field, ok := userFilterFields[r.Field]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown field %q", model.ErrInvalidFilter, r.Field)
+	}
+
+	allowed := false
+	for _, op := range field.ops {
+		allowed = allowed || op == r.Op
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: operator %s is not supported for %q", model.ErrInvalidFilter, r.Op, r.Field)
+	}
+
+	values := make([]any, 0, len(r.Values))
+	for _, v := range r.Values {
+		value, err := filterValue(field.kind, v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", model.ErrInvalidFilter, r.Field, err)
+		}
+		values = append(values, value)
+	}
+
+	switch field.kind {
+	case filterBool:
+		if field.column == isAdmin {
+			if values[0] == (r.Op == filter.OpEq) {
+				return sq.Expr(isAdmin), nil
+			}
+			return sq.Expr("not " + isAdmin), nil
+		}
+	case filterAdminScale:
+		scales := make([]string, 0, len(values))
+		for _, v := range values {
+			scales = append(scales, v.(string))
+		}
+		if r.Op == filter.OpNe {
+			return sq.Expr("not "+adminScale, scales), nil
+		}
+		return sq.Expr(adminScale, scales), nil
+	}
+
+	switch r.Op {
+	case filter.OpEq:
+		return sq.Eq{field.column: values[0]}, nil
+	case filter.OpNe:
+		return sq.NotEq{field.column: values[0]}, nil
+	case filter.OpLt:
+		return sq.Lt{field.column: values[0]}, nil
+	case filter.OpLe:
+		return sq.LtOrEq{field.column: values[0]}, nil
+	case filter.OpGt:
+		return sq.Gt{field.column: values[0]}, nil
+	case filter.OpGe:
+		return sq.GtOrEq{field.column: values[0]}, nil
+	default:
+		return sq.Eq{field.column: values}, nil
+	}
+}
+
+func filterValue(kind filterKind, value string) (any, error) {
+	switch kind {
+	case filterUUID:
+		return uuid.Parse(value)
+	case filterBool:
+		return strconv.ParseBool(value)
+	case filterTime:
+		if t, err := time.Parse(time.DateOnly, value); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errors.New("expected RFC 3339 timestamp or date")
+		}
+		return t.UTC(), nil
+	case filterAdminScale:
+		scale := generated.AdminScale(value)
+		if scale != generated.AdminScaleMinor && scale != generated.AdminScaleMajor {
+			return nil, fmt.Errorf("expected %s or %s", generated.AdminScaleMinor, generated.AdminScaleMajor)
+		}
+		return string(scale), nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsersQuery_FilterCanNotSurfaceDeletedUsers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter string
+	}{
+		{name: "deleted", filter: `is_deleted = true`},
+		{name: "not active", filter: `is_deleted != false`},
+		{name: "negation", filter: `NOT is_deleted = false`},
+		{name: "nested", filter: `username = "bob" OR (is_premium = true AND is_deleted = true)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := usersQuery(model.GetUsersParams{Filter: &tt.filter})
+			require.ErrorIs(t, err, model.ErrInvalidFilter)
+		})
+	}
+}
+
+func TestUsersQuery_SkipsDeletedUsers(t *testing.T) {
+	t.Parallel()
+
+	filter := `is_premium = true`
+	query, err := usersQuery(model.GetUsersParams{Filter: &filter})
+	require.NoError(t, err)
+
+	stmt, args, err := query.ToSql()
+	require.NoError(t, err)
+	assert.Contains(t, stmt, "WHERE is_deleted = $1")
+	assert.Equal(t, false, args[0])
+}
+
+func TestUsersQuery_IncludeDeleted(t *testing.T) {
+	t.Parallel()
+
+	filter := `is_deleted = true`
+	query, err := usersQuery(model.GetUsersParams{Filter: &filter, IncludeDeleted: true})
+	require.NoError(t, err)
+
+	stmt, args, err := query.ToSql()
+	require.NoError(t, err)
+	assert.Contains(t, stmt, `WHERE "is_deleted" = $1`)
+	assert.NotContains(t, stmt, "WHERE is_deleted =")
+	assert.Equal(t, []any{true}, args)
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/google/uuid"
+)
+
+func (s *UserStore) GetUserMetadata(ctx context.Context, userID uuid.UUID, namespace string) (*generated.UsersMetadatum, error) {
+	metadata, err := s.Queries.GetUserMetadata(ctx, generated.GetUserMetadataParams{
+		UserID:    userID,
+		Namespace: namespace,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrMetadataNotFound
+		}
+		return nil, err
+	}
+
+	return &metadata, nil
+}
+
+func (s *UserStore) GetUserMetadataByNamespaces(ctx context.Context, userID uuid.UUID, namespaces []string) ([]generated.UsersMetadatum, error) {
+	return s.Queries.GetUserMetadataByNamespaces(ctx, generated.GetUserMetadataByNamespacesParams{
+		UserID:     userID,
+		Namespaces: namespaces,
+	})
+}
+
+// UpdateUserMetadata replaces namespace metadata with result of update, user row is locked
+// so concurrent patches of the same user are applied one after another. Current data is nil
+// when namespace was never set.
+func (s *UserStore) UpdateUserMetadata(ctx context.Context, userID uuid.UUID, namespace string, update func(current []byte) ([]byte, error)) (*generated.UsersMetadatum, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	if _, err := qtx.GetUserByIDForUpdate(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	var current []byte
+	metadata, err := qtx.GetUserMetadata(ctx, generated.GetUserMetadataParams{
+		UserID:    userID,
+		Namespace: namespace,
+	})
+	switch {
+	case err == nil:
+		current = metadata.Data
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	data, err := update(current)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err = qtx.SetUserMetadata(ctx, generated.SetUserMetadataParams{
+		UserID:    userID,
+		Namespace: namespace,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &metadata, nil
+}
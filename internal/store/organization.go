@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/postgres"
+	"github.com/google/uuid"
+)
+
+type OrganizationStore struct {
+	*postgres.Postgres
+	*generated.Queries
+	log *slog.Logger
+}
+
+func NewOrganizationStore(pg *postgres.Postgres, log *slog.Logger) *OrganizationStore {
+	return &OrganizationStore{pg, generated.New(pg.DB), log}
+}
+
+// CreateOrganization saves organization together with its owner membership.
+func (s *OrganizationStore) CreateOrganization(ctx context.Context, name string, ownerID uuid.UUID) (*generated.Organization, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	organization, err := qtx.SaveOrganization(ctx, generated.SaveOrganizationParams{
+		Name:      name,
+		CreatedBy: ownerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = qtx.SaveOrganizationMember(ctx, generated.SaveOrganizationMemberParams{
+		OrganizationID: organization.ID,
+		UserID:         ownerID,
+		Role:           generated.OrganizationRoleOwner,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &organization, nil
+}
+
+func (s *OrganizationStore) SaveOrganizationMember(ctx context.Context, params generated.SaveOrganizationMemberParams) error {
+	if err := s.Queries.SaveOrganizationMember(ctx, params); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *OrganizationStore) DeleteOrganizationMember(ctx context.Context, params generated.DeleteOrganizationMemberParams) error {
+	if err := s.Queries.DeleteOrganizationMember(ctx, params); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *OrganizationStore) GetOrganizationMember(ctx context.Context, organizationID, userID uuid.UUID) (*generated.OrganizationMember, error) {
+	member, err := s.Queries.GetOrganizationMember(ctx, generated.GetOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrOrganizationMemberNotFound
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (s *OrganizationStore) GetUserOrganizations(ctx context.Context, userID uuid.UUID) ([]generated.GetUserOrganizationsRow, error) {
+	return s.Queries.GetUserOrganizations(ctx, userID)
+}
@@ -0,0 +1,61 @@
+package store
+
+import (
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// userSortColumns and adminSortColumns map sort fields to columns of users and admins queries.
+var (
+	userSortColumns = map[string]string{
+		"id":         `"id"`,
+		"username":   `"username"`,
+		"pseudonym":  `"pseudonym"`,
+		"first_name": `"first_name"`,
+		"last_name":  `"last_name"`,
+		"created_at": `"created_at"`,
+		"updated_at": `"updated_at"`,
+	}
+	adminSortColumns = map[string]string{
+		"id":         `u."id"`,
+		"username":   `u."username"`,
+		"scale":      `ua."scale"`,
+		"created_at": `ua."created_at"`,
+	}
+)
+
+// orderBy orders query by sort, fields were validated by service so every field has column.
+func orderBy(query sq.SelectBuilder, sort model.Sort, columns map[string]string) sq.SelectBuilder {
+	for _, field := range sort {
+		query = query.OrderBy(columns[field.Field] + " " + field.Order)
+	}
+
+	return query
+}
+
+// keyset orders query by sort and selects rows following cursor, one extra row is read
+// so caller can tell whether there are more rows. Sort ends with unique id, so rows after
+// cursor are the ones greater in first differing field.
+func keyset(query sq.SelectBuilder, sort model.Sort, cursor *model.Cursor, limit uint64, columns map[string]string) (sq.SelectBuilder, error) {
+	if cursor != nil {
+		values, err := cursor.SortValues()
+		if err != nil {
+			return query, err
+		}
+
+		after := make(sq.Or, 0, len(sort))
+		for i, field := range sort {
+			cond := make(sq.And, 0, i+1)
+			for j := range i {
+				cond = append(cond, sq.Eq{columns[sort[j].Field]: values[j]})
+			}
+			cond = append(cond, sq.Expr(columns[field.Field]+" "+cursor.Operator(field)+" ?", values[i]))
+			after = append(after, cond)
+		}
+
+		query = query.Where(after)
+		sort = cursor.ScanSort()
+	}
+
+	return orderBy(query, sort, columns).Limit(limit + 1), nil
+}
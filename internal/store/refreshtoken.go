@@ -17,6 +17,11 @@ func NewRefreshTokenStore(r *redis.Redis) *RefreshTokenStore {
 	return &RefreshTokenStore{r}
 }
 
+// userTokensKey is a set of refresh tokens issued to user, used to revoke all sessions.
+func userTokensKey(userID string) string {
+	return "refresh_tokens:" + userID
+}
+
 func (s *RefreshTokenStore) GetRefreshToken(ctx context.Context, tokenID string) (*string, error) {
 	userID, err := s.Redis.Get(ctx, tokenID).Result()
 	if err == rdb.Nil {
@@ -29,7 +34,14 @@ func (s *RefreshTokenStore) GetRefreshToken(ctx context.Context, tokenID string)
 }
 
 func (s *RefreshTokenStore) SetRefreshToken(ctx context.Context, userID, tokenID string, expiry time.Duration) error {
-	if err := s.Redis.Set(ctx, tokenID, userID, expiry).Err(); err != nil {
+	_, err := s.Redis.TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+		pipe.Set(ctx, tokenID, userID, expiry)
+		pipe.SAdd(ctx, userTokensKey(userID), tokenID)
+		pipe.Expire(ctx, userTokensKey(userID), expiry)
+
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
@@ -40,6 +52,30 @@ func (s *RefreshTokenStore) ReplaceRefreshToken(ctx context.Context, oldID, newI
 	_, err := s.Redis.TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
 		pipe.Del(ctx, oldID)
 		pipe.Set(ctx, newID, userID, expiry)
+		pipe.SRem(ctx, userTokensKey(userID), oldID)
+		pipe.SAdd(ctx, userTokensKey(userID), newID)
+		pipe.Expire(ctx, userTokensKey(userID), expiry)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *RefreshTokenStore) RevokeRefreshTokens(ctx context.Context, userID string) error {
+	tokenIDs, err := s.Redis.SMembers(ctx, userTokensKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Redis.TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+		if len(tokenIDs) > 0 {
+			pipe.Del(ctx, tokenIDs...)
+		}
+		pipe.Del(ctx, userTokensKey(userID))
 
 		return nil
 	})
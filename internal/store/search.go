@@ -0,0 +1,49 @@
+package store
+
+import (
+	"strings"
+	"unicode"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// searchText is indexed expression user search runs against, see users_search migration.
+const searchText = `users_search_text("username", "pseudonym", "first_name", "last_name")`
+
+// maxSearchTerms limits words of search query taken into account.
+const maxSearchTerms = 8
+
+// searchTerms splits query into lowercase words, punctuation is dropped so query can not
+// inject tsquery operators.
+func searchTerms(query string) []string {
+	terms := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+
+	return terms
+}
+
+// searchUsers filters active users matching every term as word prefix or similar to
+// the whole query by trigrams, it returns relevance expression to order results by.
+func searchUsers(query sq.SelectBuilder, terms []string) (sq.SelectBuilder, sq.Sqlizer) {
+	text := strings.Join(terms, " ")
+	tsQuery := strings.Join(terms, ":* & ") + ":*"
+
+	// literal condition lets planner use partial search indexes
+	query = query.Where(`"is_deleted" = false`).Where(sq.Or{
+		sq.Expr("to_tsvector('simple', "+searchText+") @@ to_tsquery('simple', immutable_unaccent(?))", tsQuery),
+		sq.Expr("immutable_unaccent(?) <% "+searchText, text),
+	})
+
+	rank := sq.Expr(
+		"greatest(ts_rank(to_tsvector('simple', "+searchText+"), to_tsquery('simple', immutable_unaccent(?))), "+
+			"word_similarity(immutable_unaccent(?), "+searchText+")) desc",
+		tsQuery, text,
+	)
+
+	return query, rank
+}
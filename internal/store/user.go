@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"time"
 
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
 	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
@@ -15,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type UserStore struct {
@@ -27,9 +30,80 @@ func NewUserStore(pg *postgres.Postgres, log *slog.Logger) *UserStore {
 	return &UserStore{pg, generated.New(pg.DB), log}
 }
 
-func (s *UserStore) UpdateUser(ctx context.Context, updateUser generated.UpdateUserParams) (*generated.User, error) {
-	user, err := s.Queries.UpdateUser(ctx, updateUser)
+// querier is implemented by both connection pool and transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Unique indexes on pseudonym skeleton and username of active users, and primary key of admins.
+const (
+	pseudonymSkeletonKey = "users_pseudonym_skeleton_active_key"
+	usernameKey          = "users_username_active_key"
+	adminKey             = "users_admins_pkey"
+)
+
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == constraint
+}
+
+// UpdateUser updates user and records pseudonym change made by changedBy in one transaction.
+// Pseudonym can not be changed again within cooldown, zero cooldown skips the check.
+func (s *UserStore) UpdateUser(ctx context.Context, updateUser generated.UpdateUserParams, changedBy uuid.UUID, cooldown time.Duration) (*generated.User, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	var current generated.User
+	if updateUser.Pseudonym != nil {
+		current, err = qtx.GetUserByIDForUpdate(ctx, updateUser.ID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, model.ErrUserNotFound
+			}
+			return nil, err
+		}
+
+		// Row lock serializes concurrent changes, so only one of them passes cooldown
+		if cooldown > 0 && current.Pseudonym != *updateUser.Pseudonym {
+			change, err := qtx.GetLastPseudonymChange(ctx, updateUser.ID)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+
+			if next := change.ChangedAt.Time.Add(cooldown); err == nil && time.Now().Before(next) {
+				return nil, fmt.Errorf("%w: next change after %s", model.ErrPseudonymCooldown, next.UTC().Format(time.RFC3339))
+			}
+		}
+	}
+
+	user, err := qtx.UpdateUser(ctx, updateUser)
 	if err != nil {
+		if isUniqueViolation(err, pseudonymSkeletonKey) {
+			return nil, model.ErrPseudonymTaken
+		}
+		return nil, err
+	}
+
+	if updateUser.Pseudonym != nil && current.Pseudonym != user.Pseudonym {
+		err = qtx.SavePseudonymChange(ctx, generated.SavePseudonymChangeParams{
+			UserID:               user.ID,
+			OldPseudonym:         current.Pseudonym,
+			OldPseudonymSkeleton: current.PseudonymSkeleton,
+			NewPseudonym:         user.Pseudonym,
+			ChangedBy:            changedBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
@@ -39,7 +113,96 @@ func (s *UserStore) UpdateUser(ctx context.Context, updateUser generated.UpdateU
 func (s *UserStore) GetUsers(ctx context.Context, params model.GetUsersParams) (users []generated.User, total *uint64, err error) {
 	builder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
-	query := builder.Select(
+	query, err := usersQuery(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var db querier = s.DB
+	var rank sq.Sqlizer
+	if params.Query != nil {
+		terms := searchTerms(*params.Query)
+		if len(terms) == 0 {
+			return nil, new(uint64), nil
+		}
+
+		tx, err := s.DB.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+		if err != nil {
+			return nil, nil, err
+		}
+		defer tx.Rollback(ctx) // nolint
+
+		// word similarity operator compares with threshold of session, local setting is reset with transaction
+		if params.SimilarityThreshold > 0 {
+			threshold := strconv.FormatFloat(params.SimilarityThreshold, 'f', -1, 64)
+			if _, err := tx.Exec(ctx, "select set_config('pg_trgm.word_similarity_threshold', $1, true)", threshold); err != nil {
+				s.log.Error("failed to set similarity threshold", sl.Err(err))
+				return nil, nil, err
+			}
+		}
+
+		db = tx
+		query, rank = searchUsers(query, terms)
+	}
+
+	if !params.SkipTotal {
+		count := builder.Select("count(*)").FromSelect(query, "u")
+		stmt, args, err := count.ToSql()
+		if err != nil {
+			s.log.Error("failed to convert to sql", sl.Err(err))
+			return nil, nil, err
+		}
+
+		err = db.QueryRow(ctx, stmt, args...).Scan(&total)
+		if err != nil {
+			s.log.Error("failed to count users", sl.Err(err))
+			return nil, nil, err
+		}
+	}
+
+	switch {
+	case params.UseCursor:
+		query, err = keyset(query, params.Sort, params.Cursor, params.Limit, userSortColumns)
+		if err != nil {
+			return nil, nil, err
+		}
+	case len(params.Sort) > 0:
+		query = orderBy(query, params.Sort, userSortColumns)
+		query = query.Limit(params.Limit).Offset(params.Offset)
+	case rank != nil:
+		query = query.OrderByClause(rank).OrderBy(`"id"`)
+		query = query.Limit(params.Limit).Offset(params.Offset)
+	default:
+		query = query.OrderBy(`"id"`)
+		query = query.Limit(params.Limit).Offset(params.Offset)
+	}
+
+	stmt, args, err := query.ToSql()
+	if err != nil {
+		s.log.Error("failed to convert to sql", sl.Err(err))
+		return nil, nil, err
+	}
+
+	rows, err := db.Query(ctx, stmt, args...)
+	if err != nil {
+		s.log.Error("failed to query users", sl.Err(err))
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	users, err = pgx.CollectRows(rows, pgx.RowToStructByName[generated.User])
+	if err != nil {
+		s.log.Error("failed to scan users", sl.Err(err))
+		return nil, nil, err
+	}
+
+	return users, total, nil
+}
+
+// usersQuery selects users matching fields and filter of params, deleted users are selected
+// only when params include them.
+func usersQuery(params model.GetUsersParams) (sq.SelectBuilder, error) {
+	query := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).Select(
 		"id",
 		"username",
 		"pseudonym",
@@ -48,8 +211,23 @@ func (s *UserStore) GetUsers(ctx context.Context, params model.GetUsersParams) (
 		"is_deleted",
 		"created_at",
 		"updated_at",
+		"deleted_at",
+		"anonymized_at",
+		"pseudonym_skeleton",
+		"telegram_id",
+		"language_code",
+		"is_premium",
+		"photo_url",
+		"allows_write_to_pm",
+		"is_bot",
+		"first_name_source",
+		"last_name_source",
 	).From("users")
 
+	if !params.IncludeDeleted {
+		query = query.Where(sq.Eq{"is_deleted": false})
+	}
+
 	if params.UserID != nil {
 		query = query.Where(sq.Eq{"id": *params.UserID})
 	}
@@ -57,7 +235,14 @@ func (s *UserStore) GetUsers(ctx context.Context, params model.GetUsersParams) (
 		query = query.Where(sq.Eq{"username": *params.Username})
 	}
 	if params.Pseudonym != nil {
-		query = query.Where(sq.Eq{"pseudonym": *params.Pseudonym})
+		pseudonym := sq.Or{sq.Eq{"pseudonym": *params.Pseudonym}}
+		if former := params.FormerPseudonym; former != nil {
+			pseudonym = append(pseudonym, sq.Expr(
+				`id in (select user_id from "pseudonym_history" where old_pseudonym_skeleton = ? and changed_at > ?)`,
+				former.Skeleton, former.ChangedAfter,
+			))
+		}
+		query = query.Where(pseudonym)
 	}
 	if params.FirstName != nil {
 		query = query.Where(sq.Eq{"first_name": *params.FirstName})
@@ -65,55 +250,107 @@ func (s *UserStore) GetUsers(ctx context.Context, params model.GetUsersParams) (
 	if params.LastName != nil {
 		query = query.Where(sq.Eq{"last_name": *params.LastName})
 	}
+	if params.Filter != nil {
+		filter, err := userFilter(*params.Filter, params.IncludeDeleted)
+		if err != nil {
+			return query, err
+		}
+		if filter != nil {
+			query = query.Where(filter)
+		}
+	}
+
+	return query, nil
+}
+
+// exportBatchSize is number of rows fetched from export cursor at once.
+const exportBatchSize = 1000
+
+// ExportUsers calls fn for every user matching params, rows are fetched in batches from
+// server side cursor of one snapshot so export is consistent and memory use is bounded.
+func (s *UserStore) ExportUsers(ctx context.Context, params model.GetUsersParams, fn func(generated.User) error) error {
+	query, err := usersQuery(params)
+	if err != nil {
+		return err
+	}
 
-	count := builder.Select("count(*)").FromSelect(query, "u")
-	stmt, args, err := count.ToSql()
+	query = orderBy(query, params.Sort, userSortColumns)
+
+	stmt, args, err := query.ToSql()
 	if err != nil {
 		s.log.Error("failed to convert to sql", sl.Err(err))
-		return nil, nil, err
+		return err
 	}
 
-	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&total)
+	tx, err := s.DB.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
 	if err != nil {
-		s.log.Error("failed to count users", sl.Err(err))
-		return nil, nil, err
+		return err
 	}
+	defer tx.Rollback(ctx) // nolint
 
-	if params.OrderBy != nil {
-		query = query.OrderBy(fmt.Sprintf("%q %s", params.OrderBy.Field, params.OrderBy.Order))
+	if _, err := tx.Exec(ctx, "declare users_export no scroll cursor for "+stmt, args...); err != nil {
+		s.log.Error("failed to declare export cursor", sl.Err(err))
+		return err
 	}
 
-	query = query.Limit(params.Limit).Offset(params.Offset)
+	for {
+		rows, err := tx.Query(ctx, fmt.Sprintf("fetch %d from users_export", exportBatchSize))
+		if err != nil {
+			s.log.Error("failed to fetch users", sl.Err(err))
+			return err
+		}
 
-	stmt, args, err = query.ToSql()
-	if err != nil {
-		s.log.Error("failed to convert to sql", sl.Err(err))
-		return nil, nil, err
-	}
+		users, err := pgx.CollectRows(rows, pgx.RowToStructByName[generated.User])
+		if err != nil {
+			s.log.Error("failed to scan users", sl.Err(err))
+			return err
+		}
 
-	rows, err := s.DB.Query(ctx, stmt, args...)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			return nil, nil, model.ErrOrderByInvalidField
+		for _, user := range users {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+
+		if len(users) < exportBatchSize {
+			return nil
 		}
-		s.log.Error("failed to query users", sl.Err(err))
-		return nil, nil, err
 	}
-	defer rows.Close()
+}
 
-	users, err = pgx.CollectRows(rows, pgx.RowToStructByName[generated.User])
-	if err != nil {
-		s.log.Error("failed to scan users", sl.Err(err))
-		return nil, nil, err
+// releaseUsername renames active accounts other than id holding username. Telegram usernames are
+// optional and empty username is shared by users without one, so it is never released.
+func releaseUsername(ctx context.Context, qtx *generated.Queries, username string, id uuid.UUID) error {
+	if username == "" {
+		return nil
 	}
 
-	return users, total, nil
+	return qtx.ReleaseUsername(ctx, generated.ReleaseUsernameParams{Username: username, ID: id})
 }
 
+// SaveUser releases username held by stale account and saves user in one transaction.
 func (s *UserStore) SaveUser(ctx context.Context, user generated.SaveUserParams) (*uuid.UUID, error) {
-	id, err := s.Queries.SaveUser(ctx, user)
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	if err := releaseUsername(ctx, qtx, user.Username, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	id, err := qtx.SaveUser(ctx, user)
 	if err != nil {
+		if isUniqueViolation(err, pseudonymSkeletonKey) {
+			return nil, model.ErrPseudonymTaken
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 
@@ -144,6 +381,62 @@ func (s *UserStore) GetUserAdminByUsername(ctx context.Context, username string)
 	return &user, nil
 }
 
+func (s *UserStore) GetUserAdminByTelegramID(ctx context.Context, telegramID int64) (*generated.GetUserAdminByTelegramIDRow, error) {
+	user, err := s.Queries.GetUserAdminByTelegramID(ctx, &telegramID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// GetLegacyUserAdminByUsername finds user created before Telegram ids were stored, users with
+// Telegram id are never matched by username.
+func (s *UserStore) GetLegacyUserAdminByUsername(ctx context.Context, username string) (*generated.GetUserAdminByUsernameRow, error) {
+	user, err := s.Queries.GetLegacyUserAdminByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return (*generated.GetUserAdminByUsernameRow)(&user), nil
+}
+
+// SyncTelegramProfile overwrites the Telegram-sourced profile fields of the user and reports
+// whether anything actually changed. Names edited by the user are left untouched.
+//
+// Telegram usernames are unique at any moment, so the username belongs to the user logging in
+// and is released from the stale account still holding it in the same transaction.
+func (s *UserStore) SyncTelegramProfile(ctx context.Context, params generated.SyncTelegramProfileParams) (bool, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	if err := releaseUsername(ctx, qtx, params.Username, params.ID); err != nil {
+		return false, err
+	}
+
+	rows, err := qtx.SyncTelegramProfile(ctx, params)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
 func (s *UserStore) SaveAdmin(ctx context.Context, params generated.SaveAdminParams) error {
 	if err := s.Queries.SaveAdmin(ctx, params); err != nil {
 		return err
@@ -160,25 +453,263 @@ func (s *UserStore) DeleteAdmin(ctx context.Context, userID uuid.UUID) error {
 	return nil
 }
 
-func (s *UserStore) GetAdmins(ctx context.Context, params generated.GetAdminsParams) (admins []generated.GetAdminsRow, total *uint64, err error) {
-	cnt, err := s.Queries.CountAdmins(ctx, generated.CountAdminsParams{
-		UserID:     params.UserID,
-		Username:   params.Username,
-		AdminScale: params.AdminScale,
+// DeleteUser soft deletes user, records who deleted it and removes admin role in one transaction.
+func (s *UserStore) DeleteUser(ctx context.Context, id, deletedBy uuid.UUID) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	rows, err := qtx.DeleteUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return model.ErrUserNotFound
+	}
+
+	err = qtx.SaveUserDeletion(ctx, generated.SaveUserDeletionParams{
+		UserID:    id,
+		DeletedBy: deletedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := qtx.DeleteAdmin(ctx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetRestorableUser looks deleted user up by Telegram id and falls back to username for accounts
+// without Telegram id, the same way login finds active users. Empty username matches nobody.
+func (s *UserStore) GetRestorableUser(ctx context.Context, telegramID *int64, username string, deletedAfter time.Time) (*generated.GetRestorableUserRow, error) {
+	user, err := s.Queries.GetRestorableUser(ctx, generated.GetRestorableUserParams{
+		TelegramID:   telegramID,
+		Username:     username,
+		DeletedAfter: pgtype.Timestamp{Time: deletedAfter, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// RestoreUser restores user with current username from Telegram, username stored on deletion
+// may belong to another user by now and is released from that user.
+func (s *UserStore) RestoreUser(ctx context.Context, id uuid.UUID, username string) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	if err := releaseUsername(ctx, qtx, username, id); err != nil {
+		return err
+	}
+
+	err = qtx.RestoreUser(ctx, generated.RestoreUserParams{
+		Username: username,
+		ID:       id,
+	})
+	if err != nil {
+		if isUniqueViolation(err, pseudonymSkeletonKey) {
+			return model.ErrPseudonymTaken
+		} else if isUniqueViolation(err, usernameKey) {
+			return model.ErrUsernameTaken
+		}
+		return err
+	}
+
+	if err := qtx.DeleteUserDeletion(ctx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// IsPseudonymTaken checks pseudonyms of active users and pseudonyms released after releasedAfter
+// by other users, former pseudonyms still resolve to their previous owner.
+func (s *UserStore) IsPseudonymTaken(ctx context.Context, skeleton string, userID *uuid.UUID, releasedAfter time.Time) (bool, error) {
+	var id pgtype.UUID
+	if userID != nil {
+		id = pgtype.UUID{Bytes: *userID, Valid: true}
+	}
+
+	return s.Queries.IsPseudonymTaken(ctx, generated.IsPseudonymTakenParams{
+		PseudonymSkeleton: skeleton,
+		UserID:            id,
+		ReleasedAfter:     pgtype.Timestamp{Time: releasedAfter, Valid: true},
 	})
+}
+
+// AnonymizeDeletedUsers erases personal data, custom metadata and pseudonym history of users, strips
+// personal data from their audit events and saves events about it to outbox in one transaction.
+// Audit events themselves are kept until retention period of audit log is over.
+func (s *UserStore) AnonymizeDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	ids, err := qtx.AnonymizeDeletedUsers(ctx, pgtype.Timestamp{Time: deletedBefore, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) > 0 {
+		if err := qtx.DeleteUsersMetadata(ctx, ids); err != nil {
+			return nil, err
+		}
+
+		if err := qtx.DeleteUsersPseudonymHistory(ctx, ids); err != nil {
+			return nil, err
+		}
+
+		err = qtx.AnonymizeUsersAuditEvents(ctx, generated.AnonymizeUsersAuditEventsParams{
+			UserIds: ids,
+			Keys:    model.AuditPersonalMetadata,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = qtx.SaveOutboxEvents(ctx, generated.SaveOutboxEventsParams{
+			Type:    model.EventUserAnonymized,
+			UserIds: ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// PublishOutboxEvents publishes up to limit events of outbox in order they occurred and deletes
+// published ones. Events locked by another instance are skipped, events which failed to publish
+// stay in outbox until the next call.
+func (s *UserStore) PublishOutboxEvents(ctx context.Context, limit int32, publish func(model.Event) error) (int, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) // nolint
+
+	qtx := s.Queries.WithTx(tx)
+
+	events, err := qtx.GetOutboxEventsForUpdate(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var published int
+	var publishErr error
+	for _, event := range events {
+		publishErr = publish(model.Event{
+			ID:         event.ID,
+			Type:       event.Type,
+			UserID:     event.UserID,
+			OccurredAt: event.OccurredAt.Time,
+		})
+		if publishErr != nil {
+			break
+		}
+
+		if err := qtx.DeleteOutboxEvent(ctx, event.ID); err != nil {
+			return 0, err
+		}
+		published++
+	}
+
+	// Published events are deleted even if the rest failed, so they are not published twice
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return published, publishErr
+}
+
+// GetAdmins returns admins ordered by creation time, with cursor pagination one extra
+// admin is returned so caller can tell whether there are more admins.
+func (s *UserStore) GetAdmins(ctx context.Context, params model.GetAdminsParams) (admins []model.Admin, total *uint64, err error) {
+	if !params.SkipTotal {
+		cnt, err := s.Queries.CountAdmins(ctx, generated.CountAdminsParams{
+			UserID:     params.UserID,
+			Username:   params.Username,
+			AdminScale: params.AdminScale,
+		})
+		if err != nil {
+			s.log.Error("failed to count admins", sl.Err(err))
+			return nil, nil, err
+		}
+
+		tmp := uint64(cnt)
+		total = &tmp
+	}
+
+	query := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(`u."id"`, `u."username"`, `ua."scale"`, `ua."created_at"`).
+		From(`"users_admins" ua`).
+		Join(`"users" u on u."id" = ua."user_id"`).
+		Where(`u."is_deleted" = false`)
+
+	if params.UserID.Valid {
+		query = query.Where(sq.Eq{`u."id"`: params.UserID})
+	}
+	if params.Username != nil {
+		query = query.Where(sq.Eq{`u."username"`: *params.Username})
+	}
+	if params.AdminScale.Valid {
+		query = query.Where(sq.Eq{`ua."scale"`: params.AdminScale.AdminScale})
+	}
+
+	if params.UseCursor {
+		query, err = keyset(query, params.Sort, params.Cursor, params.Limit, adminSortColumns)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		query = orderBy(query, params.Sort, adminSortColumns).Limit(params.Limit).Offset(params.Offset)
+	}
+
+	stmt, args, err := query.ToSql()
 	if err != nil {
-		s.log.Error("failed to count admins", sl.Err(err))
+		s.log.Error("failed to convert to sql", sl.Err(err))
 		return nil, nil, err
 	}
 
-	admins, err = s.Queries.GetAdmins(ctx, params)
+	rows, err := s.DB.Query(ctx, stmt, args...)
 	if err != nil {
 		s.log.Error("failed to query admins", sl.Err(err))
 		return nil, nil, err
 	}
+	defer rows.Close()
 
-	tmp := uint64(cnt)
-	total = &tmp
+	admins, err = pgx.CollectRows(rows, pgx.RowToStructByName[model.Admin])
+	if err != nil {
+		s.log.Error("failed to scan admins", sl.Err(err))
+		return nil, nil, err
+	}
 
 	return admins, total, nil
 }
@@ -0,0 +1,356 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	sl "github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/metrics"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/redis"
+	"github.com/google/uuid"
+	rdb "github.com/redis/go-redis/v9"
+)
+
+// notFoundValue is cached for users which do not exist, so repeated lookups of them skip Postgres.
+const notFoundValue = "-"
+
+// Cache lookups, they label hit and miss counters of cache.
+const (
+	lookupUser            = "user"
+	lookupAdminByID       = "admin_by_id"
+	lookupAdminByUsername = "admin_by_username"
+)
+
+// cachedUsers is part of UserStore which is read through cache or invalidates it.
+type cachedUsers interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*generated.User, error)
+	GetUserAdminByID(ctx context.Context, id uuid.UUID) (*generated.GetUserAdminByIDRow, error)
+	GetUserAdminByUsername(ctx context.Context, username string) (*generated.GetUserAdminByUsernameRow, error)
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]generated.User, error)
+	SaveUser(ctx context.Context, user generated.SaveUserParams) (*uuid.UUID, error)
+	UpdateUser(ctx context.Context, updateUser generated.UpdateUserParams, changedBy uuid.UUID, cooldown time.Duration) (*generated.User, error)
+	SyncTelegramProfile(ctx context.Context, params generated.SyncTelegramProfileParams) (bool, error)
+	SaveAdmin(ctx context.Context, params generated.SaveAdminParams) error
+	DeleteAdmin(ctx context.Context, userID uuid.UUID) error
+	ApproveAdminRequest(ctx context.Context, id, approverID uuid.UUID) (*generated.AdminRequest, error)
+	DeleteUser(ctx context.Context, id, deletedBy uuid.UUID) error
+	RestoreUser(ctx context.Context, id uuid.UUID, username string) error
+	AnonymizeDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error)
+}
+
+// CachedUserStore reads users and their admin roles through redis cache, missing users are cached
+// for negativeTTL. Cached entries are dropped when users or admins are modified through the store.
+// Cache failures fall back to Postgres.
+//
+// Every cache key has generation which is bumped on invalidation. Readers remember generation before
+// loading from Postgres and cache loaded value only if it has not changed, so value read before
+// concurrent commit is not cached after that commit invalidated the key.
+type CachedUserStore struct {
+	*UserStore
+	users       cachedUsers
+	rdb         *redis.Redis
+	ttl         time.Duration
+	negativeTTL time.Duration
+	log         *slog.Logger
+}
+
+func NewCachedUserStore(users *UserStore, r *redis.Redis, ttl, negativeTTL time.Duration, log *slog.Logger) *CachedUserStore {
+	return &CachedUserStore{UserStore: users, users: users, rdb: r, ttl: ttl, negativeTTL: negativeTTL, log: log}
+}
+
+func userCacheKey(id uuid.UUID) string {
+	return "users:" + id.String()
+}
+
+func adminByIDCacheKey(id uuid.UUID) string {
+	return "users:admin:id:" + id.String()
+}
+
+func adminByUsernameCacheKey(username string) string {
+	return "users:admin:username:" + username
+}
+
+func generationKey(key string) string {
+	return key + ":gen"
+}
+
+// setIfGeneration caches value only if generation of key is still the one seen before loading it.
+var setIfGeneration = rdb.NewScript(`
+if (redis.call("GET", KEYS[2]) or "") ~= ARGV[1] then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+return 1
+`)
+
+// readThrough returns cached value of key or loads and caches it, ErrUserNotFound is cached too.
+func readThrough[T any](ctx context.Context, s *CachedUserStore, lookup, key string, load func() (*T, error)) (*T, error) {
+	data, err := s.rdb.Get(ctx, key).Bytes()
+	switch {
+	case err == nil:
+		if string(data) == notFoundValue {
+			metrics.UserCacheRequests.WithLabelValues(lookup, "hit").Inc()
+			return nil, model.ErrUserNotFound
+		}
+
+		var value T
+		if json.Unmarshal(data, &value) == nil {
+			metrics.UserCacheRequests.WithLabelValues(lookup, "hit").Inc()
+			return &value, nil
+		}
+	case !errors.Is(err, rdb.Nil):
+		s.log.Warn("failed to read cached user", sl.Err(err))
+	}
+	metrics.UserCacheRequests.WithLabelValues(lookup, "miss").Inc()
+
+	generation, ok := s.generation(ctx, key)
+
+	value, err := load()
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) && s.negativeTTL > 0 && ok {
+			s.cache(ctx, key, generation, notFoundValue, s.negativeTTL)
+		}
+		return nil, err
+	}
+
+	data, err = json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		s.cache(ctx, key, generation, data, s.ttl)
+	}
+
+	return value, nil
+}
+
+// generation reads generation of key, value is not cached if it can not be read.
+func (s *CachedUserStore) generation(ctx context.Context, key string) (string, bool) {
+	generation, err := s.rdb.Get(ctx, generationKey(key)).Result()
+	if err != nil && !errors.Is(err, rdb.Nil) {
+		s.log.Warn("failed to read cached user generation", sl.Err(err))
+		return "", false
+	}
+
+	return generation, true
+}
+
+func (s *CachedUserStore) cache(ctx context.Context, key, generation string, value any, ttl time.Duration) {
+	err := setIfGeneration.Run(ctx, s.rdb, []string{key, generationKey(key)}, generation, value, ttl.Milliseconds()).Err()
+	if err != nil {
+		s.log.Warn("failed to cache user", sl.Err(err))
+	}
+}
+
+func (s *CachedUserStore) GetUserByID(ctx context.Context, id uuid.UUID) (*generated.User, error) {
+	return readThrough(ctx, s, lookupUser, userCacheKey(id), func() (*generated.User, error) {
+		return s.users.GetUserByID(ctx, id)
+	})
+}
+
+func (s *CachedUserStore) GetUserAdminByID(ctx context.Context, id uuid.UUID) (*generated.GetUserAdminByIDRow, error) {
+	return readThrough(ctx, s, lookupAdminByID, adminByIDCacheKey(id), func() (*generated.GetUserAdminByIDRow, error) {
+		return s.users.GetUserAdminByID(ctx, id)
+	})
+}
+
+func (s *CachedUserStore) GetUserAdminByUsername(ctx context.Context, username string) (*generated.GetUserAdminByUsernameRow, error) {
+	return readThrough(ctx, s, lookupAdminByUsername, adminByUsernameCacheKey(username), func() (*generated.GetUserAdminByUsernameRow, error) {
+		return s.users.GetUserAdminByUsername(ctx, username)
+	})
+}
+
+func (s *CachedUserStore) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]generated.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, 2*len(ids))
+	for _, id := range ids {
+		keys = append(keys, userCacheKey(id))
+	}
+	for _, id := range ids {
+		keys = append(keys, generationKey(userCacheKey(id)))
+	}
+
+	values, err := s.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		s.log.Warn("failed to read cached users", sl.Err(err))
+		return s.users.GetUsersByIDs(ctx, ids)
+	}
+
+	users := make([]generated.User, 0, len(ids))
+	var missed []uuid.UUID
+	generations := make(map[uuid.UUID]string)
+	for i, value := range values[:len(ids)] {
+		var user generated.User
+		data, ok := value.(string)
+		if ok && data == notFoundValue {
+			continue
+		}
+		if !ok || json.Unmarshal([]byte(data), &user) != nil {
+			missed = append(missed, ids[i])
+			generations[ids[i]], _ = values[len(ids)+i].(string)
+			continue
+		}
+		users = append(users, user)
+	}
+	metrics.UserCacheRequests.WithLabelValues(lookupUser, "hit").Add(float64(len(ids) - len(missed)))
+	metrics.UserCacheRequests.WithLabelValues(lookupUser, "miss").Add(float64(len(missed)))
+
+	if len(missed) == 0 {
+		return users, nil
+	}
+
+	found, err := s.users.GetUsersByIDs(ctx, missed)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.rdb.Pipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for _, user := range found {
+			data, err := json.Marshal(user)
+			if err != nil {
+				return err
+			}
+			key := userCacheKey(user.ID)
+			setIfGeneration.Eval(ctx, pipe, []string{key, generationKey(key)}, generations[user.ID], data, s.ttl.Milliseconds())
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.log.Warn("failed to cache users", sl.Err(err))
+	}
+
+	return append(users, found...), nil
+}
+
+// SaveUser may release username from stale account, so that account is dropped too.
+func (s *CachedUserStore) SaveUser(ctx context.Context, user generated.SaveUserParams) (*uuid.UUID, error) {
+	ids := s.holders(ctx, user.Username)
+	id, err := s.users.SaveUser(ctx, user)
+	if id != nil {
+		ids = append(ids, *id)
+	}
+	s.invalidate(ctx, ids, user.Username)
+	return id, err
+}
+
+func (s *CachedUserStore) UpdateUser(ctx context.Context, updateUser generated.UpdateUserParams, changedBy uuid.UUID, cooldown time.Duration) (*generated.User, error) {
+	defer s.invalidate(ctx, []uuid.UUID{updateUser.ID}, s.usernames(ctx, updateUser.ID)...)
+	return s.users.UpdateUser(ctx, updateUser, changedBy, cooldown)
+}
+
+// SyncTelegramProfile may change username, so both former and new usernames are dropped together
+// with stale account the new username is released from.
+func (s *CachedUserStore) SyncTelegramProfile(ctx context.Context, params generated.SyncTelegramProfileParams) (bool, error) {
+	usernames := append(s.usernames(ctx, params.ID), params.Username)
+	defer s.invalidate(ctx, append(s.holders(ctx, params.Username), params.ID), usernames...)
+	return s.users.SyncTelegramProfile(ctx, params)
+}
+
+func (s *CachedUserStore) SaveAdmin(ctx context.Context, params generated.SaveAdminParams) error {
+	defer s.invalidate(ctx, []uuid.UUID{params.UserID}, s.usernames(ctx, params.UserID)...)
+	return s.users.SaveAdmin(ctx, params)
+}
+
+func (s *CachedUserStore) DeleteAdmin(ctx context.Context, userID uuid.UUID) error {
+	defer s.invalidate(ctx, []uuid.UUID{userID}, s.usernames(ctx, userID)...)
+	return s.users.DeleteAdmin(ctx, userID)
+}
+
+func (s *CachedUserStore) ApproveAdminRequest(ctx context.Context, id, approverID uuid.UUID) (*generated.AdminRequest, error) {
+	request, err := s.users.ApproveAdminRequest(ctx, id, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate(ctx, []uuid.UUID{request.TargetUserID}, s.usernames(ctx, request.TargetUserID)...)
+	return request, nil
+}
+
+// DeleteUser drops username before deletion, deleted user can not be read afterwards.
+func (s *CachedUserStore) DeleteUser(ctx context.Context, id, deletedBy uuid.UUID) error {
+	defer s.invalidate(ctx, []uuid.UUID{id}, s.usernames(ctx, id)...)
+	return s.users.DeleteUser(ctx, id, deletedBy)
+}
+
+// RestoreUser drops username after restoring, restored user may be cached as not found by username.
+func (s *CachedUserStore) RestoreUser(ctx context.Context, id uuid.UUID, username string) error {
+	ids := append(s.holders(ctx, username), id)
+	err := s.users.RestoreUser(ctx, id, username)
+	s.invalidate(ctx, ids, username)
+	return err
+}
+
+func (s *CachedUserStore) AnonymizeDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	ids, err := s.users.AnonymizeDeletedUsers(ctx, deletedBefore)
+	s.invalidate(ctx, ids)
+	return ids, err
+}
+
+// usernames reads current username of user from Postgres, it is empty for missing users.
+func (s *CachedUserStore) usernames(ctx context.Context, id uuid.UUID) []string {
+	user, err := s.users.GetUserByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+
+	return []string{user.Username}
+}
+
+// holders reads active user holding username from Postgres, it is empty if username is free.
+// Empty username is never released, so nobody holds it.
+func (s *CachedUserStore) holders(ctx context.Context, username string) []uuid.UUID {
+	if username == "" {
+		return nil
+	}
+
+	user, err := s.users.GetUserAdminByUsername(ctx, username)
+	if err != nil {
+		return nil
+	}
+
+	return []uuid.UUID{user.ID}
+}
+
+// invalidate drops cached users, their admin roles and lookups by usernames after modification and
+// bumps their generations, so readers which loaded them before modification do not cache them again.
+// It runs even if modification failed because transaction may be committed before error is returned.
+func (s *CachedUserStore) invalidate(ctx context.Context, ids []uuid.UUID, usernames ...string) {
+	keys := make([]string, 0, 2*len(ids)+len(usernames))
+	for _, id := range ids {
+		keys = append(keys, userCacheKey(id), adminByIDCacheKey(id))
+	}
+	for _, username := range usernames {
+		keys = append(keys, adminByUsernameCacheKey(username))
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	_, err := s.rdb.TxPipelined(ctx, func(pipe rdb.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Incr(ctx, generationKey(key))
+			pipe.PExpire(ctx, generationKey(key), s.generationTTL())
+		}
+		pipe.Del(ctx, keys...)
+
+		return nil
+	})
+	if err != nil {
+		s.log.Warn("failed to invalidate cached users", sl.Err(err))
+	}
+}
+
+// generationTTL keeps generation longer than any value guarded by it is cached.
+func (s *CachedUserStore) generationTTL() time.Duration {
+	return 2 * max(s.ttl, s.negativeTTL)
+}
@@ -0,0 +1,326 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/db/generated"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/domain/model"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/logger/slogdiscard"
+	"github.com/MAXXXIMUS-tropical-milkshake/beatflow-auth/internal/lib/redis"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	rdb "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTTL         = time.Minute
+	testNegativeTTL = 10 * time.Second
+)
+
+// fakeUsers keeps users and their admin scales in memory, it counts lookups which reach it.
+type fakeUsers struct {
+	cachedUsers
+	users   map[uuid.UUID]generated.User
+	admins  map[uuid.UUID]generated.AdminScale
+	lookups int
+	// onLookup runs after user is read and before it is returned.
+	onLookup func()
+}
+
+func (f *fakeUsers) GetUserByID(_ context.Context, id uuid.UUID) (*generated.User, error) {
+	f.lookups++
+	user, ok := f.users[id]
+	if f.onLookup != nil {
+		f.onLookup()
+	}
+	if !ok {
+		return nil, model.ErrUserNotFound
+	}
+
+	return &user, nil
+}
+
+func (f *fakeUsers) GetUserAdminByID(_ context.Context, id uuid.UUID) (*generated.GetUserAdminByIDRow, error) {
+	f.lookups++
+	if _, ok := f.users[id]; !ok {
+		return nil, model.ErrUserNotFound
+	}
+
+	scale, ok := f.admins[id]
+	return &generated.GetUserAdminByIDRow{ID: id, Scale: generated.NullAdminScale{AdminScale: scale, Valid: ok}}, nil
+}
+
+func (f *fakeUsers) GetUserAdminByUsername(_ context.Context, username string) (*generated.GetUserAdminByUsernameRow, error) {
+	f.lookups++
+	for id, user := range f.users {
+		if user.Username == username {
+			scale, ok := f.admins[id]
+			return &generated.GetUserAdminByUsernameRow{ID: id, Scale: generated.NullAdminScale{AdminScale: scale, Valid: ok}}, nil
+		}
+	}
+
+	return nil, model.ErrUserNotFound
+}
+
+func (f *fakeUsers) GetUsersByIDs(_ context.Context, ids []uuid.UUID) ([]generated.User, error) {
+	f.lookups++
+	var users []generated.User
+	for _, id := range ids {
+		if user, ok := f.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	if f.onLookup != nil {
+		f.onLookup()
+	}
+
+	return users, nil
+}
+
+func (f *fakeUsers) SaveUser(_ context.Context, params generated.SaveUserParams) (*uuid.UUID, error) {
+	id := uuid.New()
+	f.users[id] = generated.User{ID: id, Username: params.Username}
+	return &id, nil
+}
+
+func (f *fakeUsers) UpdateUser(_ context.Context, params generated.UpdateUserParams, _ uuid.UUID, _ time.Duration) (*generated.User, error) {
+	user := f.users[params.ID]
+	user.FirstName = *params.FirstName
+	f.users[params.ID] = user
+	return &user, nil
+}
+
+func (f *fakeUsers) SaveAdmin(_ context.Context, params generated.SaveAdminParams) error {
+	f.admins[params.UserID] = params.Scale
+	return nil
+}
+
+func (f *fakeUsers) DeleteAdmin(_ context.Context, userID uuid.UUID) error {
+	delete(f.admins, userID)
+	return nil
+}
+
+func newTestCachedUserStore(t *testing.T, users *fakeUsers) (*CachedUserStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := rdb.NewClient(&rdb.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return &CachedUserStore{
+		users:       users,
+		rdb:         &redis.Redis{Client: client},
+		ttl:         testTTL,
+		negativeTTL: testNegativeTTL,
+		log:         slogdiscard.NewDiscardLogger(),
+	}, mr
+}
+
+func newFakeUsers(users ...generated.User) *fakeUsers {
+	f := &fakeUsers{users: make(map[uuid.UUID]generated.User), admins: make(map[uuid.UUID]generated.AdminScale)}
+	for _, user := range users {
+		f.users[user.ID] = user
+	}
+
+	return f
+}
+
+func TestCachedUserStore_CachesUser(t *testing.T) {
+	t.Parallel()
+
+	user := generated.User{ID: uuid.New(), Username: "bob"}
+	users := newFakeUsers(user)
+	s, mr := newTestCachedUserStore(t, users)
+	ctx := context.Background()
+
+	for range 2 {
+		got, err := s.GetUserByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.ID)
+	}
+	assert.Equal(t, 1, users.lookups)
+	assert.Equal(t, testTTL, mr.TTL(userCacheKey(user.ID)))
+}
+
+func TestCachedUserStore_CachesMissingUser(t *testing.T) {
+	t.Parallel()
+
+	users := newFakeUsers()
+	s, mr := newTestCachedUserStore(t, users)
+	ctx := context.Background()
+	id := uuid.New()
+
+	for range 2 {
+		_, err := s.GetUserByID(ctx, id)
+		require.ErrorIs(t, err, model.ErrUserNotFound)
+	}
+	assert.Equal(t, 1, users.lookups)
+
+	value, err := mr.Get(userCacheKey(id))
+	require.NoError(t, err)
+	assert.Equal(t, notFoundValue, value)
+	assert.Equal(t, testNegativeTTL, mr.TTL(userCacheKey(id)))
+
+	mr.FastForward(testNegativeTTL)
+	_, err = s.GetUserByID(ctx, id)
+	require.ErrorIs(t, err, model.ErrUserNotFound)
+	assert.Equal(t, 2, users.lookups)
+}
+
+func TestCachedUserStore_NegativeCacheDisabled(t *testing.T) {
+	t.Parallel()
+
+	users := newFakeUsers()
+	s, mr := newTestCachedUserStore(t, users)
+	s.negativeTTL = 0
+	ctx := context.Background()
+	id := uuid.New()
+
+	for range 2 {
+		_, err := s.GetUserByID(ctx, id)
+		require.ErrorIs(t, err, model.ErrUserNotFound)
+	}
+	assert.Equal(t, 2, users.lookups)
+	assert.False(t, mr.Exists(userCacheKey(id)))
+}
+
+func TestCachedUserStore_Invalidates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(ctx context.Context, s *CachedUserStore, id uuid.UUID) error
+	}{
+		{
+			name: "save admin",
+			modify: func(ctx context.Context, s *CachedUserStore, id uuid.UUID) error {
+				return s.SaveAdmin(ctx, generated.SaveAdminParams{UserID: id, Scale: generated.AdminScaleMinor})
+			},
+		},
+		{
+			name: "delete admin",
+			modify: func(ctx context.Context, s *CachedUserStore, id uuid.UUID) error {
+				return s.DeleteAdmin(ctx, id)
+			},
+		},
+		{
+			name: "update user",
+			modify: func(ctx context.Context, s *CachedUserStore, id uuid.UUID) error {
+				firstName := "Robert"
+				_, err := s.UpdateUser(ctx, generated.UpdateUserParams{ID: id, FirstName: &firstName}, id, 0)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			user := generated.User{ID: uuid.New(), Username: "bob"}
+			users := newFakeUsers(user)
+			users.admins[user.ID] = generated.AdminScaleMajor
+			s, mr := newTestCachedUserStore(t, users)
+			ctx := context.Background()
+
+			_, err := s.GetUserByID(ctx, user.ID)
+			require.NoError(t, err)
+			_, err = s.GetUserAdminByID(ctx, user.ID)
+			require.NoError(t, err)
+			_, err = s.GetUserAdminByUsername(ctx, user.Username)
+			require.NoError(t, err)
+
+			keys := []string{userCacheKey(user.ID), adminByIDCacheKey(user.ID), adminByUsernameCacheKey(user.Username)}
+			for _, key := range keys {
+				require.True(t, mr.Exists(key), key)
+			}
+
+			require.NoError(t, tt.modify(ctx, s, user.ID))
+
+			for _, key := range keys {
+				assert.False(t, mr.Exists(key), key)
+			}
+
+			want := users.users[user.ID]
+			got, err := s.GetUserByID(ctx, user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, want.FirstName, got.FirstName)
+
+			admin, err := s.GetUserAdminByUsername(ctx, user.Username)
+			require.NoError(t, err)
+			scale, ok := users.admins[user.ID]
+			assert.Equal(t, generated.NullAdminScale{AdminScale: scale, Valid: ok}, admin.Scale)
+		})
+	}
+}
+
+func TestCachedUserStore_StaleReadNotCachedAfterInvalidation(t *testing.T) {
+	t.Parallel()
+
+	user := generated.User{ID: uuid.New(), Username: "bob"}
+	users := newFakeUsers(user)
+	s, mr := newTestCachedUserStore(t, users)
+	ctx := context.Background()
+
+	// Modification commits and invalidates cache after user was read by lookup, but before lookup cached it.
+	users.onLookup = func() {
+		users.onLookup = nil
+		firstName := "Robert"
+		_, err := s.UpdateUser(ctx, generated.UpdateUserParams{ID: user.ID, FirstName: &firstName}, user.ID, 0)
+		require.NoError(t, err)
+	}
+
+	got, err := s.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.FirstName)
+	assert.False(t, mr.Exists(userCacheKey(user.ID)))
+
+	got, err = s.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Robert", got.FirstName)
+}
+
+func TestCachedUserStore_StaleBatchReadNotCachedAfterInvalidation(t *testing.T) {
+	t.Parallel()
+
+	bob := generated.User{ID: uuid.New(), Username: "bob"}
+	alice := generated.User{ID: uuid.New(), Username: "alice"}
+	users := newFakeUsers(bob, alice)
+	s, mr := newTestCachedUserStore(t, users)
+	ctx := context.Background()
+
+	users.onLookup = func() {
+		users.onLookup = nil
+		firstName := "Robert"
+		_, err := s.UpdateUser(ctx, generated.UpdateUserParams{ID: bob.ID, FirstName: &firstName}, bob.ID, 0)
+		require.NoError(t, err)
+	}
+
+	got, err := s.GetUsersByIDs(ctx, []uuid.UUID{bob.ID, alice.ID})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.False(t, mr.Exists(userCacheKey(bob.ID)))
+	assert.True(t, mr.Exists(userCacheKey(alice.ID)))
+}
+
+func TestCachedUserStore_SaveUserWithoutUsername(t *testing.T) {
+	t.Parallel()
+
+	other := generated.User{ID: uuid.New()}
+	users := newFakeUsers(other)
+	s, mr := newTestCachedUserStore(t, users)
+	ctx := context.Background()
+
+	_, err := s.GetUserByID(ctx, other.ID)
+	require.NoError(t, err)
+
+	// empty username is shared by users without one, it is not released from other users
+	_, err = s.SaveUser(ctx, generated.SaveUserParams{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, users.lookups)
+	assert.True(t, mr.Exists(userCacheKey(other.ID)))
+}
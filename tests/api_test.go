@@ -190,6 +190,69 @@ func (suite *ApiTestSuite) TestLogin_SuccessTwice() {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func (suite *ApiTestSuite) TestLogin_SuccessUsernameMovedToAnotherAccount() {
+	t := suite.T()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	resp, err := suite.backendContainer.PostRequest("/v1/auth/login", `{"pseudonym": "former"}`, testhelpers.WithTmaToken(map[string]string{
+		"id":       "1001",
+		"username": "moved",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var formerID string
+	err = suite.pgContainer.DB.QueryRow(suite.ctx, `select id from users where telegram_id = 1001`).Scan(&formerID)
+	require.NoError(t, err)
+
+	resp, err = suite.backendContainer.PostRequest("/v1/auth/login", `{"pseudonym": "current"}`, testhelpers.WithTmaToken(map[string]string{
+		"id":       "1002",
+		"username": "moved",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var telegramID int64
+	err = suite.pgContainer.DB.QueryRow(suite.ctx, `select telegram_id from users where username = 'moved' and is_deleted = false`).Scan(&telegramID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1002), telegramID)
+
+	var formerUsername string
+	err = suite.pgContainer.DB.QueryRow(suite.ctx, `select username from users where id = $1`, formerID).Scan(&formerUsername)
+	require.NoError(t, err)
+	assert.Equal(t, "released_"+formerID, formerUsername)
+}
+
+func (suite *ApiTestSuite) TestLogin_SuccessUsersWithoutUsername() {
+	t := suite.T()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	for _, telegramID := range []string{"1003", "1004", "1003"} {
+		resp, err := suite.backendContainer.PostRequest("/v1/auth/login", `{"pseudonym": "nameless`+telegramID+`"}`, testhelpers.WithTmaToken(map[string]string{
+			"id": telegramID,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	var usernames []string
+	rows, err := suite.pgContainer.DB.Query(suite.ctx, `select username from users where telegram_id in (1003, 1004) order by telegram_id`)
+	require.NoError(t, err)
+	for rows.Next() {
+		var username string
+		require.NoError(t, rows.Scan(&username))
+		usernames = append(usernames, username)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"", ""}, usernames)
+}
+
 func (suite *ApiTestSuite) TestRefreshToken_Success() {
 	t := suite.T()
 
@@ -382,6 +445,62 @@ func (suite *ApiTestSuite) TestGetUsers_Success() {
 	})
 }
 
+func (suite *ApiTestSuite) TestGetUsers_SuccessSearchQueryHeader() {
+	t := suite.T()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	vals := url.Values{}
+	vals.Add("limit", "5")
+	resp, err := suite.backendContainer.GetRequest("/v1/users", vals, testhelpers.WithHeader("x-search-query", url.QueryEscape("Sandor Vanozzi")))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var users struct {
+		Users []struct {
+			Username string `json:"username"`
+		} `json:"users"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&users)
+	require.NoError(t, err)
+	require.NotEmpty(t, users.Users)
+	assert.Equal(t, "svannozzii2", users.Users[0].Username)
+}
+
+func (suite *ApiTestSuite) TestGetUsers_DeletedUsersRequireOptIn() {
+	t := suite.T()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	vals := url.Values{}
+	vals.Add("limit", "5")
+
+	token, err := suite.getToken("minor")
+	require.NoError(t, err)
+
+	resp, err := suite.backendContainer.GetRequest("/v1/users", vals,
+		testhelpers.WithBearerToken(token), testhelpers.WithHeader("x-filter", "is_deleted = true"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	userToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.MapClaims{"id": uuid.NewString()}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	resp, err = suite.backendContainer.GetRequest("/v1/users", vals,
+		testhelpers.WithBearerToken(userToken), testhelpers.WithHeader("x-include-deleted", "true"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, err = suite.backendContainer.GetRequest("/v1/users", vals,
+		testhelpers.WithBearerToken(token), testhelpers.WithHeader("x-include-deleted", "true"), testhelpers.WithHeader("x-filter", "is_deleted = true"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 func (suite *ApiTestSuite) TestGetUsers_FailValidation() {
 	t := suite.T()
 
@@ -747,6 +866,166 @@ func (suite *ApiTestSuite) TestDeleteAdmin_FailCannotDeleteMajorAdmin() {
 	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
 }
 
+func (suite *ApiTestSuite) TestDeleteUser_SuccessRestoredOnLogin() {
+	t := suite.T()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	params := map[string]string{
+		"username":   "aleks123",
+		"first_name": "Alexander",
+		"last_name":  "Ilin",
+	}
+
+	type tokens struct {
+		AccessToken string `json:"accessToken"`
+	}
+
+	resp, err := suite.backendContainer.PostRequest("/v1/auth/login", `{"pseudonym": "qwerty"}`, testhelpers.WithTmaToken(params))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	accessToken := &tokens{}
+	err = json.NewDecoder(resp.Body).Decode(&accessToken)
+	require.NoError(t, err)
+
+	var id string
+	err = suite.pgContainer.DB.QueryRow(suite.ctx, `select id from users where username = 'aleks123'`).Scan(&id)
+	require.NoError(t, err)
+
+	resp, err = suite.backendContainer.DeleteRequest("/v1/users/"+id, nil, testhelpers.WithBearerToken(accessToken.AccessToken))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	var isDeleted bool
+	err = suite.pgContainer.DB.QueryRow(suite.ctx, `select is_deleted from users where id = $1`, id).Scan(&isDeleted)
+	require.NoError(t, err)
+	assert.True(t, isDeleted)
+
+	resp, err = suite.backendContainer.PostRequest("/v1/auth/login", `{"pseudonym": "qwerty"}`, testhelpers.WithTmaToken(params))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	err = suite.pgContainer.DB.QueryRow(suite.ctx, `select is_deleted from users where id = $1`, id).Scan(&isDeleted)
+	require.NoError(t, err)
+	assert.False(t, isDeleted)
+}
+
+func (suite *ApiTestSuite) TestDeleteUser_FailNotAdmin() {
+	t := suite.T()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	var id string
+	row := suite.pgContainer.DB.QueryRow(suite.ctx, `select id from users order by random() limit 1`)
+	err := row.Scan(&id)
+	require.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.MapClaims{"id": uuid.NewString()}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	resp, err := suite.backendContainer.DeleteRequest("/v1/users/"+id, nil, testhelpers.WithBearerToken(token))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func (suite *ApiTestSuite) TestSetUserPseudonym_Success() {
+	t := suite.T()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	var id string
+	row := suite.pgContainer.DB.QueryRow(suite.ctx, `select id from users order by random() limit 1`)
+	err := row.Scan(&id)
+	require.NoError(t, err)
+
+	token, err := suite.getToken("minor")
+	require.NoError(t, err)
+
+	resp, err := suite.backendContainer.PutRequest("/v1/users/"+id+"/pseudonym", `{"pseudonym": " Metro  Boomin "}`, testhelpers.WithBearerToken(token))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var user struct {
+		Pseudonym string `json:"pseudonym"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&user)
+	require.NoError(t, err)
+	assert.Equal(t, "Metro Boomin", user.Pseudonym)
+}
+
+func (suite *ApiTestSuite) TestSetUserPseudonym_FailNotAdmin() {
+	t := suite.T()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.MapClaims{"id": uuid.NewString()}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	resp, err := suite.backendContainer.PutRequest("/v1/users/"+uuid.NewString()+"/pseudonym", `{"pseudonym": "Metro"}`, testhelpers.WithBearerToken(token))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func (suite *ApiTestSuite) TestGetTelegramProfile_RoundTrip() {
+	t := suite.T()
+
+	if testing.Short() {
+		t.Skip()
+	}
+
+	type tokens struct {
+		AccessToken string `json:"accessToken"`
+	}
+
+	resp, err := suite.backendContainer.PostRequest("/v1/auth/login", `{"pseudonym": "tgprofile"}`, testhelpers.WithTmaToken(map[string]string{
+		"id":            "777000",
+		"username":      "tgprofile",
+		"first_name":    "Alexander",
+		"language_code": "ru",
+		"is_premium":    "true",
+		"photo_url":     "https://t.me/i/userpic/320/tgprofile.jpg",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	accessToken := &tokens{}
+	err = json.NewDecoder(resp.Body).Decode(&accessToken)
+	require.NoError(t, err)
+
+	var id string
+	err = suite.pgContainer.DB.QueryRow(suite.ctx, `select id from users where username = 'tgprofile'`).Scan(&id)
+	require.NoError(t, err)
+
+	resp, err = suite.backendContainer.GetRequest("/v1/users/"+id+"/telegram", nil, testhelpers.WithBearerToken(accessToken.AccessToken))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var profile struct {
+		TelegramID   *int64  `json:"telegram_id"`
+		LanguageCode *string `json:"language_code"`
+		IsPremium    bool    `json:"is_premium"`
+		PhotoURL     *string `json:"photo_url"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&profile)
+	require.NoError(t, err)
+	require.NotNil(t, profile.TelegramID)
+	assert.Equal(t, int64(777000), *profile.TelegramID)
+	require.NotNil(t, profile.LanguageCode)
+	assert.Equal(t, "ru", *profile.LanguageCode)
+	assert.True(t, profile.IsPremium)
+	require.NotNil(t, profile.PhotoURL)
+	assert.Equal(t, "https://t.me/i/userpic/320/tgprofile.jpg", *profile.PhotoURL)
+}
+
 func TestApiTestSuite(t *testing.T) {
 	suite.Run(t, new(ApiTestSuite))
 }
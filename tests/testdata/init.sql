@@ -1,100 +1,100 @@
-insert into users (first_name, last_name, username, pseudonym) values ('Celesta', 'Shitliffe', 'cshitliffe0', 'cshitliffe0');
-insert into users (first_name, last_name, username, pseudonym) values ('Elaina', 'Lintot', 'elintot1', 'elintot1');
-insert into users (first_name, last_name, username, pseudonym) values ('Sandor', 'Vannozzii', 'svannozzii2', 'svannozzii2');
-insert into users (first_name, last_name, username, pseudonym) values ('Evin', 'Gillespie', 'egillespie3', 'egillespie3');
-insert into users (first_name, last_name, username, pseudonym) values ('Tabina', 'L'' Anglois', 'tlanglois4', 'tlanglois4');
-insert into users (first_name, last_name, username, pseudonym) values ('Lindie', 'Minto', 'lminto5', 'lminto5');
-insert into users (first_name, last_name, username, pseudonym) values ('Port', 'Siburn', 'psiburn6', 'psiburn6');
-insert into users (first_name, last_name, username, pseudonym) values ('Renata', 'Huison', 'rhuison7', 'rhuison7');
-insert into users (first_name, last_name, username, pseudonym) values ('Laney', 'Keemer', 'lkeemer8', 'lkeemer8');
-insert into users (first_name, last_name, username, pseudonym) values ('Tani', 'Hebbron', 'thebbron9', 'thebbron9');
-insert into users (first_name, last_name, username, pseudonym) values ('Elmore', 'Dumphrey', 'edumphreya', 'edumphreya');
-insert into users (first_name, last_name, username, pseudonym) values ('Dede', 'Depport', 'ddepportb', 'ddepportb');
-insert into users (first_name, last_name, username, pseudonym) values ('Lissy', 'Iorillo', 'liorilloc', 'liorilloc');
-insert into users (first_name, last_name, username, pseudonym) values ('Erma', 'Safhill', 'esafhilld', 'esafhilld');
-insert into users (first_name, last_name, username, pseudonym) values ('Suzie', 'Niblo', 'snibloe', 'snibloe');
-insert into users (first_name, last_name, username, pseudonym) values ('Gusella', 'Jowsey', 'gjowseyf', 'gjowseyf');
-insert into users (first_name, last_name, username, pseudonym) values ('Courtney', 'Aartsen', 'caartseng', 'caartseng');
-insert into users (first_name, last_name, username, pseudonym) values ('Demetrius', 'Winkless', 'dwinklessh', 'dwinklessh');
-insert into users (first_name, last_name, username, pseudonym) values ('Bertrando', 'Welden', 'bweldeni', 'bweldeni');
-insert into users (first_name, last_name, username, pseudonym) values ('Isabel', 'Rothon', 'irothonj', 'irothonj');
-insert into users (first_name, last_name, username, pseudonym) values ('Debor', 'Brewett', 'dbrewettk', 'dbrewettk');
-insert into users (first_name, last_name, username, pseudonym) values ('Deane', 'Brion', 'dbrionl', 'dbrionl');
-insert into users (first_name, last_name, username, pseudonym) values ('Monroe', 'Songist', 'msongistm', 'msongistm');
-insert into users (first_name, last_name, username, pseudonym) values ('Elliot', 'Hunter', 'ehuntern', 'ehuntern');
-insert into users (first_name, last_name, username, pseudonym) values ('Friederike', 'Bourgeois', 'fbourgeoiso', 'fbourgeoiso');
-insert into users (first_name, last_name, username, pseudonym) values ('Abelard', 'Tolefree', 'atolefreep', 'atolefreep');
-insert into users (first_name, last_name, username, pseudonym) values ('Charlie', 'Mildmott', 'cmildmottq', 'cmildmottq');
-insert into users (first_name, last_name, username, pseudonym) values ('Archy', 'Siggery', 'asiggeryr', 'asiggeryr');
-insert into users (first_name, last_name, username, pseudonym) values ('Nertie', 'Twells', 'ntwellss', 'ntwellss');
-insert into users (first_name, last_name, username, pseudonym) values ('Zilvia', 'Sammonds', 'zsammondst', 'zsammondst');
-insert into users (first_name, last_name, username, pseudonym) values ('Sanson', 'Beddoes', 'sbeddoesu', 'sbeddoesu');
-insert into users (first_name, last_name, username, pseudonym) values ('Shaine', 'Nussey', 'snusseyv', 'snusseyv');
-insert into users (first_name, last_name, username, pseudonym) values ('Marten', 'Walburn', 'mwalburnw', 'mwalburnw');
-insert into users (first_name, last_name, username, pseudonym) values ('Claudina', 'Cowndley', 'ccowndleyx', 'ccowndleyx');
-insert into users (first_name, last_name, username, pseudonym) values ('Edita', 'Pidon', 'epidony', 'epidony');
-insert into users (first_name, last_name, username, pseudonym) values ('Shena', 'Befroy', 'sbefroyz', 'sbefroyz');
-insert into users (first_name, last_name, username, pseudonym) values ('May', 'Valentine', 'mvalentine10', 'mvalentine10');
-insert into users (first_name, last_name, username, pseudonym) values ('Bridgette', 'Corthes', 'bcorthes11', 'bcorthes11');
-insert into users (first_name, last_name, username, pseudonym) values ('Samuele', 'Blackway', 'sblackway12', 'sblackway12');
-insert into users (first_name, last_name, username, pseudonym) values ('Aldric', 'Cockerton', 'acockerton13', 'acockerton13');
-insert into users (first_name, last_name, username, pseudonym) values ('Blythe', 'Lennox', 'blennox14', 'blennox14');
-insert into users (first_name, last_name, username, pseudonym) values ('Otto', 'Camillo', 'ocamillo15', 'ocamillo15');
-insert into users (first_name, last_name, username, pseudonym) values ('Sterling', 'Skeffington', 'sskeffington16', 'sskeffington16');
-insert into users (first_name, last_name, username, pseudonym) values ('Ernesta', 'Wallentin', 'ewallentin17', 'ewallentin17');
-insert into users (first_name, last_name, username, pseudonym) values ('Ambrosius', 'Sandeson', 'asandeson18', 'asandeson18');
-insert into users (first_name, last_name, username, pseudonym) values ('Otha', 'Blunsen', 'oblunsen19', 'oblunsen19');
-insert into users (first_name, last_name, username, pseudonym) values ('Onfroi', 'Cauthra', 'ocauthra1a', 'ocauthra1a');
-insert into users (first_name, last_name, username, pseudonym) values ('Silvie', 'Bentote', 'sbentote1b', 'sbentote1b');
-insert into users (first_name, last_name, username, pseudonym) values ('Myrtice', 'Knibb', 'mknibb1c', 'mknibb1c');
-insert into users (first_name, last_name, username, pseudonym) values ('Orran', 'Maffin', 'omaffin1d', 'omaffin1d');
-insert into users (first_name, last_name, username, pseudonym) values ('Dredi', 'Oades', 'doades1e', 'doades1e');
-insert into users (first_name, last_name, username, pseudonym) values ('Loutitia', 'Brooke', 'lbrooke1f', 'lbrooke1f');
-insert into users (first_name, last_name, username, pseudonym) values ('Araldo', 'Lerigo', 'alerigo1g', 'alerigo1g');
-insert into users (first_name, last_name, username, pseudonym) values ('Lulita', 'Van Der Weedenburg', 'lvanderweedenburg1h', 'lvanderweedenburg1h');
-insert into users (first_name, last_name, username, pseudonym) values ('Andie', 'Greathead', 'agreathead1i', 'agreathead1i');
-insert into users (first_name, last_name, username, pseudonym) values ('Rozina', 'Brimm', 'rbrimm1j', 'rbrimm1j');
-insert into users (first_name, last_name, username, pseudonym) values ('Winslow', 'Keyse', 'wkeyse1k', 'wkeyse1k');
-insert into users (first_name, last_name, username, pseudonym) values ('Alisun', 'Speed', 'aspeed1l', 'aspeed1l');
-insert into users (first_name, last_name, username, pseudonym) values ('Bambi', 'Fabbri', 'bfabbri1m', 'bfabbri1m');
-insert into users (first_name, last_name, username, pseudonym) values ('Ladonna', 'McKinless', 'lmckinless1n', 'lmckinless1n');
-insert into users (first_name, last_name, username, pseudonym) values ('Herschel', 'Eplett', 'heplett1o', 'heplett1o');
-insert into users (first_name, last_name, username, pseudonym) values ('Dolly', 'Hlavecek', 'dhlavecek1p', 'dhlavecek1p');
-insert into users (first_name, last_name, username, pseudonym) values ('Willyt', 'Corkan', 'wcorkan1q', 'wcorkan1q');
-insert into users (first_name, last_name, username, pseudonym) values ('Timothea', 'Le Cornu', 'tlecornu1r', 'tlecornu1r');
-insert into users (first_name, last_name, username, pseudonym) values ('Krystalle', 'Jenicke', 'kjenicke1s', 'kjenicke1s');
-insert into users (first_name, last_name, username, pseudonym) values ('Dulcea', 'Ninnoli', 'dninnoli1t', 'dninnoli1t');
-insert into users (first_name, last_name, username, pseudonym) values ('Justinn', 'Squeers', 'jsqueers1u', 'jsqueers1u');
-insert into users (first_name, last_name, username, pseudonym) values ('Isabel', 'Crocken', 'icrocken1v', 'icrocken1v');
-insert into users (first_name, last_name, username, pseudonym) values ('Chadwick', 'Lancetter', 'clancetter1w', 'clancetter1w');
-insert into users (first_name, last_name, username, pseudonym) values ('Menard', 'Albinson', 'malbinson1x', 'malbinson1x');
-insert into users (first_name, last_name, username, pseudonym) values ('Darbee', 'Shervington', 'dshervington1y', 'dshervington1y');
-insert into users (first_name, last_name, username, pseudonym) values ('Dannel', 'Dunster', 'ddunster1z', 'ddunster1z');
-insert into users (first_name, last_name, username, pseudonym) values ('Rockie', 'Estcot', 'restcot20', 'restcot20');
-insert into users (first_name, last_name, username, pseudonym) values ('Rudiger', 'Beston', 'rbeston21', 'rbeston21');
-insert into users (first_name, last_name, username, pseudonym) values ('Helaina', 'Maunders', 'hmaunders22', 'hmaunders22');
-insert into users (first_name, last_name, username, pseudonym) values ('Zitella', 'Caruth', 'zcaruth23', 'zcaruth23');
-insert into users (first_name, last_name, username, pseudonym) values ('Sherlock', 'Ambrogetti', 'sambrogetti24', 'sambrogetti24');
-insert into users (first_name, last_name, username, pseudonym) values ('Haleigh', 'Mathwen', 'hmathwen25', 'hmathwen25');
-insert into users (first_name, last_name, username, pseudonym) values ('Marji', 'Tunkin', 'mtunkin26', 'mtunkin26');
-insert into users (first_name, last_name, username, pseudonym) values ('Brantley', 'Slobom', 'bslobom27', 'bslobom27');
-insert into users (first_name, last_name, username, pseudonym) values ('Starlene', 'McKendo', 'smckendo28', 'smckendo28');
-insert into users (first_name, last_name, username, pseudonym) values ('Ambur', 'Jiruch', 'ajiruch29', 'ajiruch29');
-insert into users (first_name, last_name, username, pseudonym) values ('Brianne', 'Meekins', 'bmeekins2a', 'bmeekins2a');
-insert into users (first_name, last_name, username, pseudonym) values ('Rorie', 'Dudney', 'rdudney2b', 'rdudney2b');
-insert into users (first_name, last_name, username, pseudonym) values ('Spense', 'Janusz', 'sjanusz2c', 'sjanusz2c');
-insert into users (first_name, last_name, username, pseudonym) values ('Jodi', 'Ganderton', 'jganderton2d', 'jganderton2d');
-insert into users (first_name, last_name, username, pseudonym) values ('Nelia', 'Ferrea', 'nferrea2e', 'nferrea2e');
-insert into users (first_name, last_name, username, pseudonym) values ('Nate', 'Skentelbury', 'nskentelbury2f', 'nskentelbury2f');
-insert into users (first_name, last_name, username, pseudonym) values ('Loria', 'Nodin', 'lnodin2g', 'lnodin2g');
-insert into users (first_name, last_name, username, pseudonym) values ('Lionel', 'Bucktharp', 'lbucktharp2h', 'lbucktharp2h');
-insert into users (first_name, last_name, username, pseudonym) values ('Rhody', 'Maffini', 'rmaffini2i', 'rmaffini2i');
-insert into users (first_name, last_name, username, pseudonym) values ('Vilma', 'Blumer', 'vblumer2j', 'vblumer2j');
-insert into users (first_name, last_name, username, pseudonym) values ('Jacinthe', 'Mc Pake', 'jmcpake2k', 'jmcpake2k');
-insert into users (first_name, last_name, username, pseudonym) values ('Toby', 'Dudeney', 'tdudeney2l', 'tdudeney2l');
-insert into users (first_name, last_name, username, pseudonym) values ('Sue', 'Rutgers', 'srutgers2m', 'srutgers2m');
-insert into users (first_name, last_name, username, pseudonym) values ('Web', 'Tegeller', 'wtegeller2n', 'wtegeller2n');
-insert into users (first_name, last_name, username, pseudonym) values ('Ana', 'Trythall', 'atrythall2o', 'atrythall2o');
-insert into users (first_name, last_name, username, pseudonym) values ('Kile', 'Volette', 'kvolette2p', 'kvolette2p');
-insert into users (first_name, last_name, username, pseudonym) values ('Korie', 'Showering', 'kshowering2q', 'kshowering2q');
-insert into users (first_name, last_name, username, pseudonym) values ('Moe', 'Ferentz', 'mferentz2r', 'mferentz2r');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Celesta', 'Shitliffe', 'cshitliffe0', 'cshitliffe0', 'cshitliffe0');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Elaina', 'Lintot', 'elintot1', 'elintot1', 'elintot1');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Sandor', 'Vannozzii', 'svannozzii2', 'svannozzii2', 'svannozzii2');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Evin', 'Gillespie', 'egillespie3', 'egillespie3', 'egillespie3');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Tabina', 'L'' Anglois', 'tlanglois4', 'tlanglois4', 'tlanglois4');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Lindie', 'Minto', 'lminto5', 'lminto5', 'lminto5');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Port', 'Siburn', 'psiburn6', 'psiburn6', 'psiburn6');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Renata', 'Huison', 'rhuison7', 'rhuison7', 'rhuison7');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Laney', 'Keemer', 'lkeemer8', 'lkeemer8', 'lkeemer8');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Tani', 'Hebbron', 'thebbron9', 'thebbron9', 'thebbron9');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Elmore', 'Dumphrey', 'edumphreya', 'edumphreya', 'edumphreya');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Dede', 'Depport', 'ddepportb', 'ddepportb', 'ddepportb');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Lissy', 'Iorillo', 'liorilloc', 'liorilloc', 'liorilloc');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Erma', 'Safhill', 'esafhilld', 'esafhilld', 'esafhilld');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Suzie', 'Niblo', 'snibloe', 'snibloe', 'snibloe');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Gusella', 'Jowsey', 'gjowseyf', 'gjowseyf', 'gjowseyf');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Courtney', 'Aartsen', 'caartseng', 'caartseng', 'caartseng');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Demetrius', 'Winkless', 'dwinklessh', 'dwinklessh', 'dwinklessh');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Bertrando', 'Welden', 'bweldeni', 'bweldeni', 'bweldeni');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Isabel', 'Rothon', 'irothonj', 'irothonj', 'irothonj');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Debor', 'Brewett', 'dbrewettk', 'dbrewettk', 'dbrewettk');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Deane', 'Brion', 'dbrionl', 'dbrionl', 'dbrionl');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Monroe', 'Songist', 'msongistm', 'msongistm', 'msongistm');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Elliot', 'Hunter', 'ehuntern', 'ehuntern', 'ehuntern');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Friederike', 'Bourgeois', 'fbourgeoiso', 'fbourgeoiso', 'fbourgeoiso');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Abelard', 'Tolefree', 'atolefreep', 'atolefreep', 'atolefreep');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Charlie', 'Mildmott', 'cmildmottq', 'cmildmottq', 'cmildmottq');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Archy', 'Siggery', 'asiggeryr', 'asiggeryr', 'asiggeryr');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Nertie', 'Twells', 'ntwellss', 'ntwellss', 'ntwellss');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Zilvia', 'Sammonds', 'zsammondst', 'zsammondst', 'zsammondst');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Sanson', 'Beddoes', 'sbeddoesu', 'sbeddoesu', 'sbeddoesu');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Shaine', 'Nussey', 'snusseyv', 'snusseyv', 'snusseyv');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Marten', 'Walburn', 'mwalburnw', 'mwalburnw', 'mwalburnw');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Claudina', 'Cowndley', 'ccowndleyx', 'ccowndleyx', 'ccowndleyx');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Edita', 'Pidon', 'epidony', 'epidony', 'epidony');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Shena', 'Befroy', 'sbefroyz', 'sbefroyz', 'sbefroyz');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('May', 'Valentine', 'mvalentine10', 'mvalentine10', 'mvalentine10');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Bridgette', 'Corthes', 'bcorthes11', 'bcorthes11', 'bcorthes11');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Samuele', 'Blackway', 'sblackway12', 'sblackway12', 'sblackway12');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Aldric', 'Cockerton', 'acockerton13', 'acockerton13', 'acockerton13');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Blythe', 'Lennox', 'blennox14', 'blennox14', 'blennox14');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Otto', 'Camillo', 'ocamillo15', 'ocamillo15', 'ocamillo15');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Sterling', 'Skeffington', 'sskeffington16', 'sskeffington16', 'sskeffington16');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Ernesta', 'Wallentin', 'ewallentin17', 'ewallentin17', 'ewallentin17');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Ambrosius', 'Sandeson', 'asandeson18', 'asandeson18', 'asandeson18');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Otha', 'Blunsen', 'oblunsen19', 'oblunsen19', 'oblunsen19');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Onfroi', 'Cauthra', 'ocauthra1a', 'ocauthra1a', 'ocauthra1a');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Silvie', 'Bentote', 'sbentote1b', 'sbentote1b', 'sbentote1b');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Myrtice', 'Knibb', 'mknibb1c', 'mknibb1c', 'mknibb1c');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Orran', 'Maffin', 'omaffin1d', 'omaffin1d', 'omaffin1d');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Dredi', 'Oades', 'doades1e', 'doades1e', 'doades1e');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Loutitia', 'Brooke', 'lbrooke1f', 'lbrooke1f', 'lbrooke1f');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Araldo', 'Lerigo', 'alerigo1g', 'alerigo1g', 'alerigo1g');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Lulita', 'Van Der Weedenburg', 'lvanderweedenburg1h', 'lvanderweedenburg1h', 'lvanderweedenburg1h');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Andie', 'Greathead', 'agreathead1i', 'agreathead1i', 'agreathead1i');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Rozina', 'Brimm', 'rbrimm1j', 'rbrimm1j', 'rbrimm1j');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Winslow', 'Keyse', 'wkeyse1k', 'wkeyse1k', 'wkeyse1k');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Alisun', 'Speed', 'aspeed1l', 'aspeed1l', 'aspeed1l');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Bambi', 'Fabbri', 'bfabbri1m', 'bfabbri1m', 'bfabbri1m');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Ladonna', 'McKinless', 'lmckinless1n', 'lmckinless1n', 'lmckinless1n');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Herschel', 'Eplett', 'heplett1o', 'heplett1o', 'heplett1o');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Dolly', 'Hlavecek', 'dhlavecek1p', 'dhlavecek1p', 'dhlavecek1p');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Willyt', 'Corkan', 'wcorkan1q', 'wcorkan1q', 'wcorkan1q');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Timothea', 'Le Cornu', 'tlecornu1r', 'tlecornu1r', 'tlecornu1r');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Krystalle', 'Jenicke', 'kjenicke1s', 'kjenicke1s', 'kjenicke1s');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Dulcea', 'Ninnoli', 'dninnoli1t', 'dninnoli1t', 'dninnoli1t');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Justinn', 'Squeers', 'jsqueers1u', 'jsqueers1u', 'jsqueers1u');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Isabel', 'Crocken', 'icrocken1v', 'icrocken1v', 'icrocken1v');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Chadwick', 'Lancetter', 'clancetter1w', 'clancetter1w', 'clancetter1w');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Menard', 'Albinson', 'malbinson1x', 'malbinson1x', 'malbinson1x');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Darbee', 'Shervington', 'dshervington1y', 'dshervington1y', 'dshervington1y');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Dannel', 'Dunster', 'ddunster1z', 'ddunster1z', 'ddunster1z');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Rockie', 'Estcot', 'restcot20', 'restcot20', 'restcot20');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Rudiger', 'Beston', 'rbeston21', 'rbeston21', 'rbeston21');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Helaina', 'Maunders', 'hmaunders22', 'hmaunders22', 'hmaunders22');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Zitella', 'Caruth', 'zcaruth23', 'zcaruth23', 'zcaruth23');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Sherlock', 'Ambrogetti', 'sambrogetti24', 'sambrogetti24', 'sambrogetti24');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Haleigh', 'Mathwen', 'hmathwen25', 'hmathwen25', 'hmathwen25');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Marji', 'Tunkin', 'mtunkin26', 'mtunkin26', 'mtunkin26');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Brantley', 'Slobom', 'bslobom27', 'bslobom27', 'bslobom27');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Starlene', 'McKendo', 'smckendo28', 'smckendo28', 'smckendo28');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Ambur', 'Jiruch', 'ajiruch29', 'ajiruch29', 'ajiruch29');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Brianne', 'Meekins', 'bmeekins2a', 'bmeekins2a', 'bmeekins2a');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Rorie', 'Dudney', 'rdudney2b', 'rdudney2b', 'rdudney2b');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Spense', 'Janusz', 'sjanusz2c', 'sjanusz2c', 'sjanusz2c');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Jodi', 'Ganderton', 'jganderton2d', 'jganderton2d', 'jganderton2d');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Nelia', 'Ferrea', 'nferrea2e', 'nferrea2e', 'nferrea2e');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Nate', 'Skentelbury', 'nskentelbury2f', 'nskentelbury2f', 'nskentelbury2f');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Loria', 'Nodin', 'lnodin2g', 'lnodin2g', 'lnodin2g');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Lionel', 'Bucktharp', 'lbucktharp2h', 'lbucktharp2h', 'lbucktharp2h');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Rhody', 'Maffini', 'rmaffini2i', 'rmaffini2i', 'rmaffini2i');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Vilma', 'Blumer', 'vblumer2j', 'vblumer2j', 'vblumer2j');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Jacinthe', 'Mc Pake', 'jmcpake2k', 'jmcpake2k', 'jmcpake2k');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Toby', 'Dudeney', 'tdudeney2l', 'tdudeney2l', 'tdudeney2l');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Sue', 'Rutgers', 'srutgers2m', 'srutgers2m', 'srutgers2m');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Web', 'Tegeller', 'wtegeller2n', 'wtegeller2n', 'wtegeller2n');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Ana', 'Trythall', 'atrythall2o', 'atrythall2o', 'atrythall2o');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Kile', 'Volette', 'kvolette2p', 'kvolette2p', 'kvolette2p');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Korie', 'Showering', 'kshowering2q', 'kshowering2q', 'kshowering2q');
+insert into users (first_name, last_name, username, pseudonym, pseudonym_skeleton) values ('Moe', 'Ferentz', 'mferentz2r', 'mferentz2r', 'mferentz2r');
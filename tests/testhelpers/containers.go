@@ -2,10 +2,12 @@ package testhelpers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,7 +31,24 @@ func CreatePostgresContainer(ctx context.Context, n *testcontainers.DockerNetwor
 		postgres.WithDatabase("drop-auth"),
 		postgres.WithUsername("postgres"),
 		postgres.WithPassword("postgres"),
-		postgres.WithInitScripts(filepath.Join("..", "internal", "db", "migrations", "000001_initial.up.sql")),
+		postgres.WithInitScripts(
+			filepath.Join("..", "internal", "db", "migrations", "000001_initial.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000002_admin_requests.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000003_organizations.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000004_users_soft_delete.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000005_users_anonymization.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000006_users_pseudonym_skeleton.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000007_pseudonym_history.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000008_users_telegram_profile.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000009_users_profile_source.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000010_users_metadata.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000011_users_search.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000012_audit_events.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000013_users_deletions.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000014_events_outbox.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000015_users_username_optional.up.sql"),
+			filepath.Join("..", "internal", "db", "migrations", "000016_pseudonym_skeleton_substitutes.up.sql"),
+		),
 		postgres.BasicWaitStrategies(),
 		network.WithNetwork(nil, n),
 	)
@@ -143,9 +162,27 @@ type Option func(req *http.Request)
 func WithTmaToken(params map[string]string) Option {
 	return func(req *http.Request) {
 		authDate := time.Now().Add(time.Hour)
+		user := map[string]any{
+			"username":   params["username"],
+			"first_name": params["first_name"],
+			"last_name":  params["last_name"],
+		}
+		if id, err := strconv.ParseInt(params["id"], 10, 64); err == nil {
+			user["id"] = id
+		}
+		if params["language_code"] != "" {
+			user["language_code"] = params["language_code"]
+		}
+		if params["is_premium"] == "true" {
+			user["is_premium"] = true
+		}
+		if params["photo_url"] != "" {
+			user["photo_url"] = params["photo_url"]
+		}
+		userJSON, _ := json.Marshal(user)
 		payload := map[string]string{
 			"auth_date": fmt.Sprintf("%d", authDate.Unix()),
-			"user":      fmt.Sprintf(`{"username":%q,"first_name":%q,"last_name":%q}`, params["username"], params["first_name"], params["last_name"]),
+			"user":      string(userJSON),
 		}
 		hash := initdata.Sign(payload, tmaSecret, authDate)
 		token := "auth_date=" + payload["auth_date"] + "&user=" + payload["user"] + "&hash=" + hash
@@ -153,6 +190,12 @@ func WithTmaToken(params map[string]string) Option {
 	}
 }
 
+func WithHeader(key, value string) Option {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
 func WithBearerToken(token string) Option {
 	return func(req *http.Request) {
 		req.Header.Set("authorization", "bearer "+token)
@@ -190,6 +233,10 @@ func (b *BackendContainer) PatchRequest(path string, body string, opts ...Option
 	return b.bodyRequest(http.MethodPatch, path, body, opts...)
 }
 
+func (b *BackendContainer) PutRequest(path string, body string, opts ...Option) (*http.Response, error) {
+	return b.bodyRequest(http.MethodPut, path, body, opts...)
+}
+
 func (b *BackendContainer) urlvalsRequest(method, path string, vals url.Values, opts ...Option) (*http.Response, error) {
 	if vals == nil {
 		vals = url.Values{}